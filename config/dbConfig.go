@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -45,7 +46,10 @@ func OpenDB() (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		dbUser, dbPassword, dbHost, dbPort, dbName)
 
-	gormDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// TranslateError turns driver errors into gorm sentinels (gorm.ErrDuplicatedKey,
+	// gorm.ErrForeignKeyViolated), so services check unique-index races with errors.Is
+	// instead of matching MySQL error text or codes.
+	gormDB, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open gorm DB: %w", err)
 	}
@@ -62,6 +66,14 @@ func ConnectDB() {
 		log.Fatal("Failed to connect to the database:", err)
 	}
 
+	// Scope clinic-owned models for handles returned by ClinicDB
+	if err := registerTenantCallbacks(DB); err != nil {
+		log.Fatal("Failed to register tenant callbacks:", err)
+	}
+
+	// Catalogs that predate versioning are published as version 1 once the versions table exists
+	seedCatalog := !DB.Migrator().HasTable(&models.CatalogVersion{})
+
 	// Run migrations for all models
 	if err := DB.AutoMigrate(
 		&models.User{},
@@ -83,6 +95,9 @@ func ConnectDB() {
 		&models.Treatment{},
 		&models.Area{},
 		&models.SideArea{},
+		&models.CatalogVersion{},
+		// localized catalog/onboarding content
+		&models.ContentTranslation{},
 		// clinic tables
 		&models.Clinic{},
 		&models.ClinicRole{},
@@ -94,6 +109,49 @@ func ConnectDB() {
 		&models.ClinicSideArea{},
 		&models.ClinicUserSideArea{},
 		&models.ClinicUserProfile{},
+		// append-only audit trail
+		&models.AuditLog{},
+		// background CSV/XLSX exports
+		&models.ExportJob{},
+		// clinic self-service applications
+		&models.ClinicApplication{},
+		&models.ClinicApplicationDocument{},
+		// clinic public profile edits awaiting approval
+		&models.ClinicProfileChange{},
+		// appointment booking
+		&models.Appointment{},
+		&models.AppointmentItem{},
+		&models.AppointmentSlotLock{},
+		// scheduling metadata
+		&models.TreatmentResourceRequirement{},
+		// clinic rooms and equipment
+		&models.ClinicResource{},
+		&models.ClinicTreatmentRequirement{},
+		&models.AppointmentResource{},
+		// appointment reminders
+		&models.ClinicReminderRule{},
+		&models.AppointmentReminder{},
+		// cancellation policies and customer charges
+		&models.ClinicCancellationPolicy{},
+		&models.AppointmentPolicy{},
+		&models.CustomerCharge{},
+		// waitlist and slot offers
+		&models.WaitlistEntry{},
+		&models.WaitlistItem{},
+		&models.WaitlistOffer{},
+		// calendar feeds
+		&models.CalendarFeed{},
+		// clinic patient records
+		&models.ClinicPatient{},
+		// treatment records
+		&models.TreatmentRecord{},
+		&models.TreatmentRecordItem{},
+		&models.TreatmentRecordAddendum{},
+		// availability schedules
+		&models.ClinicOpeningHour{},
+		&models.ClinicHoliday{},
+		&models.PractitionerAvailability{},
+		&models.PractitionerAvailabilityException{},
 	); err != nil {
 		// attempt to close DB on migration error
 		if cerr := CloseDB(); cerr != nil {
@@ -105,6 +163,9 @@ func ConnectDB() {
 	SeedRBACData()
 	SeedClinicRoles()
 	SeedDiscoveryData()
+	if seedCatalog {
+		SeedCatalogVersion()
+	}
 	log.Println("Database connection established")
 }
 
@@ -209,6 +270,9 @@ func SeedRBACData() {
 		// Profile
 		{"profile.view", "View own profile"},
 		{"profile.edit", "Edit own profile"},
+
+		// Audit
+		{"audit.view", "View the audit log"},
 	}
 
 	// Create permissions (idempotent)
@@ -241,6 +305,7 @@ func SeedRBACData() {
 				"admins.view", "admins.create", "admins.edit", "admins.delete",
 				"appointments.view", "appointments.edit", "appointments.delete",
 				"profile.view", "profile.edit",
+				"audit.view",
 			},
 		},
 		{
@@ -325,9 +390,17 @@ func SeedClinicRoles() {
 		{"clinic.view", "View clinic settings"},
 		{"clinic.edit", "Edit clinic settings"},
 
+		// Schedules (opening hours, holidays, practitioner availability)
+		{"schedules.view", "View clinic schedules"},
+		{"schedules.edit", "Edit clinic schedules"},
+
 		// Area/Treatment Management
 		{"areas.edit", "Edit treatment areas and pricing"},
 
+		// Payments (deposits and cancellation/no-show fees)
+		{"payments.view", "View customer charges"},
+		{"payments.edit", "Collect and waive customer charges"},
+
 		// Reports
 		{"reports.view", "View reports and analytics"},
 		{"reports.export", "Export reports"},
@@ -335,6 +408,9 @@ func SeedClinicRoles() {
 		// Profile
 		{"profile.view", "View own profile"},
 		{"profile.edit", "Edit own profile"},
+
+		// Audit
+		{"audit.view", "View the clinic audit log"},
 	}
 
 	// Create clinic permissions (idempotent)
@@ -364,9 +440,12 @@ func SeedClinicRoles() {
 				"patients.view", "patients.create", "patients.edit", "patients.delete",
 				"treatment_records.view", "treatment_records.create", "treatment_records.edit",
 				"clinic.view", "clinic.edit",
+				"schedules.view", "schedules.edit",
 				"areas.edit",
+				"payments.view", "payments.edit",
 				"reports.view", "reports.export",
 				"profile.view", "profile.edit",
+				"audit.view",
 			},
 		},
 		{
@@ -378,7 +457,9 @@ func SeedClinicRoles() {
 				"patients.view", "patients.create", "patients.edit",
 				"treatment_records.view",
 				"clinic.view",
+				"schedules.view", "schedules.edit",
 				"areas.edit",
+				"payments.view", "payments.edit",
 				"reports.view",
 				"profile.view", "profile.edit",
 			},
@@ -390,6 +471,7 @@ func SeedClinicRoles() {
 				"appointments.view", "appointments.edit",
 				"patients.view", "patients.edit",
 				"treatment_records.view", "treatment_records.create", "treatment_records.edit",
+				"schedules.view",
 				"profile.view", "profile.edit",
 			},
 		},
@@ -400,6 +482,7 @@ func SeedClinicRoles() {
 				"appointments.view",
 				"patients.view",
 				"treatment_records.view", "treatment_records.create",
+				"schedules.view",
 				"profile.view", "profile.edit",
 			},
 		},
@@ -409,6 +492,8 @@ func SeedClinicRoles() {
 			Permissions: []string{
 				"appointments.view", "appointments.create", "appointments.edit",
 				"patients.view", "patients.create",
+				"schedules.view",
+				"payments.view", "payments.edit",
 				"profile.view", "profile.edit",
 			},
 		},
@@ -439,6 +524,46 @@ func SeedClinicRoles() {
 	log.Println("Clinic roles and permissions seeded successfully")
 }
 
+// SeedCatalogVersion publishes the existing treatment tables as catalog version 1 so public
+// endpoints keep serving the catalog after versioning is introduced. It does nothing when a
+// version already exists or there are no treatments.
+func SeedCatalogVersion() {
+	db := DB
+	if db == nil {
+		log.Println("DB not initialized, skipping catalog version seeding")
+		return
+	}
+
+	var versions, treatmentCount int64
+	db.Model(&models.CatalogVersion{}).Count(&versions)
+	db.Model(&models.Treatment{}).Count(&treatmentCount)
+	if versions > 0 || treatmentCount == 0 {
+		return
+	}
+
+	var treatments []models.Treatment
+	if err := db.Preload("Areas", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id")
+	}).Preload("Areas.SideAreas", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id")
+	}).Order("id").Find(&treatments).Error; err != nil {
+		log.Printf("catalog version seeding failed: %v", err)
+		return
+	}
+	snapshot, err := json.Marshal(treatments)
+	if err != nil {
+		log.Printf("catalog version seeding failed: %v", err)
+		return
+	}
+	if err := db.Create(&models.CatalogVersion{
+		Version:  1,
+		Snapshot: string(snapshot),
+		Notes:    "Initial catalog",
+	}).Error; err != nil {
+		log.Printf("catalog version seeding failed: %v", err)
+	}
+}
+
 // SeedDiscoveryData seeds sample clinics, doctors, and treatment mappings (idempotent)
 func SeedDiscoveryData() {
 	db := DB
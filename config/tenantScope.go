@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tenantClinicKey is the gorm setting that carries the clinic a handle is scoped to
+const tenantClinicKey = "tenant:clinic_id"
+
+// ErrCrossTenantWrite is returned when a clinic-scoped handle tries to write another clinic's rows
+var ErrCrossTenantWrite = errors.New("cross-tenant write rejected")
+
+// ClinicDB returns a DB handle scoped to a single clinic.
+// Every query, update and delete on a model with a clinic_id column is filtered
+// to this clinic, and creates/updates carrying a different clinic_id are rejected.
+// Models without a clinic_id column (treatments, side areas, roles...) are unaffected.
+func ClinicDB(clinicID uint64) *gorm.DB {
+	if DB == nil {
+		return nil
+	}
+	return DB.Set(tenantClinicKey, clinicID).Session(&gorm.Session{})
+}
+
+// registerTenantCallbacks installs the clinic scoping callbacks on the given DB
+func registerTenantCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("tenant:scope_query", tenantScopeFilter); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:scope_row", tenantScopeFilter); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:scope_delete", tenantScopeFilter); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:scope_update", tenantScopeUpdate); err != nil {
+		return err
+	}
+	return cb.Create().Before("gorm:create").Register("tenant:scope_create", tenantScopeCreate)
+}
+
+// tenantClinicID returns the clinic the statement is scoped to and its clinic_id field, if both exist
+func tenantClinicID(db *gorm.DB) (uint64, bool) {
+	if db.Statement.Schema == nil {
+		return 0, false
+	}
+	v, ok := db.Get(tenantClinicKey)
+	if !ok {
+		return 0, false
+	}
+	clinicID, ok := v.(uint64)
+	if !ok || db.Statement.Schema.LookUpField("clinic_id") == nil {
+		return 0, false
+	}
+	return clinicID, true
+}
+
+// tenantScopeFilter adds "<table>.clinic_id = ?" to reads and deletes
+func tenantScopeFilter(db *gorm.DB) {
+	clinicID, ok := tenantClinicID(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: "clinic_id"}, Value: clinicID},
+	}})
+}
+
+// tenantScopeUpdate filters updates to the clinic and rejects moving rows to another clinic
+func tenantScopeUpdate(db *gorm.DB) {
+	clinicID, ok := tenantClinicID(db)
+	if !ok {
+		return
+	}
+
+	switch dest := db.Statement.Dest.(type) {
+	case map[string]interface{}:
+		if v, exists := dest["clinic_id"]; exists && !sameClinic(reflect.ValueOf(v), clinicID) {
+			db.AddError(ErrCrossTenantWrite)
+			return
+		}
+	default:
+		if !checkClinicField(db, reflect.ValueOf(dest), clinicID, false) {
+			db.AddError(ErrCrossTenantWrite)
+			return
+		}
+	}
+
+	tenantScopeFilter(db)
+}
+
+// tenantScopeCreate stamps clinic_id on new rows and rejects rows that belong to another clinic
+func tenantScopeCreate(db *gorm.DB) {
+	clinicID, ok := tenantClinicID(db)
+	if !ok {
+		return
+	}
+	if !checkClinicField(db, db.Statement.ReflectValue, clinicID, true) {
+		db.AddError(ErrCrossTenantWrite)
+	}
+}
+
+// checkClinicField walks a struct or slice of structs and verifies each clinic_id.
+// When fill is true, zero clinic_ids are set to the tenant clinic.
+func checkClinicField(db *gorm.DB, rv reflect.Value, clinicID uint64, fill bool) bool {
+	field := db.Statement.Schema.LookUpField("clinic_id")
+	rv = reflect.Indirect(rv)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !checkClinicField(db, rv.Index(i), clinicID, fill) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		if rv.Type() != db.Statement.Schema.ModelType {
+			return true
+		}
+		value, isZero := field.ValueOf(db.Statement.Context, rv)
+		if isZero {
+			if fill && rv.CanAddr() {
+				if err := field.Set(db.Statement.Context, rv, clinicID); err != nil {
+					db.AddError(err)
+				}
+			}
+			return true
+		}
+		return sameClinic(reflect.ValueOf(value), clinicID)
+	}
+	return true
+}
+
+// sameClinic compares an integer reflect value against the tenant clinic id
+func sameClinic(v reflect.Value, clinicID uint64) bool {
+	v = reflect.Indirect(v)
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == clinicID
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() >= 0 && uint64(v.Int()) == clinicID
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == float64(clinicID)
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"skinSync/models"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// dryRunDB installs a statement-only DB with the tenant callbacks as the package DB
+func dryRunDB(t *testing.T) {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "u:p@tcp(127.0.0.1:1)/x", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registerTenantCallbacks(db); err != nil {
+		t.Fatal(err)
+	}
+	prev := DB
+	DB = db
+	t.Cleanup(func() { DB = prev })
+}
+
+func TestClinicDBScopesStatements(t *testing.T) {
+	dryRunDB(t)
+	tests := []struct {
+		name string
+		run  func(db *gorm.DB) *gorm.DB
+		want string
+	}{
+		{"query", func(db *gorm.DB) *gorm.DB {
+			return db.Where("id = ?", 5).Find(&[]models.ClinicResource{})
+		}, "`clinic_resources`.`clinic_id` = ?"},
+		{"update", func(db *gorm.DB) *gorm.DB {
+			return db.Model(&models.ClinicResource{}).Where("id = ?", 5).Update("name", "A")
+		}, "`clinic_resources`.`clinic_id` = ?"},
+		{"delete", func(db *gorm.DB) *gorm.DB {
+			return db.Where("id = ?", 5).Delete(&models.ClinicHoliday{})
+		}, "`clinic_holidays`.`clinic_id` = ?"},
+		{"bulk delete", func(db *gorm.DB) *gorm.DB {
+			return db.Delete(&models.ClinicOpeningHour{})
+		}, "`clinic_opening_hours`.`clinic_id` = ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := tt.run(ClinicDB(7))
+			if res.Error != nil {
+				t.Fatal(res.Error)
+			}
+			stmt := res.Statement
+			if sql := stmt.SQL.String(); !strings.Contains(sql, tt.want) {
+				t.Errorf("SQL %q does not filter by clinic", sql)
+			}
+			if got := stmt.Vars[len(stmt.Vars)-1]; got != uint64(7) {
+				t.Errorf("clinic_id bound to %v, want 7", got)
+			}
+		})
+	}
+}
+
+func TestClinicDBLeavesGlobalModelsAlone(t *testing.T) {
+	dryRunDB(t)
+	stmt := ClinicDB(7).Where("id = ?", 3).Find(&[]models.Treatment{}).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "clinic_id") {
+		t.Errorf("SQL %q should not filter a model without clinic_id", sql)
+	}
+}
+
+func TestClinicDBCreate(t *testing.T) {
+	dryRunDB(t)
+
+	room := models.ClinicResource{Name: "Room 1"}
+	if err := ClinicDB(7).Create(&room).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if room.ClinicID != 7 {
+		t.Errorf("clinic_id = %d, want it stamped to 7", room.ClinicID)
+	}
+
+	other := models.ClinicResource{ClinicID: 8, Name: "Room 2"}
+	if err := ClinicDB(7).Create(&other).Error; !errors.Is(err, ErrCrossTenantWrite) {
+		t.Errorf("create for another clinic error = %v, want ErrCrossTenantWrite", err)
+	}
+}
+
+func TestClinicDBRejectsMovingRows(t *testing.T) {
+	dryRunDB(t)
+	err := ClinicDB(7).Model(&models.ClinicResource{}).Where("id = ?", 5).
+		Updates(map[string]interface{}{"clinic_id": uint64(8)}).Error
+	if !errors.Is(err, ErrCrossTenantWrite) {
+		t.Errorf("update error = %v, want ErrCrossTenantWrite", err)
+	}
+}
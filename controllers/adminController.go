@@ -1,13 +1,16 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	reqdto "skinSync/dto/request"
 	resdto "skinSync/dto/response"
 	"skinSync/services"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // AdminRegisterHandler handles admin/clinic user registration
@@ -121,3 +124,165 @@ func VerifyPasswordHandler(c echo.Context) error {
 		},
 	})
 }
+
+// ==================== ADMIN USER ADMINISTRATION ====================
+
+// adminUserErrorStatus maps admin administration errors to HTTP status codes
+func adminUserErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrLastSuperAdmin), errors.Is(err, services.ErrAdminSelfAction):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// ListAdminUsersHandler handles GET /admin/admins?q=&role=&status=&page=&page_size=
+func ListAdminUsersHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListAdminUsers(c.QueryParam("q"), c.QueryParam("role"), c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "admins retrieved", Data: list})
+}
+
+// GetAdminUserHandler handles GET /admin/admins/:id
+func GetAdminUserHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid admin id"})
+	}
+
+	admin, err := services.GetAdminUserByID(id)
+	if err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "admin retrieved", Data: admin})
+}
+
+// UpdateAdminUserHandler handles PUT /admin/admins/:id
+func UpdateAdminUserHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid admin id"})
+	}
+
+	var req reqdto.UpdateAdminUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	admin, err := services.UpdateAdminUser(id, req)
+	if err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "admin updated", Data: admin})
+}
+
+// SetAdminStatusHandler handles PATCH /admin/admins/:id/status
+func SetAdminStatusHandler(c echo.Context) error {
+	actorID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid admin id"})
+	}
+
+	var req reqdto.UpdateAdminStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	admin, err := services.SetAdminStatus(uint64(actorID), id, req.Status)
+	if err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "admin status updated", Data: admin})
+}
+
+// ResetAdminPasswordHandler handles POST /admin/admins/:id/reset-password
+func ResetAdminPasswordHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid admin id"})
+	}
+
+	var req reqdto.AdminResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	temporary, err := services.ResetAdminPassword(id, req.NewPassword)
+	if err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{
+		IsSuccess: true,
+		Message:   "password reset",
+		Data:      resdto.AdminPasswordResetResponse{TemporaryPassword: temporary},
+	})
+}
+
+// DeleteAdminUserHandler handles DELETE /admin/admins/:id
+func DeleteAdminUserHandler(c echo.Context) error {
+	actorID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid admin id"})
+	}
+
+	if err := services.DeleteAdminUser(uint64(actorID), id); err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "admin deleted"})
+}
+
+// UpdateAdminMeHandler handles PUT /admin/me
+func UpdateAdminMeHandler(c echo.Context) error {
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+
+	var req reqdto.UpdateAdminProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	admin, err := services.UpdateAdminProfile(uint64(adminID), req)
+	if err != nil {
+		return c.JSON(adminUserErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile updated", Data: admin})
+}
+
+// AdminChangePasswordHandler handles POST /admin/change-password
+func AdminChangePasswordHandler(c echo.Context) error {
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+
+	var req reqdto.AdminChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid payload"})
+	}
+	if req.OldPassword == "" || req.NewPassword == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "old_password and new_password are required"})
+	}
+
+	if err := services.AdminChangePassword(uint64(adminID), req.OldPassword, req.NewPassword); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "password changed successfully, please log in again"})
+}
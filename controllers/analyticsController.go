@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// analyticsRangeFromQuery reads from/to (RFC3339 or YYYY-MM-DD, to is exclusive)
+func analyticsRangeFromQuery(c echo.Context) (services.AnalyticsRange, error) {
+	from, err := parseQueryTime(c.QueryParam("from"))
+	if err != nil {
+		return services.AnalyticsRange{}, errors.New("from must be RFC3339 or YYYY-MM-DD")
+	}
+	to, err := parseQueryTime(c.QueryParam("to"))
+	if err != nil {
+		return services.AnalyticsRange{}, errors.New("to must be RFC3339 or YYYY-MM-DD")
+	}
+	if from != nil && to != nil && !to.After(*from) {
+		return services.AnalyticsRange{}, errors.New("to must be after from")
+	}
+	return services.AnalyticsRange{From: from, To: to}, nil
+}
+
+// GetSignupAnalyticsHandler handles GET /admin/analytics/signups?from=&to=&group_by=day|week|month|year&provider=
+func GetSignupAnalyticsHandler(c echo.Context) error {
+	r, err := analyticsRangeFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	data, err := services.GetSignupAnalytics(r, c.QueryParam("group_by"), c.QueryParam("provider"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "signup analytics", Data: data})
+}
+
+// GetOnboardingAnalyticsHandler handles GET /admin/analytics/onboarding?from=&to=
+func GetOnboardingAnalyticsHandler(c echo.Context) error {
+	r, err := analyticsRangeFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	data, err := services.GetOnboardingAnalytics(r)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "onboarding analytics", Data: data})
+}
+
+// GetClinicAnalyticsHandler handles GET /admin/analytics/clinics?from=&to=&group_by=status|day|week|month|year
+func GetClinicAnalyticsHandler(c echo.Context) error {
+	r, err := analyticsRangeFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	data, err := services.GetClinicAnalytics(r, c.QueryParam("group_by"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic analytics", Data: data})
+}
+
+// GetClinicTreatmentAnalyticsHandler handles GET /admin/analytics/clinic-treatments?page=&page_size=
+func GetClinicTreatmentAnalyticsHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	data, err := services.GetClinicTreatmentAnalytics(page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic treatment analytics", Data: data})
+}
+
+// GetPriceAnalyticsHandler handles GET /admin/analytics/prices?group_by=side_area|syringe_size|area|treatment&treatment_id=&area_id=
+func GetPriceAnalyticsHandler(c echo.Context) error {
+	var treatmentID, areaID uint64
+	if v := c.QueryParam("treatment_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment_id"})
+		}
+		treatmentID = id
+	}
+	if v := c.QueryParam("area_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid area_id"})
+		}
+		areaID = id
+	}
+
+	data, err := services.GetPriceAnalytics(c.QueryParam("group_by"), treatmentID, areaID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "price analytics", Data: data})
+}
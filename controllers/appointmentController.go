@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// appointmentErrorStatus maps booking errors to HTTP status codes
+func appointmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrAppointmentSlotTaken), errors.Is(err, services.ErrAppointmentCustomerBusy),
+		errors.Is(err, services.ErrAppointmentStatusChange), errors.Is(err, services.ErrAppointmentNotReschedulable),
+		errors.Is(err, services.ErrOutsideAvailability), errors.Is(err, services.ErrResourceUnavailable),
+		errors.Is(err, services.ErrPatientWithoutAccount):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// appointmentFilterFromQuery builds an appointment filter from query parameters
+func appointmentFilterFromQuery(c echo.Context) (services.AppointmentFilter, error) {
+	f := services.AppointmentFilter{Status: c.QueryParam("status")}
+	for param, dst := range map[string]*uint64{
+		"clinic_id":       &f.ClinicID,
+		"user_id":         &f.UserID,
+		"practitioner_id": &f.PractitionerID,
+	} {
+		if v := c.QueryParam(param); v != "" {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return f, errors.New("invalid " + param)
+			}
+			*dst = id
+		}
+	}
+	from, err := parseQueryTime(c.QueryParam("from"))
+	if err != nil {
+		return f, errors.New("from must be RFC3339 or YYYY-MM-DD")
+	}
+	to, err := parseQueryTime(c.QueryParam("to"))
+	if err != nil {
+		return f, errors.New("to must be RFC3339 or YYYY-MM-DD")
+	}
+	f.From, f.To = from, to
+	return f, nil
+}
+
+// customerUserID reads the signed-in customer from the auth context
+func customerUserID(c echo.Context) (uint64, bool) {
+	uid, ok := c.Get("user_id").(float64)
+	return uint64(uid), ok
+}
+
+// listAppointments serves a page of appointments for the given scope
+func listAppointments(c echo.Context, f services.AppointmentFilter, viewer string) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListAppointments(f, viewer, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointments retrieved", Data: list})
+}
+
+// ==================== CUSTOMER ====================
+
+// BookAppointmentHandler handles POST /v1/appointments
+func BookAppointmentHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+
+	var req reqdto.CreateAppointmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.BookAppointment(userID, req)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "appointment requested", Data: appt})
+}
+
+// GetMyAppointmentsHandler handles GET /v1/appointments?status=&from=&to=&page=&page_size=
+func GetMyAppointmentsHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	f, err := appointmentFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	f.UserID = userID
+	return listAppointments(c, f, models.AuditActorCustomer)
+}
+
+// GetMyAppointmentHandler handles GET /v1/appointments/:id
+func GetMyAppointmentHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	appt, err := services.GetAppointment(0, userID, id, models.AuditActorCustomer)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment retrieved", Data: appt})
+}
+
+// CancelMyAppointmentHandler handles POST /v1/appointments/:id/cancel
+func CancelMyAppointmentHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	var req reqdto.CancelAppointmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.CancelCustomerAppointment(userID, id, req.Reason)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment cancelled", Data: appt})
+}
+
+// ==================== CLINIC ====================
+
+// GetClinicAppointmentsHandler handles GET /clinic/appointments?status=&practitioner_id=&user_id=&from=&to=&page=&page_size=
+func GetClinicAppointmentsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	f, err := appointmentFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	f.ClinicID = clinicID
+	return listAppointments(c, f, models.AuditActorClinicUser)
+}
+
+// GetClinicAppointmentHandler handles GET /clinic/appointments/:id
+func GetClinicAppointmentHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	appt, err := services.GetAppointment(clinicID, 0, id, models.AuditActorClinicUser)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment retrieved", Data: appt})
+}
+
+// CreateClinicAppointmentHandler handles POST /clinic/appointments
+func CreateClinicAppointmentHandler(c echo.Context) error {
+	clinicID, clinicUserID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	var req reqdto.ClinicCreateAppointmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.ClinicCreateAppointment(clinicID, clinicUserID, req)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "appointment booked", Data: appt})
+}
+
+// RescheduleClinicAppointmentHandler handles PUT /clinic/appointments/:id
+func RescheduleClinicAppointmentHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	var req reqdto.RescheduleAppointmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.RescheduleAppointment(clinicID, id, req)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment updated", Data: appt})
+}
+
+// SetClinicAppointmentStatusHandler handles PATCH /clinic/appointments/:id/status
+// Cancelling has its own endpoint and permission.
+func SetClinicAppointmentStatusHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	var req reqdto.UpdateAppointmentStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	if req.Status == models.AppointmentCancelled {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "use POST /clinic/appointments/:id/cancel to cancel"})
+	}
+
+	appt, err := services.UpdateAppointmentStatus(clinicID, id, models.AuditActorClinicUser, req.Status, req.Reason)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment status updated", Data: appt})
+}
+
+// CancelClinicAppointmentHandler handles POST /clinic/appointments/:id/cancel
+func CancelClinicAppointmentHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	var req reqdto.CancelAppointmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.UpdateAppointmentStatus(clinicID, id, models.AuditActorClinicUser, models.AppointmentCancelled, req.Reason)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment cancelled", Data: appt})
+}
+
+// ==================== ADMIN ====================
+
+// ListAdminAppointmentsHandler handles GET /admin/appointments?clinic_id=&user_id=&practitioner_id=&status=&from=&to=&page=&page_size=
+func ListAdminAppointmentsHandler(c echo.Context) error {
+	f, err := appointmentFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return listAppointments(c, f, models.AuditActorAdmin)
+}
+
+// GetAdminAppointmentHandler handles GET /admin/appointments/:id
+func GetAdminAppointmentHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	appt, err := services.GetAppointment(0, 0, id, models.AuditActorAdmin)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment retrieved", Data: appt})
+}
+
+// SetAdminAppointmentStatusHandler handles PATCH /admin/appointments/:id/status
+func SetAdminAppointmentStatusHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	var req reqdto.UpdateAppointmentStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	appt, err := services.UpdateAppointmentStatus(0, id, models.AuditActorAdmin, req.Status, req.Reason)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment status updated", Data: appt})
+}
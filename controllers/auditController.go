@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parseQueryTime accepts RFC3339 timestamps or plain YYYY-MM-DD dates
+func parseQueryTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// auditFilterFromQuery builds an audit filter from query parameters
+func auditFilterFromQuery(c echo.Context) (services.AuditLogFilter, error) {
+	f := services.AuditLogFilter{
+		ActorType:  c.QueryParam("actor_type"),
+		EntityType: c.QueryParam("entity_type"),
+		EntityID:   c.QueryParam("entity_id"),
+		Action:     c.QueryParam("action"),
+	}
+	if v := c.QueryParam("actor_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return f, errors.New("invalid actor_id")
+		}
+		f.ActorID = id
+	}
+	from, err := parseQueryTime(c.QueryParam("from"))
+	if err != nil {
+		return f, errors.New("from must be RFC3339 or YYYY-MM-DD")
+	}
+	to, err := parseQueryTime(c.QueryParam("to"))
+	if err != nil {
+		return f, errors.New("to must be RFC3339 or YYYY-MM-DD")
+	}
+	f.From, f.To = from, to
+	return f, nil
+}
+
+// ListAuditLogsHandler handles GET /admin/audit-logs?actor_type=&actor_id=&entity_type=&entity_id=&action=&from=&to=&page=&page_size=
+func ListAuditLogsHandler(c echo.Context) error {
+	f, err := auditFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListAuditLogs(f, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "audit logs retrieved", Data: list})
+}
+
+// ListClinicAuditLogsHandler handles GET /clinic/audit-logs with the same filters, scoped to the caller's clinic
+func ListClinicAuditLogsHandler(c echo.Context) error {
+	clinicID, ok := c.Get("clinic_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	f, err := auditFilterFromQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListClinicAuditLogs(uint64(clinicID), f, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "audit logs retrieved", Data: list})
+}
@@ -0,0 +1,300 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// availabilityErrorStatus maps schedule errors to HTTP status codes
+func availabilityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrClinicHolidayExists):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// practitionerFromRequest resolves the practitioner being scheduled: the caller for /profile/me routes,
+// otherwise the :id path parameter
+func practitionerFromRequest(c echo.Context, self bool) (clinicID, practitionerID uint64, err error) {
+	clinicID, clinicUserID, ok := clinicStaffContext(c)
+	if !ok {
+		return 0, 0, errors.New("clinic_id not found in context")
+	}
+	if self {
+		return clinicID, clinicUserID, nil
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid practitioner id")
+	}
+	return clinicID, id, nil
+}
+
+// GetClinicOpeningHoursHandler handles GET /clinic/opening-hours
+func GetClinicOpeningHoursHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	hours, err := services.GetClinicOpeningHours(clinicID)
+	if err != nil {
+		return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "opening hours retrieved", Data: hours})
+}
+
+// SetClinicOpeningHoursHandler handles PUT /clinic/opening-hours
+func SetClinicOpeningHoursHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	var req reqdto.SetWeeklyScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	hours, err := services.SetClinicOpeningHours(clinicID, req)
+	if err != nil {
+		return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "opening hours updated", Data: hours})
+}
+
+// GetClinicHolidaysHandler handles GET /clinic/holidays?from=YYYY-MM-DD
+func GetClinicHolidaysHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	holidays, err := services.ListClinicHolidays(clinicID, c.QueryParam("from"))
+	if err != nil {
+		return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "holidays retrieved", Data: holidays})
+}
+
+// CreateClinicHolidayHandler handles POST /clinic/holidays
+func CreateClinicHolidayHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	var req reqdto.CreateClinicHolidayRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	holiday, err := services.AddClinicHoliday(clinicID, req)
+	if err != nil {
+		return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "holiday added", Data: holiday})
+}
+
+// DeleteClinicHolidayHandler handles DELETE /clinic/holidays/:id
+func DeleteClinicHolidayHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid holiday id"})
+	}
+
+	if err := services.DeleteClinicHoliday(clinicID, id); err != nil {
+		return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "holiday removed"})
+}
+
+// GetPractitionerAvailabilityHandler handles GET /clinic/practitioners/:id/availability and GET /clinic/profile/me/availability
+func GetPractitionerAvailabilityHandler(self bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		clinicID, practitionerID, err := practitionerFromRequest(c, self)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		availability, err := services.GetPractitionerAvailability(clinicID, practitionerID)
+		if err != nil {
+			return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "availability retrieved", Data: availability})
+	}
+}
+
+// SetPractitionerAvailabilityHandler handles PUT /clinic/practitioners/:id/availability and PUT /clinic/profile/me/availability
+func SetPractitionerAvailabilityHandler(self bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		clinicID, practitionerID, err := practitionerFromRequest(c, self)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		var req reqdto.SetWeeklyScheduleRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		availability, err := services.SetPractitionerAvailability(clinicID, practitionerID, req)
+		if err != nil {
+			return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "availability updated", Data: availability})
+	}
+}
+
+// CreateAvailabilityExceptionHandler handles POST .../availability/exceptions
+func CreateAvailabilityExceptionHandler(self bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		clinicID, practitionerID, err := practitionerFromRequest(c, self)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		var req reqdto.CreateAvailabilityExceptionRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		exception, err := services.AddAvailabilityException(clinicID, practitionerID, req)
+		if err != nil {
+			return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "availability exception added", Data: exception})
+	}
+}
+
+// DeleteAvailabilityExceptionHandler handles DELETE .../availability/exceptions/:exceptionId
+func DeleteAvailabilityExceptionHandler(self bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		clinicID, practitionerID, err := practitionerFromRequest(c, self)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		id, err := strconv.ParseUint(c.Param("exceptionId"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid exception id"})
+		}
+
+		if err := services.DeleteAvailabilityException(clinicID, practitionerID, id); err != nil {
+			return c.JSON(availabilityErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "availability exception removed"})
+	}
+}
+
+// slotQueryFromRequest reads slot generation parameters
+func slotQueryFromRequest(c echo.Context) (services.SlotQuery, error) {
+	q := services.SlotQuery{Date: c.QueryParam("date")}
+	treatmentID, err := strconv.ParseUint(c.QueryParam("treatment_id"), 10, 32)
+	if err != nil {
+		return q, errors.New("invalid treatment_id")
+	}
+	q.TreatmentID = uint(treatmentID)
+	if v := c.QueryParam("practitioner_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return q, errors.New("invalid practitioner_id")
+		}
+		q.PractitionerID = id
+	}
+	if v := c.QueryParam("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return q, errors.New("invalid days")
+		}
+		q.Days = days
+	}
+	if v := c.QueryParam("items"); v != "" {
+		items, err := parseSlotItems(v)
+		if err != nil {
+			return q, err
+		}
+		q.Items = items
+	}
+	return q, nil
+}
+
+// parseSlotItems parses a side area selection given as comma-separated side_area_id[:syringe_count[:syringe_size]]
+func parseSlotItems(v string) ([]reqdto.AppointmentItemRequest, error) {
+	var items []reqdto.AppointmentItemRequest
+	for _, part := range strings.Split(v, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		if len(fields) > 3 {
+			return nil, errors.New("invalid items")
+		}
+		nums := make([]int, len(fields))
+		for i, f := range fields {
+			n, err := strconv.Atoi(f)
+			if err != nil || n < 0 {
+				return nil, errors.New("invalid items")
+			}
+			nums[i] = n
+		}
+		item := reqdto.AppointmentItemRequest{SideAreaID: uint(nums[0])}
+		if len(nums) > 1 {
+			item.SyringeCount = nums[1]
+		}
+		if len(nums) > 2 {
+			item.SyringeSize = nums[2]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// GetClinicSlotsHandler handles GET /clinics/:clinicId/slots?treatment_id=&practitioner_id=&date=&days=&items=
+func GetClinicSlotsHandler(c echo.Context) error {
+	clinicID, err := strconv.ParseUint(c.Param("clinicId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+	q, err := slotQueryFromRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	slots, err := services.ListAvailableSlots(clinicID, q)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "slots retrieved", Data: slots})
+}
+
+// GetOwnClinicSlotsHandler handles GET /clinic/slots?treatment_id=&practitioner_id=&date=&days=&items=
+func GetOwnClinicSlotsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	q, err := slotQueryFromRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	slots, err := services.ListAvailableSlots(clinicID, q)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "slots retrieved", Data: slots})
+}
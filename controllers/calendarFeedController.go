@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// calendarFeedErrorStatus maps calendar feed errors to HTTP status codes
+func calendarFeedErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrCalendarFeedNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCalendarFeedLimit):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetCalendarFeedHandler handles GET /calendar/:token (the token ends in .ics)
+func GetCalendarFeedHandler(c echo.Context) error {
+	ics, err := services.GetCalendarFeedICS(c.Param("token"))
+	if err != nil {
+		return c.JSON(calendarFeedErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=300")
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
+}
+
+// createCalendarFeed binds the request and issues a feed
+func createCalendarFeed(c echo.Context, create func(reqdto.CreateCalendarFeedRequest) (*resdto.CalendarFeedDTO, error)) error {
+	var req reqdto.CreateCalendarFeedRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	feed, err := create(req)
+	if err != nil {
+		return c.JSON(calendarFeedErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "calendar feed created; keep the URL secret", Data: feed})
+}
+
+// listCalendarFeeds serves the feeds of an owner or clinic
+func listCalendarFeeds(c echo.Context, ownerType string, ownerID, clinicID uint64) error {
+	includeRevoked, _ := strconv.ParseBool(c.QueryParam("include_revoked"))
+	feeds, err := services.ListCalendarFeeds(ownerType, ownerID, clinicID, includeRevoked)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "calendar feeds retrieved", Data: feeds})
+}
+
+// revokeCalendarFeed revokes the feed named by the :id param
+func revokeCalendarFeed(c echo.Context, ownerType string, ownerID, clinicID uint64, actorType string, actorID uint64) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid calendar feed id"})
+	}
+	if err := services.RevokeCalendarFeed(ownerType, ownerID, clinicID, id, actorType, actorID); err != nil {
+		return c.JSON(calendarFeedErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "calendar feed revoked"})
+}
+
+// CreateMyCalendarFeedHandler handles POST /clinic/profile/me/calendar-feeds
+func CreateMyCalendarFeedHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	return createCalendarFeed(c, func(req reqdto.CreateCalendarFeedRequest) (*resdto.CalendarFeedDTO, error) {
+		return services.CreatePractitionerCalendarFeed(clinicID, actorID, req)
+	})
+}
+
+// GetMyCalendarFeedsHandler handles GET /clinic/profile/me/calendar-feeds?include_revoked=
+func GetMyCalendarFeedsHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	return listCalendarFeeds(c, models.AuditActorClinicUser, actorID, clinicID)
+}
+
+// RevokeMyCalendarFeedHandler handles DELETE /clinic/profile/me/calendar-feeds/:id
+func RevokeMyCalendarFeedHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	return revokeCalendarFeed(c, models.AuditActorClinicUser, actorID, clinicID, models.AuditActorClinicUser, actorID)
+}
+
+// GetClinicCalendarFeedsHandler handles GET /clinic/calendar-feeds?clinic_user_id=&include_revoked=
+func GetClinicCalendarFeedsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var ownerID uint64
+	if v := c.QueryParam("clinic_user_id"); v != "" {
+		var err error
+		if ownerID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic_user_id"})
+		}
+	}
+	return listCalendarFeeds(c, models.AuditActorClinicUser, ownerID, clinicID)
+}
+
+// RevokeClinicCalendarFeedHandler handles DELETE /clinic/calendar-feeds/:id (any staff member's feed)
+func RevokeClinicCalendarFeedHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	return revokeCalendarFeed(c, models.AuditActorClinicUser, 0, clinicID, models.AuditActorClinicUser, actorID)
+}
+
+// CreateCustomerCalendarFeedHandler handles POST /v1/calendar-feeds
+func CreateCustomerCalendarFeedHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	return createCalendarFeed(c, func(req reqdto.CreateCalendarFeedRequest) (*resdto.CalendarFeedDTO, error) {
+		return services.CreateCustomerCalendarFeed(userID, req)
+	})
+}
+
+// GetCustomerCalendarFeedsHandler handles GET /v1/calendar-feeds?include_revoked=
+func GetCustomerCalendarFeedsHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	return listCalendarFeeds(c, models.AuditActorCustomer, userID, 0)
+}
+
+// RevokeCustomerCalendarFeedHandler handles DELETE /v1/calendar-feeds/:id
+func RevokeCustomerCalendarFeedHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	return revokeCalendarFeed(c, models.AuditActorCustomer, userID, 0, models.AuditActorCustomer, userID)
+}
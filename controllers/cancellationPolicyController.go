@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// chargeErrorStatus maps policy and customer charge errors to HTTP status codes
+func chargeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrTreatmentNotOffered):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrChargeNotOpen):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetBookingPolicyHandler handles GET /clinics/:clinicId/cancellation-policy?treatment_id=
+// and returns the terms a customer books under, shown before booking
+func GetBookingPolicyHandler(c echo.Context) error {
+	clinicID, err := strconv.ParseUint(c.Param("clinicId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+	var treatmentID uint64
+	if v := c.QueryParam("treatment_id"); v != "" {
+		if treatmentID, err = strconv.ParseUint(v, 10, 32); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment_id"})
+		}
+	}
+
+	policy, err := services.GetBookingPolicy(clinicID, uint(treatmentID))
+	if err != nil {
+		return c.JSON(chargeErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "cancellation policy retrieved", Data: policy})
+}
+
+// GetClinicCancellationPoliciesHandler handles GET /clinic/cancellation-policies
+func GetClinicCancellationPoliciesHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	policies, err := services.GetClinicCancellationPolicies(clinicID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "cancellation policies retrieved", Data: policies})
+}
+
+// SetClinicCancellationPolicyHandler handles PUT /clinic/cancellation-policy (clinic default)
+// and PUT /clinic/treatments/:treatmentId/cancellation-policy
+func SetClinicCancellationPolicyHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var treatmentID uint
+	if c.Param("treatmentId") != "" {
+		id, err := parseCatalogID(c, "treatmentId")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+		}
+		treatmentID = id
+	}
+
+	var req reqdto.CancellationPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	policies, err := services.SetClinicCancellationPolicy(clinicID, treatmentID, req)
+	if err != nil {
+		return c.JSON(chargeErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "cancellation policy updated", Data: policies})
+}
+
+// DeleteClinicCancellationPolicyHandler handles DELETE /clinic/cancellation-policy
+// and DELETE /clinic/treatments/:treatmentId/cancellation-policy
+func DeleteClinicCancellationPolicyHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var treatmentID uint
+	if c.Param("treatmentId") != "" {
+		id, err := parseCatalogID(c, "treatmentId")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+		}
+		treatmentID = id
+	}
+
+	policies, err := services.DeleteClinicCancellationPolicy(clinicID, treatmentID)
+	if err != nil {
+		return c.JSON(chargeErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "cancellation policy removed", Data: policies})
+}
+
+// GetClinicChargesHandler handles GET /clinic/charges?status=&user_id=
+func GetClinicChargesHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var userID uint64
+	if v := c.QueryParam("user_id"); v != "" {
+		var err error
+		if userID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user_id"})
+		}
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListCustomerCharges(clinicID, userID, c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "charges retrieved", Data: list})
+}
+
+// CollectClinicChargeHandler handles POST /clinic/charges/:id/collect
+func CollectClinicChargeHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid charge id"})
+	}
+
+	var req reqdto.CollectChargeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	charge, err := services.CollectCustomerCharge(clinicID, id, req)
+	if err != nil {
+		return c.JSON(chargeErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "charge processed", Data: charge})
+}
+
+// WaiveClinicChargeHandler handles POST /clinic/charges/:id/waive
+func WaiveClinicChargeHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid charge id"})
+	}
+
+	var req reqdto.WaiveChargeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	charge, err := services.WaiveCustomerCharge(clinicID, id, req)
+	if err != nil {
+		return c.JSON(chargeErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "charge waived", Data: charge})
+}
+
+// GetMyChargesHandler handles GET /v1/charges?status=
+func GetMyChargesHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListCustomerCharges(0, userID, c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "charges retrieved", Data: list})
+}
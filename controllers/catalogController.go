@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetCatalogDraftHandler handles GET /admin/catalog/draft
+func GetCatalogDraftHandler(c echo.Context) error {
+	tree, err := services.GetDraftCatalog()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "catalog draft retrieved", Data: tree})
+}
+
+// GetCatalogDraftDiffHandler handles GET /admin/catalog/draft/diff
+func GetCatalogDraftDiffHandler(c echo.Context) error {
+	diff, err := services.GetCatalogDraftDiff()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "catalog diff retrieved", Data: diff})
+}
+
+// PublishCatalogHandler handles POST /admin/catalog/publish
+// Returns 409 when a node staged for deletion has since been taken into use.
+func PublishCatalogHandler(c echo.Context) error {
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+
+	var req reqdto.PublishCatalogRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	version, err := services.PublishCatalog(uint64(adminID), req.Notes)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrCatalogInUse) {
+			status = http.StatusConflict
+		}
+		return c.JSON(status, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "catalog published", Data: version})
+}
+
+// ListCatalogVersionsHandler handles GET /admin/catalog/versions
+func ListCatalogVersionsHandler(c echo.Context) error {
+	versions, err := services.ListCatalogVersions()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "catalog versions retrieved", Data: versions})
+}
+
+// RollbackCatalogHandler handles POST /admin/catalog/versions/:version/rollback
+func RollbackCatalogHandler(c echo.Context) error {
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "admin_id not found in context"})
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid catalog version"})
+	}
+
+	restored, err := services.RollbackCatalog(version, uint64(adminID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, resdto.BaseResponse{IsSuccess: false, Message: "catalog version not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "catalog rolled back", Data: restored})
+}
+
+// catalogFileFormat picks csv or json from the format query param, the upload name or the content type
+func catalogFileFormat(c echo.Context, filename string) string {
+	if f := strings.ToLower(c.QueryParam("format")); f != "" {
+		return f
+	}
+	if strings.HasSuffix(strings.ToLower(filename), ".csv") {
+		return "csv"
+	}
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		return "json"
+	}
+	if strings.Contains(c.Request().Header.Get(echo.HeaderContentType), "csv") {
+		return "csv"
+	}
+	return "json"
+}
+
+// ExportCatalogHandler handles GET /admin/catalog/export?format=csv|json
+func ExportCatalogHandler(c echo.Context) error {
+	switch catalogFileFormat(c, "") {
+	case "csv":
+		data, err := services.ExportCatalogCSV()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="catalog.csv"`)
+		return c.Blob(http.StatusOK, "text/csv; charset=utf-8", data)
+	case "json":
+		tree, err := services.ExportCatalog()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="catalog.json"`)
+		return c.JSON(http.StatusOK, tree)
+	default:
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "format must be csv or json"})
+	}
+}
+
+// ImportCatalogHandler handles POST /admin/catalog/import?format=csv|json&dry_run=true
+// Accepts a multipart "file" upload or the raw file as the request body.
+func ImportCatalogHandler(c echo.Context) error {
+	var body io.Reader = c.Request().Body
+	filename := ""
+	if fh, err := c.FormFile("file"); err == nil {
+		f, err := fh.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		defer f.Close()
+		body = f
+		filename = fh.Filename
+	}
+
+	var (
+		tree []services.CatalogFileTreatment
+		err  error
+	)
+	switch catalogFileFormat(c, filename) {
+	case "csv":
+		tree, err = services.ParseCatalogCSV(body)
+	case "json":
+		tree, err = services.ParseCatalogJSON(body)
+	default:
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "format must be csv or json"})
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	report, err := services.ImportCatalog(tree, dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrCatalogImportConflicts) {
+			return c.JSON(http.StatusUnprocessableEntity, resdto.BaseResponse{IsSuccess: false, Message: err.Error(), Data: report})
+		}
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	message := "catalog imported into draft"
+	if dryRun {
+		message = "catalog import dry run"
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: message, Data: report})
+}
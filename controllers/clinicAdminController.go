@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// clinicAdminErrorStatus maps clinic lifecycle errors to HTTP status codes
+func clinicAdminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrClinicNotDeleted):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// ListClinicsHandler handles GET /admin/clinics?q=&status=&deleted=include|only&page=&page_size=
+func ListClinicsHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	deleted := c.QueryParam("deleted")
+	if deleted != services.CustomerDeletedExclude && deleted != services.CustomerDeletedInclude && deleted != services.CustomerDeletedOnly {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "deleted must be 'include' or 'only'"})
+	}
+
+	list, err := services.ListClinics(c.QueryParam("q"), c.QueryParam("status"), deleted, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinics retrieved", Data: list})
+}
+
+// GetClinicAdminDetailHandler handles GET /admin/clinics/:id
+func GetClinicAdminDetailHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+
+	detail, err := services.GetClinicAdminDetail(id)
+	if err != nil {
+		return c.JSON(clinicAdminErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic retrieved", Data: detail})
+}
+
+// AdminUpdateClinicHandler handles PUT /admin/clinics/:id
+func AdminUpdateClinicHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+
+	var req reqdto.AdminUpdateClinicRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	detail, err := services.AdminUpdateClinic(id, req)
+	if err != nil {
+		return c.JSON(clinicAdminErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic updated", Data: detail})
+}
+
+// SetClinicStatusHandler handles PATCH /admin/clinics/:id/status
+func SetClinicStatusHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+
+	var req reqdto.UpdateClinicStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	detail, err := services.SetClinicStatus(id, req.Status)
+	if err != nil {
+		return c.JSON(clinicAdminErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic status updated", Data: detail})
+}
+
+// DeleteClinicHandler handles DELETE /admin/clinics/:id (soft delete)
+func DeleteClinicHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+
+	if err := services.DeleteClinic(id); err != nil {
+		return c.JSON(clinicAdminErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic deleted"})
+}
+
+// RestoreClinicHandler handles POST /admin/clinics/:id/restore
+func RestoreClinicHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+
+	detail, err := services.RestoreClinic(id)
+	if err != nil {
+		return c.JSON(clinicAdminErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic restored", Data: detail})
+}
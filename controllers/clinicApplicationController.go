@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// clinicApplicationErrorStatus maps clinic application errors to HTTP status codes
+func clinicApplicationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrClinicApplicationExists),
+		errors.Is(err, services.ErrClinicApplicationOwnerLimit),
+		errors.Is(err, services.ErrClinicApplicationEmailTaken),
+		errors.Is(err, services.ErrClinicApplicationClosed),
+		errors.Is(err, services.ErrClinicApplicationNotAwaitingInfo):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// applicationDocuments returns the "documents" files of a multipart request
+func applicationDocuments(form *multipart.Form) []*multipart.FileHeader {
+	if form == nil {
+		return nil
+	}
+	return form.File["documents"]
+}
+
+// applicationToken reads the applicant access token from the X-Application-Token header or ?token=
+func applicationToken(c echo.Context) string {
+	if token := c.Request().Header.Get("X-Application-Token"); token != "" {
+		return token
+	}
+	return c.QueryParam("token")
+}
+
+// ==================== PUBLIC (APPLICANT) ====================
+
+// SubmitClinicApplicationHandler handles POST /clinic/applications
+// Multipart form with the SubmitClinicApplicationRequest fields and one or more "documents" files.
+func SubmitClinicApplicationHandler(c echo.Context) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "request must be multipart/form-data"})
+	}
+
+	req := reqdto.SubmitClinicApplicationRequest{
+		ClinicName:    c.FormValue("clinic_name"),
+		ClinicEmail:   c.FormValue("clinic_email"),
+		ClinicPhone:   c.FormValue("clinic_phone"),
+		ClinicAddress: c.FormValue("clinic_address"),
+		ClinicLogo:    c.FormValue("clinic_logo"),
+		LicenseNumber: c.FormValue("license_number"),
+		OwnerName:     c.FormValue("owner_name"),
+		OwnerEmail:    c.FormValue("owner_email"),
+		OwnerPhone:    c.FormValue("owner_phone"),
+		Message:       c.FormValue("message"),
+	}
+
+	resp, err := services.SubmitClinicApplication(req, applicationDocuments(form))
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "application submitted", Data: resp})
+}
+
+// GetApplicantClinicApplicationHandler handles GET /clinic/applications/:id (token via X-Application-Token or ?token=)
+func GetApplicantClinicApplicationHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid application id"})
+	}
+
+	app, err := services.GetApplicantClinicApplication(id, applicationToken(c))
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "application retrieved", Data: app})
+}
+
+// RespondToClinicApplicationHandler handles POST /clinic/applications/:id/respond
+// Multipart form with an optional "message" and "documents" files; only allowed after an information request.
+func RespondToClinicApplicationHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid application id"})
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "request must be multipart/form-data"})
+	}
+
+	app, err := services.RespondToClinicApplication(id, applicationToken(c), c.FormValue("message"), applicationDocuments(form))
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "application updated", Data: app})
+}
+
+// ==================== ADMIN REVIEW QUEUE ====================
+
+// ListClinicApplicationsHandler handles GET /admin/clinic-applications?q=&status=&page=&page_size=
+func ListClinicApplicationsHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListClinicApplications(c.QueryParam("q"), c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "applications retrieved", Data: list})
+}
+
+// GetClinicApplicationHandler handles GET /admin/clinic-applications/:id
+func GetClinicApplicationHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid application id"})
+	}
+
+	app, err := services.GetClinicApplication(id)
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "application retrieved", Data: app})
+}
+
+// DownloadClinicApplicationDocumentHandler handles GET /admin/clinic-applications/:id/documents/:documentId
+func DownloadClinicApplicationDocumentHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid application id"})
+	}
+	documentID, err := strconv.ParseUint(c.Param("documentId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid document id"})
+	}
+
+	doc, err := services.GetClinicApplicationDocument(id, documentID)
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	c.Response().Header().Set(echo.HeaderContentType, doc.ContentType)
+	return c.Attachment(doc.FilePath, doc.FileName)
+}
+
+// reviewClinicApplication runs an approve/reject/request-info action with the reviewer note from the body
+func reviewClinicApplication(c echo.Context, message string, review func(adminID, id uint64, note string) (interface{}, error)) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid application id"})
+	}
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "unauthorized"})
+	}
+
+	var req reqdto.ReviewClinicApplicationRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+	}
+
+	data, err := review(uint64(adminID), id, req.Note)
+	if err != nil {
+		return c.JSON(clinicApplicationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: message, Data: data})
+}
+
+// ApproveClinicApplicationHandler handles POST /admin/clinic-applications/:id/approve
+func ApproveClinicApplicationHandler(c echo.Context) error {
+	return reviewClinicApplication(c, "application approved and clinic registered", func(adminID, id uint64, note string) (interface{}, error) {
+		return services.ApproveClinicApplication(adminID, id, note)
+	})
+}
+
+// RejectClinicApplicationHandler handles POST /admin/clinic-applications/:id/reject
+func RejectClinicApplicationHandler(c echo.Context) error {
+	return reviewClinicApplication(c, "application rejected", func(adminID, id uint64, note string) (interface{}, error) {
+		return services.RejectClinicApplication(adminID, id, note)
+	})
+}
+
+// RequestClinicApplicationInfoHandler handles POST /admin/clinic-applications/:id/request-info
+func RequestClinicApplicationInfoHandler(c echo.Context) error {
+	return reviewClinicApplication(c, "more information requested from applicant", func(adminID, id uint64, note string) (interface{}, error) {
+		return services.RequestClinicApplicationInfo(adminID, id, note)
+	})
+}
@@ -1,17 +1,20 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"skinSync/config"
 	reqdto "skinSync/dto/request"
 	resdto "skinSync/dto/response"
 	"skinSync/services"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // RegisterClinicHandler handles clinic registration by super_admin
@@ -266,18 +269,24 @@ func CreateClinicSideAreasFromSideAreaHandler(c echo.Context) error {
 		})
 	}
 
-	// fill clinic_id from context if middleware provides it
-	if clinicIDf, ok := c.Get("clinic_id").(float64); ok {
-		clinicID := uint64(clinicIDf)
-		for i := range payload {
-			if payload[i].ClinicID == 0 {
-				payload[i].ClinicID = clinicID
-			}
-		}
+	// clinic always comes from the token, never from the payload
+	clinicIDf, ok := c.Get("clinic_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{
+			IsSuccess: false,
+			Message:   "clinic_id not found in context",
+		})
 	}
+	clinicID := uint64(clinicIDf)
 
 	// call service to upsert (service will resolve side-area -> area/treatment)
-	if err := services.UpsertClinicSideAreasFromSideArea(payload); err != nil {
+	if err := services.UpsertClinicSideAreasFromSideArea(payload, clinicID); err != nil {
+		if errors.Is(err, config.ErrCrossTenantWrite) {
+			return c.JSON(http.StatusForbidden, resdto.BaseResponse{
+				IsSuccess: false,
+				Message:   "clinic_id in payload does not match your clinic",
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{
 			IsSuccess: false,
 			Message:   "failed to save clinic side areas: " + err.Error(),
@@ -313,9 +322,9 @@ func CreateClinicSideAreasFromAreaHandler(c echo.Context) error {
 
 	resp, err := services.CreateClinicSideAreasBulk(req, clinicID)
 	if err != nil {
-		// Check if it's an "already exists" error
+		// Check if it's an "already exists" error, including a concurrent insert hitting the unique index
 		errMsg := err.Error()
-		if strings.Contains(errMsg, "already exists") {
+		if strings.Contains(errMsg, "already exists") || errors.Is(err, gorm.ErrDuplicatedKey) {
 			return c.JSON(http.StatusConflict, resdto.BaseResponse{
 				IsSuccess: false,
 				Message:   errMsg,
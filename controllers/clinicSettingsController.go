@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// clinicSettingsErrorStatus maps clinic settings errors to HTTP status codes
+func clinicSettingsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrProfileChangeNotPending):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetClinicSettingsHandler handles GET /clinic/settings
+func GetClinicSettingsHandler(c echo.Context) error {
+	clinicID, ok := c.Get("clinic_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	settings, err := services.GetClinicSettings(uint64(clinicID))
+	if err != nil {
+		return c.JSON(clinicSettingsErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "clinic settings retrieved", Data: settings})
+}
+
+// UpdateClinicSettingsHandler handles PUT /clinic/settings
+// Public profile fields may be held for admin approval; timezone and currency apply immediately.
+func UpdateClinicSettingsHandler(c echo.Context) error {
+	clinicID, ok := c.Get("clinic_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	clinicUserID, ok := c.Get("clinic_user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_user_id not found in context"})
+	}
+
+	var req reqdto.UpdateClinicSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	settings, queued, err := services.UpdateClinicSettings(uint64(clinicID), uint64(clinicUserID), req)
+	if err != nil {
+		return c.JSON(clinicSettingsErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	message := "clinic settings updated"
+	if queued {
+		message = "clinic settings updated; public profile changes are pending admin approval"
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: message, Data: settings})
+}
+
+// WithdrawClinicProfileChangeHandler handles DELETE /clinic/settings/pending
+func WithdrawClinicProfileChangeHandler(c echo.Context) error {
+	clinicID, ok := c.Get("clinic_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	if err := services.WithdrawClinicProfileChange(uint64(clinicID)); err != nil {
+		return c.JSON(clinicSettingsErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "pending profile change withdrawn"})
+}
+
+// ListClinicProfileChangesHandler handles GET /admin/clinic-profile-changes?status=&clinic_id=&page=&page_size=
+func ListClinicProfileChangesHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	var clinicID uint64
+	if raw := c.QueryParam("clinic_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic_id"})
+		}
+		clinicID = id
+	}
+
+	list, err := services.ListClinicProfileChanges(c.QueryParam("status"), clinicID, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile changes retrieved", Data: list})
+}
+
+// ApproveClinicProfileChangeHandler handles POST /admin/clinic-profile-changes/:id/approve
+func ApproveClinicProfileChangeHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid profile change id"})
+	}
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "unauthorized"})
+	}
+
+	change, err := services.ApproveClinicProfileChange(uint64(adminID), id)
+	if err != nil {
+		return c.JSON(clinicSettingsErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile change approved", Data: change})
+}
+
+// RejectClinicProfileChangeHandler handles POST /admin/clinic-profile-changes/:id/reject
+func RejectClinicProfileChangeHandler(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid profile change id"})
+	}
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "unauthorized"})
+	}
+
+	var req reqdto.RejectClinicProfileChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	change, err := services.RejectClinicProfileChange(uint64(adminID), id, req.Note)
+	if err != nil {
+		return c.JSON(clinicSettingsErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile change rejected", Data: change})
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// clinicStaffErrorStatus maps staff management errors to HTTP status codes
+func clinicStaffErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrLastClinicOwner), errors.Is(err, services.ErrClinicStaffHasAppointments):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrClinicStaffSelfAction), errors.Is(err, services.ErrClinicOwnerRole),
+		errors.Is(err, services.ErrPractitionerOnlyField):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// clinicStaffContext reads the clinic and acting clinic user from the auth context
+func clinicStaffContext(c echo.Context) (clinicID, actorID uint64, ok bool) {
+	cid, ok1 := c.Get("clinic_id").(float64)
+	uid, ok2 := c.Get("clinic_user_id").(float64)
+	return uint64(cid), uint64(uid), ok1 && ok2
+}
+
+// GetClinicUsersHandler handles GET /clinic/users?q=&role=&status=&page=&page_size=
+func GetClinicUsersHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListClinicStaff(clinicID, c.QueryParam("q"), c.QueryParam("role"), c.QueryParam("status"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "staff retrieved", Data: list})
+}
+
+// GetClinicUserHandler handles GET /clinic/users/:id
+func GetClinicUserHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	staff, err := services.GetClinicStaff(clinicID, id)
+	if err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "staff member retrieved", Data: staff})
+}
+
+// UpdateClinicUserHandler handles PUT /clinic/users/:id
+func UpdateClinicUserHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	var req reqdto.UpdateClinicUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	staff, err := services.UpdateClinicStaff(clinicID, id, req)
+	if err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "staff member updated", Data: staff})
+}
+
+// SetClinicUserStatusHandler handles PATCH /clinic/users/:id/status
+func SetClinicUserStatusHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	var req reqdto.UpdateClinicUserStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	staff, err := services.SetClinicStaffStatus(clinicID, actorID, id, req.Status)
+	if err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "staff status updated", Data: staff})
+}
+
+// DeleteClinicUserHandler handles DELETE /clinic/users/:id
+func DeleteClinicUserHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	if err := services.DeleteClinicStaff(clinicID, actorID, id); err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "staff member removed"})
+}
+
+// GetClinicUserProfileHandler handles GET /clinic/profile/me
+func GetClinicUserProfileHandler(c echo.Context) error {
+	clinicID, clinicUserID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_user_id not found in context"})
+	}
+
+	me, err := services.GetClinicMe(clinicID, clinicUserID)
+	if err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile retrieved", Data: me})
+}
+
+// UpdateClinicUserProfileHandler handles PUT /clinic/profile/me
+func UpdateClinicUserProfileHandler(c echo.Context) error {
+	clinicID, clinicUserID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_user_id not found in context"})
+	}
+
+	var req reqdto.UpdateClinicMeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	me, err := services.UpdateClinicMe(clinicID, clinicUserID, req)
+	if err != nil {
+		return c.JSON(clinicStaffErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile updated", Data: me})
+}
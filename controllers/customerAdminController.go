@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// customerErrorStatus maps customer admin service errors to HTTP status codes
+func customerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrCustomerNotDeleted):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseCustomerID parses the :id path param of customer routes
+func parseCustomerID(c echo.Context) (uint64, error) {
+	return strconv.ParseUint(c.Param("id"), 10, 64)
+}
+
+// SearchCustomersHandler handles GET /admin/users?q=&status=&deleted=include|only&page=&page_size=
+func SearchCustomersHandler(c echo.Context) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	deleted := c.QueryParam("deleted")
+	if deleted != services.CustomerDeletedExclude && deleted != services.CustomerDeletedInclude && deleted != services.CustomerDeletedOnly {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "deleted must be 'include' or 'only'"})
+	}
+
+	list, err := services.SearchCustomers(c.QueryParam("q"), c.QueryParam("status"), deleted, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "customers retrieved", Data: list})
+}
+
+// GetCustomerDetailHandler handles GET /admin/users/:id
+func GetCustomerDetailHandler(c echo.Context) error {
+	id, err := parseCustomerID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	detail, err := services.GetCustomerDetail(id)
+	if err != nil {
+		return c.JSON(customerErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "customer retrieved", Data: detail})
+}
+
+// SetCustomerStatusHandler handles PATCH /admin/users/:id/status (suspend/reactivate)
+func SetCustomerStatusHandler(c echo.Context) error {
+	id, err := parseCustomerID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	var req reqdto.UpdateCustomerStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	detail, err := services.SetCustomerStatus(id, req.Status)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusNotFound, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "customer status updated", Data: detail})
+}
+
+// ForceLogoutCustomerHandler handles POST /admin/users/:id/logout
+func ForceLogoutCustomerHandler(c echo.Context) error {
+	id, err := parseCustomerID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	if _, err := services.GetCustomerDetail(id); err != nil {
+		return c.JSON(customerErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	revoked, err := services.RevokeCustomerSessions(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{
+		IsSuccess: true,
+		Message:   "customer logged out of all sessions",
+		Data:      map[string]int64{"revoked_sessions": revoked},
+	})
+}
+
+// DeleteCustomerHandler handles DELETE /admin/users/:id (soft delete)
+func DeleteCustomerHandler(c echo.Context) error {
+	id, err := parseCustomerID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	if err := services.DeleteCustomer(id); err != nil {
+		return c.JSON(customerErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "customer deleted"})
+}
+
+// RestoreCustomerHandler handles POST /admin/users/:id/restore
+func RestoreCustomerHandler(c echo.Context) error {
+	id, err := parseCustomerID(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid user id"})
+	}
+
+	detail, err := services.RestoreCustomer(id)
+	if err != nil {
+		return c.JSON(customerErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "customer restored", Data: detail})
+}
@@ -54,7 +54,7 @@ func GetTreatmentsByClinicHandler(c echo.Context) error {
 		})
 	}
 
-	treatments, err := services.GetTreatmentsByClinic(clinicID)
+	treatments, err := services.GetTreatmentsByClinic(clinicID, requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{
 			IsSuccess: false,
@@ -104,7 +104,7 @@ func GetTreatmentsByDoctorHandler(c echo.Context) error {
 		})
 	}
 
-	treatments, err := services.GetTreatmentsByDoctor(doctorID)
+	treatments, err := services.GetTreatmentsByDoctor(doctorID, requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{
 			IsSuccess: false,
@@ -170,7 +170,7 @@ func GetTreatmentsByDoctorAndClinicHandler(c echo.Context) error {
 		})
 	}
 
-	treatments, err := services.GetTreatmentsByDoctorAndClinic(doctorID, clinicID)
+	treatments, err := services.GetTreatmentsByDoctorAndClinic(doctorID, clinicID, requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{
 			IsSuccess: false,
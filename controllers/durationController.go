@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// durationErrorStatus maps clinic duration override errors to HTTP status codes
+func durationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrTreatmentNotOffered):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// EstimateAppointmentDurationHandler handles POST /clinics/:clinicId/appointment-duration
+// and returns the appointment length and resource requirements of a treatment selection
+func EstimateAppointmentDurationHandler(c echo.Context) error {
+	clinicID, err := strconv.ParseUint(c.Param("clinicId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+	var req reqdto.AppointmentDurationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	estimate, err := services.EstimateAppointmentDuration(clinicID, req)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "duration computed", Data: estimate})
+}
+
+// GetClinicTreatmentDurationsHandler handles GET /clinic/treatments/:treatmentId/durations
+func GetClinicTreatmentDurationsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	treatmentID, err := parseCatalogID(c, "treatmentId")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	durations, err := services.GetClinicTreatmentDurations(clinicID, treatmentID)
+	if err != nil {
+		return c.JSON(durationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "durations retrieved", Data: durations})
+}
+
+// SetClinicTreatmentDurationsHandler handles PUT /clinic/treatments/:treatmentId/durations
+func SetClinicTreatmentDurationsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	treatmentID, err := parseCatalogID(c, "treatmentId")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+	var req reqdto.SetClinicTreatmentDurationsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	durations, err := services.SetClinicTreatmentDurations(clinicID, treatmentID, req)
+	if err != nil {
+		return c.JSON(durationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "durations updated", Data: durations})
+}
+
+// GetTreatmentRequirementsHandler handles GET /admin/treatments/:id/requirements
+func GetTreatmentRequirementsHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	requirements, err := services.GetTreatmentRequirements(id)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "requirements retrieved", Data: requirements})
+}
+
+// SetTreatmentRequirementsHandler handles PUT /admin/treatments/:id/requirements
+func SetTreatmentRequirementsHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+	var req reqdto.SetTreatmentRequirementsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	requirements, err := services.SetTreatmentRequirements(id, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "requirements updated", Data: requirements})
+}
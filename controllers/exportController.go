@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// exportErrorStatus maps export errors to HTTP status codes
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrExportDatasetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrExportJobNotReady):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrExportJobExpired):
+		return http.StatusGone
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// exportCaller returns who is exporting and the clinic their data is limited to.
+// Admins may narrow clinic-aware datasets with ?clinic_id=.
+func exportCaller(c echo.Context, forClinic bool) (string, uint64, *uint64, error) {
+	if forClinic {
+		clinicUserID, ok := c.Get("clinic_user_id").(float64)
+		if !ok {
+			return "", 0, nil, errors.New("clinic_user_id not found in context")
+		}
+		clinicID, ok := c.Get("clinic_id").(float64)
+		if !ok {
+			return "", 0, nil, errors.New("clinic_id not found in context")
+		}
+		cid := uint64(clinicID)
+		return models.AuditActorClinicUser, uint64(clinicUserID), &cid, nil
+	}
+
+	adminID, ok := c.Get("admin_id").(float64)
+	if !ok {
+		return "", 0, nil, errors.New("admin_id not found in context")
+	}
+	return models.AuditActorAdmin, uint64(adminID), nil, nil
+}
+
+// exportRequestFromQuery reads dataset, format (default csv), columns and clinic filter
+func exportRequestFromQuery(c echo.Context, forClinic bool) (services.ExportRequest, error) {
+	req := services.ExportRequest{
+		Dataset:   c.Param("dataset"),
+		Format:    strings.ToLower(c.QueryParam("format")),
+		ForClinic: forClinic,
+	}
+	if req.Format == "" {
+		req.Format = services.ExportFormatCSV
+	}
+	if cols := strings.TrimSpace(c.QueryParam("columns")); cols != "" {
+		req.Columns = strings.Split(cols, ",")
+	}
+
+	_, _, clinicID, err := exportCaller(c, forClinic)
+	if err != nil {
+		return req, err
+	}
+	req.ClinicID = clinicID
+	if !forClinic && c.QueryParam("clinic_id") != "" {
+		id, err := strconv.ParseUint(c.QueryParam("clinic_id"), 10, 64)
+		if err != nil {
+			return req, errors.New("invalid clinic_id")
+		}
+		req.ClinicID = &id
+	}
+	return req, nil
+}
+
+// ListExportDatasetsHandler handles GET /exports - datasets and their selectable columns
+func ListExportDatasetsHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "export datasets", Data: services.ListExportDatasets(forClinic)})
+	}
+}
+
+// queueExportJob starts a background export and responds 202 with the job
+func queueExportJob(c echo.Context, forClinic bool, req services.ExportRequest) error {
+	actorType, actorID, _, err := exportCaller(c, forClinic)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	job, err := services.CreateExportJob(req, actorType, actorID)
+	if err != nil {
+		return c.JSON(exportErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, resdto.BaseResponse{IsSuccess: true, Message: "export queued", Data: job})
+}
+
+// ExportHandler handles GET /exports/:dataset?format=csv|xlsx&columns=a,b
+// Small exports stream straight back; exports above the sync row limit are queued as a job (202).
+func ExportHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req, err := exportRequestFromQuery(c, forClinic)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		background, err := services.ShouldRunExportInBackground(req)
+		if err != nil {
+			return c.JSON(exportErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		if background {
+			return queueExportJob(c, forClinic, req)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, services.ExportContentType(req.Format))
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+services.ExportFileName(req)+`"`)
+		c.Response().WriteHeader(http.StatusOK)
+		if _, err := services.StreamExport(c.Response(), req); err != nil {
+			// headers are already sent; the truncated file is the only signal left to the client
+			log.Printf("export %s failed mid-stream: %v", req.Dataset, err)
+		}
+		return nil
+	}
+}
+
+// CreateExportJobHandler handles POST /exports/:dataset/jobs - always runs the export in the background
+func CreateExportJobHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req, err := exportRequestFromQuery(c, forClinic)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return queueExportJob(c, forClinic, req)
+	}
+}
+
+// ListExportJobsHandler handles GET /exports/jobs?page=&page_size=
+func ListExportJobsHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, _, clinicID, err := exportCaller(c, forClinic)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+		list, err := services.ListExportJobs(clinicID, page, pageSize)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "export jobs retrieved", Data: list})
+	}
+}
+
+// GetExportJobHandler handles GET /exports/jobs/:id
+func GetExportJobHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, _, clinicID, err := exportCaller(c, forClinic)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid job id"})
+		}
+
+		job, err := services.GetExportJob(id, clinicID)
+		if err != nil {
+			return c.JSON(exportErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "export job retrieved", Data: job})
+	}
+}
+
+// DownloadExportJobHandler handles GET /exports/jobs/:id/download
+func DownloadExportJobHandler(forClinic bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, _, clinicID, err := exportCaller(c, forClinic)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid job id"})
+		}
+
+		job, err := services.GetExportJobFile(id, clinicID)
+		if err != nil {
+			return c.JSON(exportErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		c.Response().Header().Set(echo.HeaderContentType, services.ExportContentType(job.Format))
+		return c.Attachment(job.FilePath, job.FileName)
+	}
+}
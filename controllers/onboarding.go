@@ -13,7 +13,7 @@ import (
 )
 
 func GetOnboardingMastersHandler(c echo.Context) error {
-	m, err := services.GetOnboardingMasters()
+	m, err := services.GetOnboardingMasters(requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
 	}
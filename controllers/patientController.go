@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// patientErrorStatus maps patient record errors to HTTP status codes
+func patientErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrNotClinicPatient):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetClinicPatientsHandler handles GET /clinic/patients?q=&source=&page=&page_size=
+func GetClinicPatientsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListClinicPatients(clinicID, c.QueryParam("q"), c.QueryParam("source"), page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "patients retrieved", Data: list})
+}
+
+// GetClinicPatientHandler handles GET /clinic/patients/:id
+func GetClinicPatientHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid patient id"})
+	}
+
+	patient, err := services.GetClinicPatient(clinicID, id)
+	if err != nil {
+		return c.JSON(patientErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "patient retrieved", Data: patient})
+}
+
+// CreateClinicPatientHandler handles POST /clinic/patients (walk-ins without an app account)
+func CreateClinicPatientHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var req reqdto.PatientRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	patient, err := services.CreateWalkInPatient(clinicID, req)
+	if err != nil {
+		return c.JSON(patientErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "patient created", Data: patient})
+}
+
+// UpdateClinicPatientHandler handles PUT /clinic/patients/:id
+func UpdateClinicPatientHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid patient id"})
+	}
+	var req reqdto.PatientRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	patient, err := services.UpdateClinicPatient(clinicID, id, req)
+	if err != nil {
+		return c.JSON(patientErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "patient updated", Data: patient})
+}
+
+// DeleteClinicPatientHandler handles DELETE /clinic/patients/:id
+func DeleteClinicPatientHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid patient id"})
+	}
+
+	if err := services.DeleteClinicPatient(clinicID, id); err != nil {
+		return c.JSON(patientErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "patient deleted"})
+}
+
+// GetMyPatientConsentsHandler handles GET /v1/patient-consents
+func GetMyPatientConsentsHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+
+	consents, err := services.ListPatientConsents(userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile sharing retrieved", Data: consents})
+}
+
+// SetMyPatientConsentHandler handles PUT /v1/patient-consents/:clinicId
+func SetMyPatientConsentHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	clinicID, err := strconv.ParseUint(c.Param("clinicId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid clinic id"})
+	}
+	var req reqdto.PatientConsentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	consent, err := services.SetPatientConsent(userID, clinicID, req)
+	if err != nil {
+		return c.JSON(patientErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "profile sharing updated", Data: consent})
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// reminderErrorStatus maps reminder link errors to HTTP status codes
+func reminderErrorStatus(err error) int {
+	if errors.Is(err, services.ErrInvalidActionLink) {
+		return http.StatusUnauthorized
+	}
+	return appointmentErrorStatus(err)
+}
+
+// GetClinicReminderRulesHandler handles GET /clinic/reminders
+func GetClinicReminderRulesHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	settings, err := services.GetClinicReminderRules(clinicID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "reminder rules retrieved", Data: settings})
+}
+
+// SetClinicReminderRulesHandler handles PUT /clinic/reminders
+// An empty rules list restores the default reminders.
+func SetClinicReminderRulesHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	var req reqdto.SetReminderRulesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	settings, err := services.SetClinicReminderRules(clinicID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "reminder rules updated", Data: settings})
+}
+
+// GetClinicAppointmentRemindersHandler handles GET /clinic/appointments/:id/reminders
+func GetClinicAppointmentRemindersHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid appointment id"})
+	}
+
+	reminders, err := services.ListAppointmentReminders(clinicID, id)
+	if err != nil {
+		return c.JSON(appointmentErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "reminders retrieved", Data: reminders})
+}
+
+// GetAppointmentActionHandler handles GET /appointments/actions?token=
+// and shows the customer what a reminder link will do before they apply it
+func GetAppointmentActionHandler(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "token is required"})
+	}
+
+	action, err := services.GetAppointmentAction(token)
+	if err != nil {
+		return c.JSON(reminderErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "appointment action retrieved", Data: action})
+}
+
+// ApplyAppointmentActionHandler handles POST /appointments/actions?token=
+// and confirms or cancels the appointment the reminder link was issued for
+func ApplyAppointmentActionHandler(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "token is required"})
+	}
+
+	action, err := services.ApplyAppointmentAction(token)
+	if err != nil {
+		return c.JSON(reminderErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	message := "appointment confirmed"
+	if action.Action == services.AppointmentActionCancel {
+		message = "appointment cancelled"
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: message, Data: action})
+}
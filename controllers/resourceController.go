@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// resourceErrorStatus maps clinic resource errors to HTTP status codes
+func resourceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrTreatmentNotOffered):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrResourceInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetClinicResourcesHandler handles GET /clinic/resources
+func GetClinicResourcesHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	resources, err := services.ListClinicResources(clinicID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "resources retrieved", Data: resources})
+}
+
+// CreateClinicResourceHandler handles POST /clinic/resources
+func CreateClinicResourceHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+
+	var req reqdto.CreateClinicResourceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	resource, err := services.CreateClinicResource(clinicID, req)
+	if err != nil {
+		return c.JSON(resourceErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "resource added", Data: resource})
+}
+
+// UpdateClinicResourceHandler handles PUT /clinic/resources/:id
+func UpdateClinicResourceHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid resource id"})
+	}
+
+	var req reqdto.UpdateClinicResourceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	resource, err := services.UpdateClinicResource(clinicID, id, req)
+	if err != nil {
+		return c.JSON(resourceErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "resource updated", Data: resource})
+}
+
+// DeleteClinicResourceHandler handles DELETE /clinic/resources/:id
+// Returns 409 while upcoming appointments hold the resource; deactivate it instead.
+func DeleteClinicResourceHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid resource id"})
+	}
+
+	if err := services.DeleteClinicResource(clinicID, id); err != nil {
+		return c.JSON(resourceErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "resource removed"})
+}
+
+// GetClinicTreatmentRequirementsHandler handles GET /clinic/treatments/:treatmentId/requirements
+func GetClinicTreatmentRequirementsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	treatmentID, err := parseCatalogID(c, "treatmentId")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	requirements, err := services.GetClinicTreatmentRequirements(clinicID, treatmentID)
+	if err != nil {
+		return c.JSON(resourceErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "requirements retrieved", Data: requirements})
+}
+
+// SetClinicTreatmentRequirementsHandler handles PUT /clinic/treatments/:treatmentId/requirements
+func SetClinicTreatmentRequirementsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	treatmentID, err := parseCatalogID(c, "treatmentId")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	var req reqdto.SetClinicTreatmentRequirementsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	requirements, err := services.SetClinicTreatmentRequirements(clinicID, treatmentID, req)
+	if err != nil {
+		return c.JSON(resourceErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "requirements updated", Data: requirements})
+}
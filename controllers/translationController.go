@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+	"skinSync/utils"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// requestLocale resolves the response locale from Accept-Language and advertises it
+func requestLocale(c echo.Context) string {
+	locale := utils.ResolveLocale(c.Request().Header.Get("Accept-Language"))
+	c.Response().Header().Set("Content-Language", locale)
+	c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	return locale
+}
+
+// translationErrorStatus maps translation service errors to HTTP status codes
+func translationErrorStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// ListTranslationsHandler handles GET .../:id/translations for an entity type
+func ListTranslationsHandler(entityType string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid id"})
+		}
+
+		translations, err := services.GetEntityTranslations(entityType, id)
+		if err != nil {
+			return c.JSON(translationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "translations retrieved", Data: translations})
+	}
+}
+
+// UpsertTranslationsHandler handles PUT .../:id/translations/:locale with a {field: value} body
+func UpsertTranslationsHandler(entityType string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid id"})
+		}
+
+		var fields map[string]string
+		if err := c.Bind(&fields); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+
+		translations, err := services.UpsertEntityTranslations(entityType, id, c.Param("locale"), fields)
+		if err != nil {
+			return c.JSON(translationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "translations saved", Data: translations})
+	}
+}
+
+// DeleteTranslationsHandler handles DELETE .../:id/translations/:locale
+func DeleteTranslationsHandler(entityType string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid id"})
+		}
+
+		if err := services.DeleteEntityTranslations(entityType, id, c.Param("locale")); err != nil {
+			return c.JSON(translationErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+		}
+		return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "translations deleted"})
+	}
+}
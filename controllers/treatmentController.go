@@ -1,18 +1,21 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	reqdto "skinSync/dto/request"
 	resdto "skinSync/dto/response"
 	"skinSync/services"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // GetTreatmentMastersHandler handles GET /api/treatments/masters
 func GetTreatmentMastersHandler(c echo.Context) error {
-	resp, err := services.GetTreatmentMasters()
+	resp, err := services.GetTreatmentMasters(requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.TreatmentMastersResponse{
 			IsSuccess: false,
@@ -34,7 +37,7 @@ func GetAreasHandler(c echo.Context) error {
 		})
 	}
 
-	resp, err := services.GetAreasByTreatment(uint(id))
+	resp, err := services.GetAreasByTreatment(uint(id), requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.AreasResponse{
 			IsSuccess: false,
@@ -65,7 +68,7 @@ func GetSideAreasHandler(c echo.Context) error {
 		})
 	}
 
-	resp, err := services.GetSideAreas(uint(treatmentID), uint(areaID))
+	resp, err := services.GetSideAreas(uint(treatmentID), uint(areaID), requestLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, resdto.SideAreasResponse{
 			IsSuccess: false,
@@ -75,3 +78,239 @@ func GetSideAreasHandler(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, resp)
 }
+
+// ==================== ADMIN CATALOG CRUD ====================
+
+// catalogErrorStatus maps catalog service errors to HTTP status codes; unknown errors are server errors
+func catalogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidCatalogItem):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrCatalogInUse),
+		errors.Is(err, services.ErrCatalogNameTaken),
+		errors.Is(err, gorm.ErrDuplicatedKey):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseCatalogID parses a catalog id path param
+func parseCatalogID(c echo.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// CreateTreatmentHandler handles POST /admin/treatments
+func CreateTreatmentHandler(c echo.Context) error {
+	var req reqdto.CreateTreatmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.CreateTreatment(req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "treatment created", Data: item})
+}
+
+// UpdateTreatmentHandler handles PUT /admin/treatments/:id
+func UpdateTreatmentHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	var req reqdto.UpdateTreatmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.UpdateTreatment(id, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment updated", Data: item})
+}
+
+// DeleteTreatmentHandler handles DELETE /admin/treatments/:id
+// The deletion is staged in the draft and applied on the next publish.
+// Returns 409 when clinics or doctors still use the treatment; archive it instead.
+func DeleteTreatmentHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	if err := services.DeleteTreatment(id); err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment staged for deletion; publish the catalog to apply"})
+}
+
+// SetTreatmentStatusHandler handles PATCH /admin/treatments/:id/status (archive/restore)
+func SetTreatmentStatusHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	var req reqdto.UpdateCatalogStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.SetTreatmentStatus(id, req.Status)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment status updated", Data: item})
+}
+
+// CreateAreaHandler handles POST /admin/treatments/:id/areas
+func CreateAreaHandler(c echo.Context) error {
+	parentID, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment id"})
+	}
+
+	var req reqdto.CreateAreaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.CreateArea(parentID, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "area created", Data: item})
+}
+
+// UpdateAreaHandler handles PUT /admin/areas/:id
+func UpdateAreaHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid area id"})
+	}
+
+	var req reqdto.UpdateAreaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.UpdateArea(id, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "area updated", Data: item})
+}
+
+// DeleteAreaHandler handles DELETE /admin/areas/:id
+// Returns 409 when clinics or doctors still use the area; archive it instead.
+func DeleteAreaHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid area id"})
+	}
+
+	if err := services.DeleteArea(id); err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "area staged for deletion; publish the catalog to apply"})
+}
+
+// SetAreaStatusHandler handles PATCH /admin/areas/:id/status (archive/restore)
+func SetAreaStatusHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid area id"})
+	}
+
+	var req reqdto.UpdateCatalogStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.SetAreaStatus(id, req.Status)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "area status updated", Data: item})
+}
+
+// CreateSideAreaHandler handles POST /admin/areas/:id/sideareas
+func CreateSideAreaHandler(c echo.Context) error {
+	parentID, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid area id"})
+	}
+
+	var req reqdto.CreateSideAreaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.CreateSideArea(parentID, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "side area created", Data: item})
+}
+
+// UpdateSideAreaHandler handles PUT /admin/sideareas/:id
+func UpdateSideAreaHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid side area id"})
+	}
+
+	var req reqdto.UpdateSideAreaRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.UpdateSideArea(id, req)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "side area updated", Data: item})
+}
+
+// DeleteSideAreaHandler handles DELETE /admin/sideareas/:id
+// Returns 409 when clinics or doctors still use the side area; archive it instead.
+func DeleteSideAreaHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid side area id"})
+	}
+
+	if err := services.DeleteSideArea(id); err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "side area staged for deletion; publish the catalog to apply"})
+}
+
+// SetSideAreaStatusHandler handles PATCH /admin/sideareas/:id/status (archive/restore)
+func SetSideAreaStatusHandler(c echo.Context) error {
+	id, err := parseCatalogID(c, "id")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid side area id"})
+	}
+
+	var req reqdto.UpdateCatalogStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	item, err := services.SetSideAreaStatus(id, req.Status)
+	if err != nil {
+		return c.JSON(catalogErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "side area status updated", Data: item})
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// treatmentRecordErrorStatus maps treatment record errors to HTTP status codes
+func treatmentRecordErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrTreatmentRecordSigned), errors.Is(err, services.ErrTreatmentRecordNotSigned),
+		errors.Is(err, services.ErrTreatmentRecordExists):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrNotRecordPractitioner):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetTreatmentRecordsHandler handles GET /clinic/treatment-records?patient_id=&appointment_id=&practitioner_id=&status=&page=&page_size=
+func GetTreatmentRecordsHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	f := services.TreatmentRecordFilter{Status: c.QueryParam("status")}
+	for param, dst := range map[string]*uint64{
+		"patient_id":      &f.PatientID,
+		"appointment_id":  &f.AppointmentID,
+		"practitioner_id": &f.PractitionerID,
+	} {
+		if v := c.QueryParam(param); v != "" {
+			id, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid " + param})
+			}
+			*dst = id
+		}
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	pageSize, _ := strconv.Atoi(c.QueryParam("page_size"))
+
+	list, err := services.ListTreatmentRecords(clinicID, f, page, pageSize)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment records retrieved", Data: list})
+}
+
+// GetTreatmentRecordHandler handles GET /clinic/treatment-records/:id
+func GetTreatmentRecordHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment record id"})
+	}
+
+	record, err := services.GetTreatmentRecord(clinicID, id)
+	if err != nil {
+		return c.JSON(treatmentRecordErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment record retrieved", Data: record})
+}
+
+// CreateTreatmentRecordHandler handles POST /clinic/treatment-records
+func CreateTreatmentRecordHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var req reqdto.TreatmentRecordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	record, err := services.CreateTreatmentRecord(clinicID, actorID, req)
+	if err != nil {
+		return c.JSON(treatmentRecordErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "treatment record created", Data: record})
+}
+
+// UpdateTreatmentRecordHandler handles PUT /clinic/treatment-records/:id (drafts only)
+func UpdateTreatmentRecordHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment record id"})
+	}
+	var req reqdto.TreatmentRecordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	record, err := services.UpdateTreatmentRecord(clinicID, id, req)
+	if err != nil {
+		return c.JSON(treatmentRecordErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment record updated", Data: record})
+}
+
+// SignTreatmentRecordHandler handles POST /clinic/treatment-records/:id/sign
+func SignTreatmentRecordHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment record id"})
+	}
+
+	record, err := services.SignTreatmentRecord(clinicID, actorID, id)
+	if err != nil {
+		return c.JSON(treatmentRecordErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "treatment record signed", Data: record})
+}
+
+// AddTreatmentRecordAddendumHandler handles POST /clinic/treatment-records/:id/addenda
+func AddTreatmentRecordAddendumHandler(c echo.Context) error {
+	clinicID, actorID, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid treatment record id"})
+	}
+	var req reqdto.TreatmentRecordAddendumRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	record, err := services.AddTreatmentRecordAddendum(clinicID, actorID, id, req)
+	if err != nil {
+		return c.JSON(treatmentRecordErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "addendum added", Data: record})
+}
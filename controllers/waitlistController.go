@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// waitlistErrorStatus maps waitlist errors to HTTP status codes
+func waitlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidActionLink):
+		return http.StatusUnauthorized
+	case errors.Is(err, services.ErrWaitlistDuplicate), errors.Is(err, services.ErrWaitlistOfferClosed):
+		return http.StatusConflict
+	default:
+		return appointmentErrorStatus(err)
+	}
+}
+
+// JoinWaitlistHandler handles POST /v1/waitlist
+func JoinWaitlistHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	var req reqdto.JoinWaitlistRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+
+	entry, err := services.JoinWaitlist(userID, req)
+	if err != nil {
+		return c.JSON(waitlistErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "added to the waitlist", Data: entry})
+}
+
+// GetMyWaitlistHandler handles GET /v1/waitlist?status=
+func GetMyWaitlistHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+
+	entries, err := services.ListWaitlistEntries(0, userID, 0, c.QueryParam("status"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "waitlist retrieved", Data: entries})
+}
+
+// LeaveWaitlistHandler handles DELETE /v1/waitlist/:id
+func LeaveWaitlistHandler(c echo.Context) error {
+	userID, ok := customerUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "user_id not found in context"})
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid waitlist entry id"})
+	}
+
+	if err := services.LeaveWaitlist(userID, id); err != nil {
+		return c.JSON(waitlistErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "removed from the waitlist"})
+}
+
+// GetClinicWaitlistHandler handles GET /clinic/waitlist?practitioner_id=&status=
+func GetClinicWaitlistHandler(c echo.Context) error {
+	clinicID, _, ok := clinicStaffContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{IsSuccess: false, Message: "clinic_id not found in context"})
+	}
+	var practitionerID uint64
+	if v := c.QueryParam("practitioner_id"); v != "" {
+		var err error
+		if practitionerID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "invalid practitioner_id"})
+		}
+	}
+
+	entries, err := services.ListWaitlistEntries(clinicID, 0, practitionerID, c.QueryParam("status"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "waitlist retrieved", Data: entries})
+}
+
+// GetWaitlistOfferHandler handles GET /waitlist/offers?token=
+func GetWaitlistOfferHandler(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "token is required"})
+	}
+
+	offer, err := services.GetWaitlistOffer(token)
+	if err != nil {
+		return c.JSON(waitlistErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "offer retrieved", Data: offer})
+}
+
+// ClaimWaitlistOfferHandler handles POST /waitlist/offers/claim?token=
+func ClaimWaitlistOfferHandler(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "token is required"})
+	}
+
+	offer, err := services.ClaimWaitlistOffer(token)
+	if err != nil {
+		return c.JSON(waitlistErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusCreated, resdto.BaseResponse{IsSuccess: true, Message: "slot booked", Data: offer})
+}
+
+// DeclineWaitlistOfferHandler handles POST /waitlist/offers/decline?token=
+func DeclineWaitlistOfferHandler(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, resdto.BaseResponse{IsSuccess: false, Message: "token is required"})
+	}
+
+	offer, err := services.DeclineWaitlistOffer(token)
+	if err != nil {
+		return c.JSON(waitlistErrorStatus(err), resdto.BaseResponse{IsSuccess: false, Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, resdto.BaseResponse{IsSuccess: true, Message: "offer declined", Data: offer})
+}
@@ -19,3 +19,33 @@ type VerifyPasswordRequest struct {
 	Password string `json:"password" validate:"required"`
 	Hash     string `json:"hash" validate:"required"`
 }
+
+// UpdateAdminUserRequest represents an admin editing another admin account
+type UpdateAdminUserRequest struct {
+	Name     *string `json:"name"`
+	Email    *string `json:"email"`
+	RoleName *string `json:"role_name"`
+}
+
+// UpdateAdminStatusRequest represents activating or deactivating an admin account
+type UpdateAdminStatusRequest struct {
+	Status string `json:"status"` // active, inactive
+}
+
+// AdminResetPasswordRequest represents an admin-initiated password reset.
+// A temporary password is generated when NewPassword is empty.
+type AdminResetPasswordRequest struct {
+	NewPassword string `json:"new_password"`
+}
+
+// UpdateAdminProfileRequest represents an admin editing their own profile
+type UpdateAdminProfileRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+// AdminChangePasswordRequest represents an admin changing their own password
+type AdminChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
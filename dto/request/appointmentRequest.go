@@ -0,0 +1,64 @@
+package request
+
+import "time"
+
+// AppointmentItemRequest selects one side area of the treatment.
+// syringe_count defaults to the side area's minimum; syringe_size picks a per-size clinic price
+// and may be omitted when the clinic prices the side area at a single size.
+type AppointmentItemRequest struct {
+	SideAreaID   uint `json:"side_area_id"`
+	SyringeCount int  `json:"syringe_count,omitempty"`
+	SyringeSize  int  `json:"syringe_size,omitempty"`
+}
+
+// CreateAppointmentRequest books a practitioner at a clinic for a treatment.
+// start_at is RFC3339 and must fall on a 5-minute boundary.
+type CreateAppointmentRequest struct {
+	ClinicID       uint64                   `json:"clinic_id"`
+	PractitionerID uint64                   `json:"practitioner_id"`
+	TreatmentID    uint                     `json:"treatment_id"`
+	StartAt        time.Time                `json:"start_at"`
+	Items          []AppointmentItemRequest `json:"items"`
+	Notes          string                   `json:"notes,omitempty"`
+	ShareProfile   bool                     `json:"share_profile,omitempty"` // let the clinic see the customer's profile and onboarding answers
+}
+
+// ClinicCreateAppointmentRequest books on behalf of one of the clinic's patients who has a customer
+// account. Bookings made by staff start confirmed; duration_minutes overrides the computed length.
+type ClinicCreateAppointmentRequest struct {
+	PatientID       uint64                   `json:"patient_id"`
+	PractitionerID  uint64                   `json:"practitioner_id"`
+	TreatmentID     uint                     `json:"treatment_id"`
+	StartAt         time.Time                `json:"start_at"`
+	DurationMinutes int                      `json:"duration_minutes,omitempty"`
+	Items           []AppointmentItemRequest `json:"items"`
+	CustomerNotes   string                   `json:"customer_notes,omitempty"`
+	ClinicNotes     string                   `json:"clinic_notes,omitempty"`
+}
+
+// RescheduleAppointmentRequest moves a requested or confirmed appointment; only provided fields change.
+// duration_minutes 0 recomputes the length from the treatment and side area durations.
+type RescheduleAppointmentRequest struct {
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	PractitionerID  *uint64    `json:"practitioner_id,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty"`
+	ClinicNotes     *string    `json:"clinic_notes,omitempty"`
+}
+
+// UpdateAppointmentStatusRequest moves an appointment to its next status.
+// reason is stored when cancelling.
+type UpdateAppointmentStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// CancelAppointmentRequest cancels an appointment with an optional reason
+type CancelAppointmentRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// AppointmentDurationRequest asks for the computed length of a treatment selection at a clinic
+type AppointmentDurationRequest struct {
+	TreatmentID uint                     `json:"treatment_id"`
+	Items       []AppointmentItemRequest `json:"items"`
+}
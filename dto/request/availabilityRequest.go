@@ -0,0 +1,30 @@
+package request
+
+// WeeklyPeriodRequest is one recurring period; weekday is 0 (Sunday) to 6 and times are "HH:MM"
+// in the clinic's timezone. end_time may be "24:00".
+type WeeklyPeriodRequest struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// SetWeeklyScheduleRequest replaces a weekly schedule; an empty list clears it
+type SetWeeklyScheduleRequest struct {
+	Periods []WeeklyPeriodRequest `json:"periods"`
+}
+
+// CreateClinicHolidayRequest closes the clinic on a date (YYYY-MM-DD)
+type CreateClinicHolidayRequest struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// CreateAvailabilityExceptionRequest adds leave ("unavailable") or extra hours ("available") on a date.
+// Leave without times covers the whole day.
+type CreateAvailabilityExceptionRequest struct {
+	Date      string `json:"date"`
+	Type      string `json:"type"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
@@ -0,0 +1,6 @@
+package request
+
+// CreateCalendarFeedRequest creates a calendar subscription URL; name is an optional label
+type CreateCalendarFeedRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,25 @@
+package request
+
+// CancellationPolicyRequest sets a clinic's cancellation terms.
+// Fee types are "fixed" (amount in the clinic currency, the default) or "percent" (of the appointment price).
+// free_cancel_hours 0 lets customers cancel free until the start.
+type CancellationPolicyRequest struct {
+	FreeCancelHours   int     `json:"free_cancel_hours"`
+	LateCancelFee     float64 `json:"late_cancel_fee"`
+	LateCancelFeeType string  `json:"late_cancel_fee_type,omitempty"`
+	NoShowFee         float64 `json:"no_show_fee"`
+	NoShowFeeType     string  `json:"no_show_fee_type,omitempty"`
+	Deposit           float64 `json:"deposit"`
+	DepositType       string  `json:"deposit_type,omitempty"`
+}
+
+// CollectChargeRequest collects a customer charge. A reference records a payment taken outside
+// the payment provider (e.g. at the front desk); without one the provider is charged.
+type CollectChargeRequest struct {
+	Reference string `json:"reference,omitempty"`
+}
+
+// WaiveChargeRequest waives a customer charge that has not been collected
+type WaiveChargeRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
@@ -27,3 +27,101 @@ type RegisterClinicUserRequest struct {
 	Name   string `json:"name" validate:"required,min=2"`
 	RoleID uint64 `json:"role_id" validate:"required"`
 }
+
+// UpdateClinicUserRequest updates only the provided staff fields
+type UpdateClinicUserRequest struct {
+	Name   *string `json:"name,omitempty"`
+	RoleID *uint64 `json:"role_id,omitempty"`
+}
+
+// UpdateClinicUserStatusRequest activates or deactivates a staff member
+type UpdateClinicUserStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active inactive"`
+}
+
+// AdminUpdateClinicRequest updates only the provided clinic fields
+type AdminUpdateClinicRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Email   *string `json:"email,omitempty"`
+	Phone   *string `json:"phone,omitempty"`
+	Address *string `json:"address,omitempty"`
+	Logo    *string `json:"logo,omitempty"`
+
+	// Profile and settings (same rules as the clinic settings endpoint; applied without review)
+	Description  *string            `json:"description,omitempty"`
+	Website      *string            `json:"website,omitempty"`
+	AddressLine1 *string            `json:"address_line1,omitempty"`
+	AddressLine2 *string            `json:"address_line2,omitempty"`
+	City         *string            `json:"city,omitempty"`
+	State        *string            `json:"state,omitempty"`
+	PostalCode   *string            `json:"postal_code,omitempty"`
+	Country      *string            `json:"country,omitempty"`
+	SocialLinks  *map[string]string `json:"social_links,omitempty"`
+	Timezone     *string            `json:"timezone,omitempty"`
+	Currency     *string            `json:"currency,omitempty"`
+}
+
+// UpdateClinicStatusRequest changes a clinic's lifecycle status
+type UpdateClinicStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active inactive suspended"`
+}
+
+// SubmitClinicApplicationRequest is the public clinic application form (multipart, with "documents" files)
+type SubmitClinicApplicationRequest struct {
+	ClinicName    string `json:"clinic_name" form:"clinic_name"`
+	ClinicEmail   string `json:"clinic_email" form:"clinic_email"`
+	ClinicPhone   string `json:"clinic_phone" form:"clinic_phone"`
+	ClinicAddress string `json:"clinic_address" form:"clinic_address"`
+	ClinicLogo    string `json:"clinic_logo" form:"clinic_logo"`
+	LicenseNumber string `json:"license_number" form:"license_number"`
+	OwnerName     string `json:"owner_name" form:"owner_name"`
+	OwnerEmail    string `json:"owner_email" form:"owner_email"`
+	OwnerPhone    string `json:"owner_phone" form:"owner_phone"`
+	Message       string `json:"message" form:"message"`
+}
+
+// ReviewClinicApplicationRequest carries the reviewer note for approve, reject and request-info.
+// The note is required when rejecting or requesting more information.
+type ReviewClinicApplicationRequest struct {
+	Note string `json:"note"`
+}
+
+// UpdateClinicSettingsRequest updates only the provided clinic profile and settings fields.
+// social_links replaces the whole map; an empty object clears it.
+type UpdateClinicSettingsRequest struct {
+	Name         *string            `json:"name,omitempty"`
+	Phone        *string            `json:"phone,omitempty"`
+	Logo         *string            `json:"logo,omitempty"`
+	Description  *string            `json:"description,omitempty"`
+	Website      *string            `json:"website,omitempty"`
+	AddressLine1 *string            `json:"address_line1,omitempty"`
+	AddressLine2 *string            `json:"address_line2,omitempty"`
+	City         *string            `json:"city,omitempty"`
+	State        *string            `json:"state,omitempty"`
+	PostalCode   *string            `json:"postal_code,omitempty"`
+	Country      *string            `json:"country,omitempty"`
+	SocialLinks  *map[string]string `json:"social_links,omitempty"`
+	Timezone     *string            `json:"timezone,omitempty"`
+	Currency     *string            `json:"currency,omitempty"`
+
+	BookingBufferMinutes *int `json:"booking_buffer_minutes,omitempty"`
+	SlotIntervalMinutes  *int `json:"slot_interval_minutes,omitempty"`
+
+	CalendarCustomerNames *string `json:"calendar_customer_names,omitempty"` // full, initials or hidden
+}
+
+// RejectClinicProfileChangeRequest carries the reason shown to the clinic
+type RejectClinicProfileChangeRequest struct {
+	Note string `json:"note"`
+}
+
+// UpdateClinicMeRequest updates the signed-in clinic user's own profile.
+// Specialization, bio and qualifications are only accepted from doctors and injectors.
+type UpdateClinicMeRequest struct {
+	Name           *string   `json:"name,omitempty"`
+	Image          *string   `json:"image,omitempty"`
+	Phone          *string   `json:"phone,omitempty"`
+	Specialization *string   `json:"specialization,omitempty"`
+	Bio            *string   `json:"bio,omitempty"`
+	Qualifications *[]string `json:"qualifications,omitempty"`
+}
@@ -0,0 +1,6 @@
+package request
+
+// UpdateCustomerStatusRequest suspends or reactivates a customer account
+type UpdateCustomerStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active suspended"`
+}
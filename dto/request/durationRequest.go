@@ -0,0 +1,18 @@
+package request
+
+// ClinicSideAreaDurationRequest overrides the catalog durations of one side area at a clinic.
+// syringe_size limits the override to one priced size; omitted applies it to every size.
+// Omitted durations fall back to the catalog values.
+type ClinicSideAreaDurationRequest struct {
+	SideAreaID          uint `json:"side_area_id"`
+	SyringeSize         *int `json:"syringe_size,omitempty"`
+	DurationMinutes     *int `json:"duration_minutes,omitempty"`
+	ExtraSyringeMinutes *int `json:"extra_syringe_minutes,omitempty"`
+}
+
+// SetClinicTreatmentDurationsRequest replaces a clinic's duration overrides for one treatment.
+// Side areas not listed go back to the catalog durations.
+type SetClinicTreatmentDurationsRequest struct {
+	BaseMinutes *int                            `json:"base_minutes,omitempty"`
+	SideAreas   []ClinicSideAreaDurationRequest `json:"side_areas"`
+}
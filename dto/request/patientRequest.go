@@ -0,0 +1,19 @@
+package request
+
+// PatientRequest creates a walk-in patient or edits a patient record; omitted fields are left unchanged.
+// date_of_birth is YYYY-MM-DD.
+type PatientRequest struct {
+	Name              *string `json:"name,omitempty"`
+	Email             *string `json:"email,omitempty"`
+	Phone             *string `json:"phone,omitempty"`
+	DateOfBirth       *string `json:"date_of_birth,omitempty"`
+	Notes             *string `json:"notes,omitempty"`
+	MedicalHistory    *string `json:"medical_history,omitempty"`
+	Allergies         *string `json:"allergies,omitempty"`
+	Contraindications *string `json:"contraindications,omitempty"`
+}
+
+// PatientConsentRequest sets whether a clinic may see the customer's profile and onboarding answers
+type PatientConsentRequest struct {
+	ShareProfile bool `json:"share_profile"`
+}
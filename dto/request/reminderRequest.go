@@ -0,0 +1,16 @@
+package request
+
+// ReminderRuleRequest is one reminder sent offset_minutes before an appointment.
+// subject and body may use {{placeholders}}; empty ones use the default wording. is_active defaults to true.
+type ReminderRuleRequest struct {
+	OffsetMinutes int    `json:"offset_minutes"`
+	Channel       string `json:"channel,omitempty"`
+	Subject       string `json:"subject,omitempty"`
+	Body          string `json:"body,omitempty"`
+	IsActive      *bool  `json:"is_active,omitempty"`
+}
+
+// SetReminderRulesRequest replaces a clinic's reminder rules; an empty list restores the defaults
+type SetReminderRulesRequest struct {
+	Rules []ReminderRuleRequest `json:"rules"`
+}
@@ -0,0 +1,33 @@
+package request
+
+// CreateClinicResourceRequest adds a room or device to the clinic.
+// resource_type matches treatment requirements (e.g. procedure_room); capacity defaults to 1.
+type CreateClinicResourceRequest struct {
+	Kind         string `json:"kind"`
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	Capacity     int    `json:"capacity,omitempty"`
+}
+
+// UpdateClinicResourceRequest updates only the provided resource fields
+type UpdateClinicResourceRequest struct {
+	Kind         *string `json:"kind,omitempty"`
+	ResourceType *string `json:"resource_type,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Capacity     *int    `json:"capacity,omitempty"`
+	Status       *string `json:"status,omitempty"`
+}
+
+// ClinicTreatmentRequirementRequest is one clinic-specific resource requirement.
+// quantity defaults to 1; 0 waives the catalog requirement with the same side area and resource type.
+type ClinicTreatmentRequirementRequest struct {
+	SideAreaID   uint   `json:"side_area_id,omitempty"`
+	Kind         string `json:"kind"`
+	ResourceType string `json:"resource_type"`
+	Quantity     *int   `json:"quantity,omitempty"`
+}
+
+// SetClinicTreatmentRequirementsRequest replaces a clinic's requirement adjustments for one treatment
+type SetClinicTreatmentRequirementsRequest struct {
+	Requirements []ClinicTreatmentRequirementRequest `json:"requirements"`
+}
@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+// TreatmentRecordItemRequest records the product used on one side area of the treatment
+type TreatmentRecordItemRequest struct {
+	SideAreaID   uint     `json:"side_area_id"`
+	Product      string   `json:"product"`
+	SyringeCount int      `json:"syringe_count"`
+	SyringeSize  int      `json:"syringe_size,omitempty"`
+	LotNumbers   []string `json:"lot_numbers,omitempty"`
+	Notes        string   `json:"notes,omitempty"`
+}
+
+// TreatmentRecordRequest creates or edits a draft treatment record. With appointment_id the patient,
+// practitioner, treatment and time default to the appointment's; without it patient_id and
+// treatment_id are required and the practitioner defaults to the caller.
+type TreatmentRecordRequest struct {
+	AppointmentID  *uint64                      `json:"appointment_id,omitempty"`
+	PatientID      uint64                       `json:"patient_id,omitempty"`
+	PractitionerID uint64                       `json:"practitioner_id,omitempty"`
+	TreatmentID    uint                         `json:"treatment_id,omitempty"`
+	PerformedAt    *time.Time                   `json:"performed_at,omitempty"`
+	Items          []TreatmentRecordItemRequest `json:"items"`
+	InjectionNotes string                       `json:"injection_notes,omitempty"`
+	Aftercare      string                       `json:"aftercare,omitempty"`
+}
+
+// TreatmentRecordAddendumRequest amends a signed treatment record
+type TreatmentRecordAddendumRequest struct {
+	Note string `json:"note"`
+}
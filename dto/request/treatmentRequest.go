@@ -0,0 +1,100 @@
+package request
+
+// CreateTreatmentRequest is the payload to create a catalog treatment
+type CreateTreatmentRequest struct {
+	Code        string `json:"code,omitempty"`
+	Name        string `json:"name" validate:"required"`
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+	IsArea      bool   `json:"is_area"`
+
+	DurationMinutes int `json:"duration_minutes"`
+}
+
+// UpdateTreatmentRequest updates only the provided treatment fields
+type UpdateTreatmentRequest struct {
+	Code        *string `json:"code,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsArea      *bool   `json:"is_area,omitempty"`
+
+	DurationMinutes *int `json:"duration_minutes,omitempty"`
+}
+
+// CreateAreaRequest is the payload to create an area under a treatment
+type CreateAreaRequest struct {
+	Code        string `json:"code,omitempty"`
+	Name        string `json:"name" validate:"required"`
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+	IsSideArea  bool   `json:"is_sidearea"`
+	MinSyringe  int    `json:"min_syringe"`
+	MaxSyringe  int    `json:"max_syringe"`
+
+	DurationMinutes     int `json:"duration_minutes"`
+	ExtraSyringeMinutes int `json:"extra_syringe_minutes"`
+}
+
+// UpdateAreaRequest updates only the provided area fields
+type UpdateAreaRequest struct {
+	Code        *string `json:"code,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsSideArea  *bool   `json:"is_sidearea,omitempty"`
+	MinSyringe  *int    `json:"min_syringe,omitempty"`
+	MaxSyringe  *int    `json:"max_syringe,omitempty"`
+
+	DurationMinutes     *int `json:"duration_minutes,omitempty"`
+	ExtraSyringeMinutes *int `json:"extra_syringe_minutes,omitempty"`
+}
+
+// CreateSideAreaRequest is the payload to create a side area under an area
+type CreateSideAreaRequest struct {
+	Code        string `json:"code,omitempty"`
+	Name        string `json:"name" validate:"required"`
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+	MinSyringe  int    `json:"min_syringe"`
+	MaxSyringe  int    `json:"max_syringe"`
+
+	DurationMinutes     int `json:"duration_minutes"`
+	ExtraSyringeMinutes int `json:"extra_syringe_minutes"`
+}
+
+// UpdateSideAreaRequest updates only the provided side area fields
+type UpdateSideAreaRequest struct {
+	Code        *string `json:"code,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	Description *string `json:"description,omitempty"`
+	MinSyringe  *int    `json:"min_syringe,omitempty"`
+	MaxSyringe  *int    `json:"max_syringe,omitempty"`
+
+	DurationMinutes     *int `json:"duration_minutes,omitempty"`
+	ExtraSyringeMinutes *int `json:"extra_syringe_minutes,omitempty"`
+}
+
+// UpdateCatalogStatusRequest archives or restores a catalog node
+type UpdateCatalogStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active archived"`
+}
+
+// PublishCatalogRequest is the optional payload when publishing the catalog draft
+type PublishCatalogRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// TreatmentRequirementRequest is one room or equipment requirement of a treatment
+type TreatmentRequirementRequest struct {
+	SideAreaID   uint   `json:"side_area_id,omitempty"`
+	Kind         string `json:"kind" validate:"required,oneof=room equipment"`
+	ResourceType string `json:"resource_type" validate:"required"`
+	Quantity     int    `json:"quantity"`
+}
+
+// SetTreatmentRequirementsRequest replaces all resource requirements of a treatment
+type SetTreatmentRequirementsRequest struct {
+	Requirements []TreatmentRequirementRequest `json:"requirements"`
+}
@@ -0,0 +1,12 @@
+package request
+
+// JoinWaitlistRequest puts the customer on a practitioner's waitlist for a treatment.
+// from_date/to_date are YYYY-MM-DD in the clinic's timezone; items size the slots offered.
+type JoinWaitlistRequest struct {
+	ClinicID       uint64                   `json:"clinic_id"`
+	PractitionerID uint64                   `json:"practitioner_id"`
+	TreatmentID    uint                     `json:"treatment_id"`
+	FromDate       string                   `json:"from_date"`
+	ToDate         string                   `json:"to_date"`
+	Items          []AppointmentItemRequest `json:"items"`
+}
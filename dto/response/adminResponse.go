@@ -19,3 +19,14 @@ type AdminInfoResponse struct {
 	BaseResponse
 	Data models.AdminUser `json:"data"`
 }
+
+// AdminUserListResponse contains one page of admin accounts
+type AdminUserListResponse struct {
+	Items []models.AdminUser `json:"items"`
+	Meta  PageMeta           `json:"meta"`
+}
+
+// AdminPasswordResetResponse contains the temporary password when one was generated
+type AdminPasswordResetResponse struct {
+	TemporaryPassword string `json:"temporary_password,omitempty"`
+}
@@ -0,0 +1,92 @@
+package response
+
+// AnalyticsRange echoes the date range and grouping an analytics query used
+type AnalyticsRange struct {
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	GroupBy string `json:"group_by,omitempty"`
+}
+
+// SignupBucket is the number of customers who signed up in one period with one provider
+type SignupBucket struct {
+	Period   string `json:"period"`
+	Provider string `json:"provider"`
+	Count    int64  `json:"count"`
+}
+
+// SignupAnalyticsResponse contains signups over time by login provider
+type SignupAnalyticsResponse struct {
+	Range   AnalyticsRange `json:"range"`
+	Total   int64          `json:"total"`
+	Buckets []SignupBucket `json:"buckets"`
+}
+
+// OnboardingQuestionStat is how many customers answered one onboarding question
+type OnboardingQuestionStat struct {
+	QuestionID   uint64 `json:"question_id"`
+	QuestionText string `json:"question_text"`
+	Answered     int64  `json:"answered"`
+}
+
+// OnboardingAnalyticsResponse contains onboarding completion for customers who signed up in the range
+type OnboardingAnalyticsResponse struct {
+	Range          AnalyticsRange           `json:"range"`
+	Customers      int64                    `json:"customers"`
+	Started        int64                    `json:"started"`
+	Completed      int64                    `json:"completed"`
+	WithProfile    int64                    `json:"with_profile"`
+	CompletionRate float64                  `json:"completion_rate"`
+	Questions      []OnboardingQuestionStat `json:"questions"`
+}
+
+// CountBucket is a labelled count
+type CountBucket struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+// ClinicAnalyticsResponse contains clinic and practitioner counts
+type ClinicAnalyticsResponse struct {
+	Range         AnalyticsRange `json:"range"`
+	Clinics       int64          `json:"clinics"`
+	ClinicsBy     []CountBucket  `json:"clinics_by"`
+	Doctors       int64          `json:"doctors"`
+	DoctorsByRole []CountBucket  `json:"doctors_by_role"`
+	StaffByRole   []CountBucket  `json:"staff_by_role"`
+}
+
+// ClinicTreatmentCount is the number of treatments one clinic offers
+type ClinicTreatmentCount struct {
+	ClinicID   uint64 `json:"clinic_id"`
+	ClinicName string `json:"clinic_name"`
+	Treatments int64  `json:"treatments"`
+	SideAreas  int64  `json:"side_areas"`
+}
+
+// ClinicTreatmentAnalyticsResponse contains treatments offered per clinic
+type ClinicTreatmentAnalyticsResponse struct {
+	Clinics       int64                  `json:"clinics"`
+	AvgTreatments float64                `json:"avg_treatments"`
+	Items         []ClinicTreatmentCount `json:"items"`
+	Meta          PageMeta               `json:"meta"`
+}
+
+// PriceStat summarizes clinic prices for one group (side area, area or treatment)
+type PriceStat struct {
+	TreatmentID uint    `json:"treatment_id"`
+	AreaID      uint    `json:"area_id,omitempty"`
+	SideAreaID  uint    `json:"side_area_id,omitempty"`
+	SyringeSize *int    `json:"syringe_size,omitempty"`
+	Name        string  `json:"name"`
+	Clinics     int64   `json:"clinics"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Avg         float64 `json:"avg"`
+	StdDev      float64 `json:"std_dev"`
+}
+
+// PriceAnalyticsResponse contains price distributions
+type PriceAnalyticsResponse struct {
+	GroupBy string      `json:"group_by"`
+	Items   []PriceStat `json:"items"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import "skinSync/models"
+
+// AppointmentItemDTO is a booked side area with its catalog names
+type AppointmentItemDTO struct {
+	models.AppointmentItem
+	AreaName     string `json:"area_name"`
+	SideAreaName string `json:"side_area_name"`
+}
+
+// AppointmentDTO is an appointment with the names needed to display it.
+// Times are UTC; timezone is the clinic's IANA zone for presentation.
+// NextStatuses lists the statuses the viewer may move the appointment to.
+// Policy is the cancellation policy it was booked under; LateCancelFeeApplies warns that
+// cancelling now is charged the late-cancel fee.
+type AppointmentDTO struct {
+	models.Appointment
+	ClinicName       string                   `json:"clinic_name"`
+	Timezone         string                   `json:"timezone"`
+	Currency         string                   `json:"currency"`
+	CustomerName     string                   `json:"customer_name,omitempty"`
+	CustomerEmail    string                   `json:"customer_email,omitempty"`
+	PractitionerName string                   `json:"practitioner_name"`
+	TreatmentName    string                   `json:"treatment_name"`
+	Items            []AppointmentItemDTO     `json:"items"`
+	Resources        []AppointmentResourceDTO `json:"resources"`
+	NextStatuses     []string                 `json:"next_statuses"`
+
+	Policy               *models.AppointmentPolicy `json:"policy,omitempty"`
+	LateCancelFeeApplies bool                      `json:"late_cancel_fee_applies,omitempty"`
+	Charges              []models.CustomerCharge   `json:"charges,omitempty"`
+}
+
+// AppointmentListResponse is a page of appointments
+type AppointmentListResponse struct {
+	Items []AppointmentDTO `json:"items"`
+	Meta  PageMeta         `json:"meta"`
+}
@@ -0,0 +1,9 @@
+package response
+
+import "skinSync/models"
+
+// AuditLogListResponse contains one page of audit log entries
+type AuditLogListResponse struct {
+	Items []models.AuditLog `json:"items"`
+	Meta  PageMeta          `json:"meta"`
+}
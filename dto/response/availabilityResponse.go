@@ -0,0 +1,42 @@
+package response
+
+import (
+	"time"
+
+	"skinSync/models"
+)
+
+// ClinicOpeningHoursDTO is a clinic's weekly opening hours in its timezone
+type ClinicOpeningHoursDTO struct {
+	Timezone     string                     `json:"timezone"`
+	OpeningHours []models.ClinicOpeningHour `json:"opening_hours"`
+}
+
+// PractitionerAvailabilityDTO is a practitioner's weekly schedule and upcoming exceptions
+type PractitionerAvailabilityDTO struct {
+	PractitionerID uint64                                     `json:"practitioner_id"`
+	Name           string                                     `json:"name"`
+	Timezone       string                                     `json:"timezone"`
+	Weekly         []models.PractitionerAvailability          `json:"weekly"`
+	Exceptions     []models.PractitionerAvailabilityException `json:"exceptions"`
+}
+
+// AvailableSlotDTO is one bookable start time. LocalStart is in the clinic's timezone.
+type AvailableSlotDTO struct {
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+	LocalStart       string    `json:"local_start"`
+	PractitionerID   uint64    `json:"practitioner_id"`
+	PractitionerName string    `json:"practitioner_name"`
+}
+
+// AvailableSlotsResponse lists bookable slots for a treatment at a clinic
+type AvailableSlotsResponse struct {
+	ClinicID        uint64             `json:"clinic_id"`
+	Timezone        string             `json:"timezone"`
+	TreatmentID     uint               `json:"treatment_id"`
+	DurationMinutes int                `json:"duration_minutes"`
+	From            string             `json:"from"`
+	To              string             `json:"to"`
+	Slots           []AvailableSlotDTO `json:"slots"`
+}
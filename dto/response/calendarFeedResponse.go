@@ -0,0 +1,11 @@
+package response
+
+import "skinSync/models"
+
+// CalendarFeedDTO is a calendar feed as listed to its owner or the clinic.
+// URL carries the secret token and is only returned when the feed is created.
+type CalendarFeedDTO struct {
+	models.CalendarFeed
+	OwnerName string `json:"owner_name,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"time"
+
+	"skinSync/models"
+)
+
+// TreatmentCancellationPolicyDTO is a clinic's policy for one treatment
+type TreatmentCancellationPolicyDTO struct {
+	models.ClinicCancellationPolicy
+	TreatmentName string `json:"treatment_name"`
+}
+
+// ClinicCancellationPoliciesDTO lists a clinic's default policy and its treatment-specific policies
+type ClinicCancellationPoliciesDTO struct {
+	Currency   string                           `json:"currency"`
+	Default    *models.ClinicCancellationPolicy `json:"default"`
+	Treatments []TreatmentCancellationPolicyDTO `json:"treatments"`
+}
+
+// BookingPolicyDTO is the cancellation policy shown to a customer before booking a treatment.
+// Policy is nil when the clinic charges no fees; Terms describes it in plain sentences.
+type BookingPolicyDTO struct {
+	ClinicID    uint64                           `json:"clinic_id"`
+	TreatmentID uint                             `json:"treatment_id"`
+	Currency    string                           `json:"currency"`
+	Policy      *models.ClinicCancellationPolicy `json:"policy"`
+	Terms       []string                         `json:"terms"`
+}
+
+// CustomerChargeDTO is a customer charge with the names needed to display it
+type CustomerChargeDTO struct {
+	models.CustomerCharge
+	ClinicName         string    `json:"clinic_name"`
+	CustomerName       string    `json:"customer_name,omitempty"`
+	AppointmentStartAt time.Time `json:"appointment_start_at"`
+}
+
+// CustomerChargeListResponse is a page of customer charges
+type CustomerChargeListResponse struct {
+	Items []CustomerChargeDTO `json:"items"`
+	Meta  PageMeta            `json:"meta"`
+}
@@ -1,5 +1,11 @@
 package response
 
+import (
+	"time"
+
+	"skinSync/models"
+)
+
 // RegisterClinicResponse represents clinic registration response
 type RegisterClinicResponse struct {
 	BaseResponse
@@ -119,3 +125,100 @@ type GetClinicTreatmentsResponse struct {
 	BaseResponse
 	Data []ClinicTreatmentDTO `json:"data"`
 }
+
+// AdminClinicDTO is a clinic row in admin listings
+type AdminClinicDTO struct {
+	ClinicDTO
+	StaffCount     int64      `json:"staff_count"`
+	TreatmentCount int64      `json:"treatment_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+// AdminClinicListResponse is a page of clinics
+type AdminClinicListResponse struct {
+	Items []AdminClinicDTO `json:"items"`
+	Meta  PageMeta         `json:"meta"`
+}
+
+// AdminClinicDetailDTO is the admin view of a clinic with its owners
+type AdminClinicDetailDTO struct {
+	AdminClinicDTO
+	Owners []ClinicUserDTO `json:"owners"`
+}
+
+// ClinicApplicationListResponse is a page of clinic applications
+type ClinicApplicationListResponse struct {
+	Items []models.ClinicApplication `json:"items"`
+	Meta  PageMeta                   `json:"meta"`
+}
+
+// SubmitClinicApplicationResponse returns the new application and the token the applicant uses to follow it up.
+// The token is only shown once.
+type SubmitClinicApplicationResponse struct {
+	Application *models.ClinicApplication `json:"application"`
+	AccessToken string                    `json:"access_token"`
+}
+
+// ApproveClinicApplicationResponse returns the approved application and the registered clinic
+type ApproveClinicApplicationResponse struct {
+	Application *models.ClinicApplication `json:"application"`
+	Clinic      *RegisterClinicData       `json:"clinic"`
+}
+
+// ClinicSettingsDTO is a clinic's own view of its profile and settings.
+// PendingChange holds public profile edits not yet approved; they are not shown in discovery.
+// LastReviewedChange is the most recent approved or rejected change, including the reviewer's note.
+type ClinicSettingsDTO struct {
+	Clinic             *models.Clinic              `json:"clinic"`
+	PendingChange      *models.ClinicProfileChange `json:"pending_change,omitempty"`
+	LastReviewedChange *models.ClinicProfileChange `json:"last_reviewed_change,omitempty"`
+	ReviewRequired     bool                        `json:"review_required"`
+}
+
+// ClinicProfileChangeListResponse is a page of clinic profile changes
+type ClinicProfileChangeListResponse struct {
+	Items []ClinicProfileChangeDTO `json:"items"`
+	Meta  PageMeta                 `json:"meta"`
+}
+
+// ClinicProfileChangeDTO is a profile change with the clinic's current public values for comparison
+type ClinicProfileChangeDTO struct {
+	models.ClinicProfileChange
+	ClinicName string         `json:"clinic_name"`
+	Current    *models.Clinic `json:"current,omitempty"`
+}
+
+// ClinicStaffDTO is a clinic user as seen by staff managers
+type ClinicStaffDTO struct {
+	ID             uint64     `json:"id"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	RoleID         uint64     `json:"role_id"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	LastLogin      *time.Time `json:"last_login,omitempty"`
+	TreatmentCount int64      `json:"treatment_count"`
+	SideAreaCount  int64      `json:"side_area_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ClinicStaffListResponse is a page of clinic staff
+type ClinicStaffListResponse struct {
+	Items []ClinicStaffDTO `json:"items"`
+	Meta  PageMeta         `json:"meta"`
+}
+
+// ClinicMeDTO is the signed-in clinic user's own profile with their effective permissions
+type ClinicMeDTO struct {
+	ID          uint64                    `json:"id"`
+	Email       string                    `json:"email"`
+	Name        string                    `json:"name"`
+	Role        string                    `json:"role"`
+	Status      string                    `json:"status"`
+	LastLogin   *time.Time                `json:"last_login,omitempty"`
+	Profile     *models.ClinicUserProfile `json:"profile,omitempty"`
+	Permissions []string                  `json:"permissions"`
+	Clinic      *ClinicDTO                `json:"clinic,omitempty"`
+}
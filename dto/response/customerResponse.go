@@ -0,0 +1,69 @@
+package response
+
+import "time"
+
+// PageMeta describes a page of a paginated list
+type PageMeta struct {
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int64 `json:"total"`
+}
+
+// CustomerSummaryDTO is a customer row in admin search results
+type CustomerSummaryDTO struct {
+	ID           uint64     `json:"id"`
+	Name         string     `json:"name,omitempty"`
+	PrimaryEmail *string    `json:"primary_email"`
+	PrimaryPhone *string    `json:"primary_phone"`
+	Status       string     `json:"status"`
+	Providers    []string   `json:"providers"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CustomerListResponse is a page of customers
+type CustomerListResponse struct {
+	Items []CustomerSummaryDTO `json:"items"`
+	Meta  PageMeta             `json:"meta"`
+}
+
+// CustomerProviderDTO is a linked login method (secrets omitted)
+type CustomerProviderDTO struct {
+	ID        uint64    `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     *string   `json:"email,omitempty"`
+	Phone     *string   `json:"phone,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CustomerSessionDTO is a customer token pair (tokens omitted)
+type CustomerSessionDTO struct {
+	ID               uint64    `json:"id"`
+	DeviceInfo       *string   `json:"device_info,omitempty"`
+	IPAddress        *string   `json:"ip_address,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastRefreshedAt  time.Time `json:"last_refreshed_at"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	IsRevoked        bool      `json:"is_revoked"`
+	Active           bool      `json:"active"`
+}
+
+// CustomerProfileDTO is the customer's onboarding profile
+type CustomerProfileDTO struct {
+	Name             string  `json:"name"`
+	PhoneNumber      *string `json:"phone_number,omitempty"`
+	EmailAddress     *string `json:"email_address,omitempty"`
+	Location         *string `json:"location,omitempty"`
+	Bio              *string `json:"bio,omitempty"`
+	ProfileImagePath *string `json:"profile_image_path,omitempty"`
+}
+
+// CustomerDetailDTO is the full admin view of a customer
+type CustomerDetailDTO struct {
+	CustomerSummaryDTO
+	Profile       *CustomerProfileDTO    `json:"profile,omitempty"`
+	AuthProviders []CustomerProviderDTO  `json:"auth_providers"`
+	Sessions      []CustomerSessionDTO   `json:"sessions"`
+	Onboarding    UserOnboardingResponse `json:"onboarding"`
+}
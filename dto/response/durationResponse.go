@@ -0,0 +1,46 @@
+package response
+
+import "skinSync/models"
+
+// AppointmentDurationItemDTO is the computed length of one selected side area
+type AppointmentDurationItemDTO struct {
+	SideAreaID   uint   `json:"side_area_id"`
+	SideAreaName string `json:"side_area_name"`
+	SyringeCount int    `json:"syringe_count"`
+	SyringeSize  int    `json:"syringe_size,omitempty"`
+	Minutes      int    `json:"minutes"`
+}
+
+// AppointmentDurationDTO is the computed appointment length for a treatment selection at a clinic.
+// DefaultApplied is set when neither the catalog nor the clinic configures durations.
+type AppointmentDurationDTO struct {
+	ClinicID       uint64                                `json:"clinic_id"`
+	TreatmentID    uint                                  `json:"treatment_id"`
+	BaseMinutes    int                                   `json:"base_minutes"`
+	Items          []AppointmentDurationItemDTO          `json:"items"`
+	TotalMinutes   int                                   `json:"total_minutes"`
+	DefaultApplied bool                                  `json:"default_applied"`
+	Requirements   []models.TreatmentResourceRequirement `json:"requirements"`
+}
+
+// ClinicSideAreaDurationDTO shows the catalog, override and effective durations of one priced side area
+type ClinicSideAreaDurationDTO struct {
+	SideAreaID                   uint   `json:"side_area_id"`
+	SideAreaName                 string `json:"side_area_name"`
+	SyringeSize                  int    `json:"syringe_size,omitempty"`
+	CatalogMinutes               int    `json:"catalog_minutes"`
+	CatalogExtraSyringeMinutes   int    `json:"catalog_extra_syringe_minutes"`
+	DurationMinutes              *int   `json:"duration_minutes,omitempty"`
+	ExtraSyringeMinutes          *int   `json:"extra_syringe_minutes,omitempty"`
+	EffectiveMinutes             int    `json:"effective_minutes"`
+	EffectiveExtraSyringeMinutes int    `json:"effective_extra_syringe_minutes"`
+}
+
+// ClinicTreatmentDurationsDTO lists a clinic's duration settings for one treatment
+type ClinicTreatmentDurationsDTO struct {
+	TreatmentID          uint                        `json:"treatment_id"`
+	CatalogBaseMinutes   int                         `json:"catalog_base_minutes"`
+	BaseMinutes          *int                        `json:"base_minutes,omitempty"`
+	EffectiveBaseMinutes int                         `json:"effective_base_minutes"`
+	SideAreas            []ClinicSideAreaDurationDTO `json:"side_areas"`
+}
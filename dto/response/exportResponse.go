@@ -0,0 +1,9 @@
+package response
+
+import "skinSync/models"
+
+// ExportJobListResponse contains one page of export jobs
+type ExportJobListResponse struct {
+	Items []models.ExportJob `json:"items"`
+	Meta  PageMeta           `json:"meta"`
+}
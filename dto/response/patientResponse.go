@@ -0,0 +1,30 @@
+package response
+
+import (
+	"time"
+
+	"skinSync/models"
+)
+
+// PatientDTO is a clinic's patient record. Profile and Onboarding are the customer's own data and
+// are only filled while the customer shares them with the clinic.
+type PatientDTO struct {
+	models.ClinicPatient
+	ProfileShared bool                    `json:"profile_shared"`
+	Profile       *CustomerProfileDTO     `json:"profile,omitempty"`
+	Onboarding    *UserOnboardingResponse `json:"onboarding,omitempty"`
+}
+
+// PatientListResponse is a page of patients
+type PatientListResponse struct {
+	Items []PatientDTO `json:"items"`
+	Meta  PageMeta     `json:"meta"`
+}
+
+// PatientConsentDTO is a clinic the customer is a patient of, with their sharing choice
+type PatientConsentDTO struct {
+	ClinicID        uint64     `json:"clinic_id"`
+	ClinicName      string     `json:"clinic_name"`
+	ProfileShared   bool       `json:"profile_shared"`
+	ProfileSharedAt *time.Time `json:"profile_shared_at,omitempty"`
+}
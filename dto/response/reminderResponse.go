@@ -0,0 +1,19 @@
+package response
+
+import "skinSync/models"
+
+// ClinicReminderSettingsDTO lists a clinic's reminder rules. UsingDefaults is set when the clinic
+// has not configured any and the default reminders apply; Placeholders lists the template variables.
+type ClinicReminderSettingsDTO struct {
+	UsingDefaults bool                        `json:"using_defaults"`
+	Rules         []models.ClinicReminderRule `json:"rules"`
+	Channels      []string                    `json:"channels"`
+	Placeholders  []string                    `json:"placeholders"`
+}
+
+// AppointmentActionDTO describes what a signed reminder link does and the appointment it applies to
+type AppointmentActionDTO struct {
+	Action      string         `json:"action"`
+	Allowed     bool           `json:"allowed"`
+	Appointment AppointmentDTO `json:"appointment"`
+}
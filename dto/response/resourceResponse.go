@@ -0,0 +1,21 @@
+package response
+
+import "skinSync/models"
+
+// AppointmentResourceDTO is a room or device unit held by an appointment
+type AppointmentResourceDTO struct {
+	ClinicResourceID uint64 `json:"clinic_resource_id"`
+	Name             string `json:"name"`
+	Kind             string `json:"kind"`
+	ResourceType     string `json:"resource_type"`
+	Unit             int    `json:"unit"`
+}
+
+// ClinicTreatmentRequirementsDTO shows a treatment's catalog requirements, the clinic's adjustments
+// and the resulting requirements enforced at the clinic
+type ClinicTreatmentRequirementsDTO struct {
+	TreatmentID uint                                  `json:"treatment_id"`
+	Catalog     []models.TreatmentResourceRequirement `json:"catalog"`
+	Clinic      []models.ClinicTreatmentRequirement   `json:"clinic"`
+	Effective   []models.TreatmentResourceRequirement `json:"effective"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "skinSync/models"
+
+// TreatmentRecordItemDTO is a treated side area with its catalog names
+type TreatmentRecordItemDTO struct {
+	models.TreatmentRecordItem
+	AreaName     string `json:"area_name"`
+	SideAreaName string `json:"side_area_name"`
+}
+
+// TreatmentRecordAddendumDTO is an addendum with its author's name
+type TreatmentRecordAddendumDTO struct {
+	models.TreatmentRecordAddendum
+	AuthorName string `json:"author_name"`
+}
+
+// TreatmentRecordDTO is a treatment record with the names needed to display it
+type TreatmentRecordDTO struct {
+	models.TreatmentRecord
+	PatientName      string                       `json:"patient_name"`
+	PractitionerName string                       `json:"practitioner_name"`
+	TreatmentName    string                       `json:"treatment_name"`
+	SignedByName     string                       `json:"signed_by_name,omitempty"`
+	Items            []TreatmentRecordItemDTO     `json:"items"`
+	Addenda          []TreatmentRecordAddendumDTO `json:"addenda"`
+}
+
+// TreatmentRecordListResponse is a page of treatment records
+type TreatmentRecordListResponse struct {
+	Items []TreatmentRecordDTO `json:"items"`
+	Meta  PageMeta             `json:"meta"`
+}
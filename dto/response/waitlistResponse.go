@@ -0,0 +1,27 @@
+package response
+
+import "skinSync/models"
+
+// WaitlistEntryDTO is a waitlist entry with the names needed to display it.
+// Position is the entry's place in the practitioner's line while waiting; Offer is its open offer.
+type WaitlistEntryDTO struct {
+	models.WaitlistEntry
+	ClinicName       string                `json:"clinic_name"`
+	Timezone         string                `json:"timezone"`
+	PractitionerName string                `json:"practitioner_name"`
+	TreatmentName    string                `json:"treatment_name"`
+	CustomerName     string                `json:"customer_name,omitempty"`
+	Position         int                   `json:"position,omitempty"`
+	Offer            *models.WaitlistOffer `json:"offer,omitempty"`
+}
+
+// WaitlistOfferDTO is an offered slot as shown behind a claim link
+type WaitlistOfferDTO struct {
+	models.WaitlistOffer
+	ClinicName       string          `json:"clinic_name"`
+	Timezone         string          `json:"timezone"`
+	PractitionerName string          `json:"practitioner_name"`
+	TreatmentName    string          `json:"treatment_name"`
+	Claimable        bool            `json:"claimable"`
+	Appointment      *AppointmentDTO `json:"appointment,omitempty"`
+}
@@ -12,10 +12,15 @@ import (
 
 func main() {
 	config.ConnectDB()
+	services.BackfillClinicPatients()
+	services.FailStaleExportJobs()
 
 	// Start background cleanup goroutines
 	services.StartOTPCleanup()
 	services.StartTokenBlacklistCleanup()
+	services.StartAppointmentReminders()
+	services.StartWaitlistOffers()
+	services.StartExportCleanup()
 
 	e := echo.New()
 	e.Binder = &middlewares.CustomBinder{}
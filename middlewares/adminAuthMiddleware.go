@@ -3,6 +3,7 @@ package middlewares
 import (
 	"net/http"
 	"skinSync/dto/response"
+	"skinSync/services"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
@@ -64,6 +65,15 @@ func AdminAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			})
 		}
 
+		adminIDf, _ := adminID.(float64)
+		issuedAt := services.TokenIssuedAt(claims)
+		if services.IsAdminSessionRevoked(uint64(adminIDf), issuedAt) {
+			return c.JSON(http.StatusUnauthorized, response.BaseResponse{
+				IsSuccess: false,
+				Message:   "session has been revoked",
+			})
+		}
+
 		// Set admin context
 		c.Set("admin_id", adminID)
 		c.Set("admin_role", role)
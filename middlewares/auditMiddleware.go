@@ -0,0 +1,210 @@
+package middlewares
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"skinSync/models"
+	"skinSync/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxAuditBodyBytes caps how much of a request or response body is inspected for auditing
+const maxAuditBodyBytes = 64 << 10
+
+// auditResponseRecorder keeps a copy of the start of the response body
+type auditResponseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *auditResponseRecorder) Write(b []byte) (int, error) {
+	if remaining := maxAuditBodyBytes - r.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			r.body.Write(b[:remaining])
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *auditResponseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *auditResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := r.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("response does not support hijacking")
+}
+
+// auditActorFromContext reads the authenticated actor set by the auth middlewares
+func auditActorFromContext(c echo.Context) (services.AuditActor, bool) {
+	email, _ := c.Get("email").(string)
+	if id, ok := c.Get("admin_id").(float64); ok {
+		return services.AuditActor{Type: models.AuditActorAdmin, ID: uint64(id), Email: email}, true
+	}
+	if id, ok := c.Get("clinic_user_id").(float64); ok {
+		actor := services.AuditActor{Type: models.AuditActorClinicUser, ID: uint64(id), Email: email}
+		if clinicID, ok := c.Get("clinic_id").(float64); ok {
+			cid := uint64(clinicID)
+			actor.ClinicID = &cid
+		}
+		return actor, true
+	}
+	if id, ok := c.Get("user_id").(float64); ok {
+		return services.AuditActor{Type: models.AuditActorCustomer, ID: uint64(id), Email: email}, true
+	}
+	return services.AuditActor{}, false
+}
+
+// auditAction derives the audit action from the HTTP method
+func auditAction(method string, existed bool) string {
+	switch method {
+	case http.MethodDelete:
+		return "delete"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	default:
+		if existed {
+			return "update"
+		}
+		return "create"
+	}
+}
+
+// readAuditBody returns the decoded JSON request body and restores it for the handler
+func readAuditBody(c echo.Context) interface{} {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	raw, err := io.ReadAll(io.LimitReader(req.Body, maxAuditBodyBytes+1))
+	if err != nil {
+		return nil
+	}
+	req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), req.Body))
+	if len(raw) > maxAuditBodyBytes {
+		return nil
+	}
+	var body interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil
+	}
+	return body
+}
+
+// createdEntityID reads data.id from a JSON response body
+func createdEntityID(raw []byte) uint64 {
+	var resp struct {
+		Data struct {
+			ID interface{} `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return 0
+	}
+	return services.AuditEntityID(resp.Data.ID)
+}
+
+// AuditTrail records every successful mutating request to the audit log.
+// Must run after an auth middleware so the actor is known. For routes with a
+// registered audit target the entity is snapshotted before and after the handler
+// and only changed fields are stored; otherwise the redacted request payload is stored.
+func AuditTrail(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		method := c.Request().Method
+		if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+			return next(c)
+		}
+		route := c.Path()
+		if !services.ShouldAuditRoute(route) {
+			return next(c)
+		}
+		actor, ok := auditActorFromContext(c)
+		if !ok {
+			return next(c)
+		}
+
+		body := readAuditBody(c)
+		target, hasTarget := services.ResolveAuditTarget(route)
+
+		var entityID uint64
+		var before map[string]interface{}
+		if hasTarget {
+			switch {
+			case target.FromActor:
+				entityID = actor.ID
+			case target.FromClinic:
+				if actor.ClinicID != nil {
+					entityID = *actor.ClinicID
+				}
+			case target.Param != "":
+				entityID = services.AuditEntityID(c.Param(target.Param))
+			case target.BodyField != "":
+				if m, ok := body.(map[string]interface{}); ok {
+					entityID = services.AuditEntityID(m[target.BodyField])
+				}
+			}
+			before = services.SnapshotAuditEntity(target, actor, entityID)
+		}
+
+		recorder := &auditResponseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		err := next(c)
+		c.Response().Writer = recorder.ResponseWriter
+
+		status := c.Response().Status
+		if err != nil || status >= http.StatusBadRequest {
+			return err
+		}
+
+		if entityID == 0 {
+			entityID = createdEntityID(recorder.body.Bytes())
+		}
+
+		entry := services.AuditEntry{
+			Actor:      actor,
+			Action:     auditAction(method, before != nil),
+			Method:     method,
+			Route:      route,
+			StatusCode: status,
+			IPAddress:  c.RealIP(),
+			UserAgent:  c.Request().UserAgent(),
+		}
+		if hasTarget {
+			entry.EntityType = target.EntityType
+			entry.EntityID = entityID
+			after := services.SnapshotAuditEntity(target, actor, entityID)
+			changedBefore, changedAfter := services.DiffAuditSnapshots(before, after)
+			if len(changedBefore) > 0 {
+				entry.Before = changedBefore
+			}
+			if len(changedAfter) > 0 {
+				entry.After = changedAfter
+			}
+		} else {
+			entry.EntityID = entityID
+		}
+		if entry.Before == nil && entry.After == nil && body != nil {
+			entry.After = services.RedactAuditPayload(body)
+		}
+
+		if auditErr := services.RecordAudit(entry); auditErr != nil {
+			log.Printf("audit: failed to record %s %s: %v", method, route, auditErr)
+		}
+		return nil
+	}
+}
@@ -103,6 +103,15 @@ func ClinicAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			})
 		}
 
+		// Reject tokens issued before the clinic or user sessions were revoked (suspension, removal)
+		issuedAt := services.TokenIssuedAt(claims)
+		if services.IsClinicSessionRevoked(uint64(clinicID), uint64(clinicUserID), issuedAt) {
+			return c.JSON(http.StatusUnauthorized, resdto.BaseResponse{
+				IsSuccess: false,
+				Message:   "session has been revoked",
+			})
+		}
+
 		// Set context values for downstream handlers
 		c.Set("clinic_user_id", clinicUserID)
 		c.Set("clinic_id", clinicID)
@@ -3,6 +3,7 @@ package middlewares
 import (
 	"net/http"
 	"skinSync/dto/response"
+	"skinSync/services"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
@@ -20,6 +21,11 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Extract token from "Bearer <token>"
 		tokenString := authHeader[len("Bearer "):]
 
+		// Reject sessions revoked by logout, suspension or admin force-logout
+		if services.IsTokenBlacklisted(tokenString) {
+			return c.JSON(http.StatusUnauthorized, response.BaseResponse{Message: "token has been revoked"})
+		}
+
 		// Parse and validate JWT token
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -37,6 +43,12 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return c.JSON(http.StatusUnauthorized, response.BaseResponse{Message: "invalid token claims"})
 		}
 
+		// Reject tokens issued before a force logout, and suspended or deleted customers
+		userID, _ := claims["user_id"].(float64)
+		if services.IsUserSessionRevoked(uint64(userID), services.TokenIssuedAt(claims)) {
+			return c.JSON(http.StatusUnauthorized, response.BaseResponse{Message: "session has been revoked"})
+		}
+
 		c.Set("email", claims["email"])
 		c.Set("user_id", claims["user_id"])
 
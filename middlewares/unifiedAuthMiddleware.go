@@ -3,6 +3,7 @@ package middlewares
 import (
 	"net/http"
 	"skinSync/dto/response"
+	"skinSync/services"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
@@ -31,6 +32,14 @@ func UnifiedAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Extract token from "Bearer <token>"
 		tokenString := authHeader[7:]
 
+		// Reject tokens that were logged out or revoked
+		if services.IsTokenBlacklisted(tokenString) {
+			return c.JSON(http.StatusUnauthorized, response.BaseResponse{
+				IsSuccess: false,
+				Message:   "token has been revoked",
+			})
+		}
+
 		// Parse and validate JWT token
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -57,12 +66,29 @@ func UnifiedAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Determine token type and set context values accordingly
 		if adminID, hasAdminID := claims["admin_id"]; hasAdminID {
 			// Admin token
+			aID, _ := adminID.(float64)
+			issuedAt := services.TokenIssuedAt(claims)
+			if services.IsAdminSessionRevoked(uint64(aID), issuedAt) {
+				return c.JSON(http.StatusUnauthorized, response.BaseResponse{
+					IsSuccess: false,
+					Message:   "session has been revoked",
+				})
+			}
 			c.Set("user_type", "admin")
 			c.Set("admin_id", adminID)
 			c.Set("admin_role", claims["role"])
 			c.Set("email", claims["email"])
 		} else if clinicUserID, hasClinicUserID := claims["clinic_user_id"]; hasClinicUserID {
 			// Clinic token
+			cuID, _ := clinicUserID.(float64)
+			cID, _ := claims["clinic_id"].(float64)
+			issuedAt := services.TokenIssuedAt(claims)
+			if services.IsClinicSessionRevoked(uint64(cID), uint64(cuID), issuedAt) {
+				return c.JSON(http.StatusUnauthorized, response.BaseResponse{
+					IsSuccess: false,
+					Message:   "session has been revoked",
+				})
+			}
 			c.Set("user_type", "clinic")
 			c.Set("clinic_user_id", clinicUserID)
 			c.Set("clinic_id", claims["clinic_id"])
@@ -70,6 +96,14 @@ func UnifiedAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			c.Set("email", claims["email"])
 		} else if userID, hasUserID := claims["user_id"]; hasUserID {
 			// Customer token
+			uID, _ := userID.(float64)
+			issuedAt := services.TokenIssuedAt(claims)
+			if services.IsUserSessionRevoked(uint64(uID), issuedAt) {
+				return c.JSON(http.StatusUnauthorized, response.BaseResponse{
+					IsSuccess: false,
+					Message:   "session has been revoked",
+				})
+			}
 			c.Set("user_type", "customer")
 			c.Set("user_id", userID)
 			c.Set("email", claims["email"])
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"net/http"
+	"skinSync/dto/response"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// clinicApplicationBodyLimit covers 10 documents of 10 MB plus the form fields
+const clinicApplicationBodyLimit = "101M"
+
+// ClinicApplicationUploadLimits guards the public clinic application uploads:
+// it caps the request body and allows each client IP 5 uploads per hour (burst 3).
+// The returned middlewares share one limiter, so mount the same slice on every upload route.
+func ClinicApplicationUploadLimits() []echo.MiddlewareFunc {
+	store := middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+		Rate:      5.0 / 3600,
+		Burst:     3,
+		ExpiresIn: time.Hour,
+	})
+	limiter := middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Store: store,
+		ErrorHandler: func(c echo.Context, err error) error {
+			return c.JSON(http.StatusForbidden, response.BaseResponse{
+				IsSuccess: false,
+				Message:   "unable to identify client",
+			})
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			return c.JSON(http.StatusTooManyRequests, response.BaseResponse{
+				IsSuccess: false,
+				Message:   "too many application uploads, please try again later",
+			})
+		},
+	})
+	return []echo.MiddlewareFunc{limiter, middleware.BodyLimit(clinicApplicationBodyLimit)}
+}
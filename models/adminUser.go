@@ -19,9 +19,19 @@ type AdminUser struct {
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Tokens issued before this time are rejected (role change, deactivation, deletion, password reset)
+	SessionsRevokedAt *time.Time `json:"-"`
 }
 
 // TableName specifies the table name for AdminUser
 func (AdminUser) TableName() string {
 	return "admin_users"
 }
+
+// Admin status and role constants
+const (
+	AdminStatusActive   = "active"
+	AdminStatusInactive = "inactive"
+	AdminRoleSuperAdmin = "super_admin"
+)
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// Appointment is a customer's booking of a practitioner for a treatment at a clinic.
+// StartAt/EndAt are stored in UTC; the clinic timezone is applied when presenting them.
+type Appointment struct {
+	ID              uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID        uint64     `gorm:"not null;index:idx_appointment_clinic_start" json:"clinic_id"`
+	UserID          uint64     `gorm:"not null;index" json:"user_id"`
+	PractitionerID  uint64     `gorm:"not null;index:idx_appointment_practitioner_start" json:"practitioner_id"` // clinic user (doctor/injector)
+	TreatmentID     uint       `gorm:"not null" json:"treatment_id"`
+	StartAt         time.Time  `gorm:"not null;index:idx_appointment_clinic_start;index:idx_appointment_practitioner_start" json:"start_at"`
+	EndAt           time.Time  `gorm:"not null" json:"end_at"`
+	BufferMinutes   int        `gorm:"not null;default:0" json:"buffer_minutes"` // practitioner kept free after EndAt
+	Status          string     `gorm:"size:20;not null;default:'requested';index" json:"status"`
+	Price           *float64   `json:"price,omitempty"` // estimate from the clinic's prices at booking time
+	CustomerNotes   string     `gorm:"type:text" json:"customer_notes,omitempty"`
+	ClinicNotes     string     `gorm:"type:text" json:"clinic_notes,omitempty"` // never shown to the customer
+	CancelReason    string     `gorm:"type:text" json:"cancel_reason,omitempty"`
+	CancelledByType string     `gorm:"size:20" json:"cancelled_by_type,omitempty"` // customer, clinic_user, admin
+	CreatedByType   string     `gorm:"size:20;not null" json:"created_by_type"`
+	CreatedByID     uint64     `gorm:"not null" json:"created_by_id"`
+	ConfirmedAt     *time.Time `json:"confirmed_at,omitempty"`
+	CheckedInAt     *time.Time `json:"checked_in_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	NoShowAt        *time.Time `json:"no_show_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Customer confirmed attendance, e.g. from a reminder link
+	CustomerConfirmedAt *time.Time `json:"customer_confirmed_at,omitempty"`
+
+	// Relationships
+	Items     []AppointmentItem     `gorm:"foreignKey:AppointmentID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+	Resources []AppointmentResource `gorm:"foreignKey:AppointmentID;constraint:OnDelete:CASCADE" json:"resources,omitempty"`
+}
+
+func (Appointment) TableName() string {
+	return "appointments"
+}
+
+// Appointment status values
+const (
+	AppointmentRequested = "requested"
+	AppointmentConfirmed = "confirmed"
+	AppointmentCheckedIn = "checked_in"
+	AppointmentCompleted = "completed"
+	AppointmentCancelled = "cancelled"
+	AppointmentNoShow    = "no_show"
+)
+
+// AppointmentTransitions lists the statuses each appointment status can move to
+var AppointmentTransitions = map[string][]string{
+	AppointmentRequested: {AppointmentConfirmed, AppointmentCancelled},
+	AppointmentConfirmed: {AppointmentCheckedIn, AppointmentCancelled, AppointmentNoShow},
+	AppointmentCheckedIn: {AppointmentCompleted},
+}
+
+// AppointmentItem is one side area booked within an appointment
+type AppointmentItem struct {
+	ID            uint64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	AppointmentID uint64   `gorm:"not null;index" json:"appointment_id"`
+	AreaID        uint     `gorm:"not null" json:"area_id"`
+	SideAreaID    uint     `gorm:"not null" json:"side_area_id"`
+	SyringeCount  int      `gorm:"not null;default:1" json:"syringe_count"`
+	SyringeSize   int      `gorm:"not null;default:0" json:"syringe_size,omitempty"`
+	UnitPrice     *float64 `json:"unit_price,omitempty"` // clinic price per syringe at booking time
+}
+
+func (AppointmentItem) TableName() string {
+	return "appointment_items"
+}
+
+// AppointmentSlotLock reserves one fixed-size time block of a resource (practitioner, customer, ...)
+// for an appointment. The unique index makes overlapping bookings fail in the database.
+type AppointmentSlotLock struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceKey   string    `gorm:"size:64;not null;uniqueIndex:idx_slot_lock_resource_block" json:"resource_key"`
+	BlockStart    time.Time `gorm:"not null;uniqueIndex:idx_slot_lock_resource_block" json:"block_start"`
+	AppointmentID uint64    `gorm:"not null;index" json:"appointment_id"`
+}
+
+func (AppointmentSlotLock) TableName() string {
+	return "appointment_slot_locks"
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// AuditLog is an append-only record of a successful mutating request.
+// Before/After hold JSON of the fields that changed on the target entity.
+type AuditLog struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ActorType  string    `gorm:"size:20;not null;index:idx_audit_actor" json:"actor_type"` // admin, clinic_user, customer, applicant, system
+	ActorID    uint64    `gorm:"not null;index:idx_audit_actor" json:"actor_id"`
+	ActorEmail string    `gorm:"size:255" json:"actor_email,omitempty"`
+	ClinicID   *uint64   `gorm:"index" json:"clinic_id,omitempty"`     // clinic the change belongs to, for clinic-scoped views
+	Action     string    `gorm:"size:20;not null;index" json:"action"` // create, update, delete
+	Method     string    `gorm:"size:10;not null" json:"method"`
+	Route      string    `gorm:"size:255;not null" json:"route"`
+	EntityType string    `gorm:"size:50;index:idx_audit_entity" json:"entity_type,omitempty"`
+	EntityID   string    `gorm:"size:100;index:idx_audit_entity" json:"entity_id,omitempty"`
+	Before     string    `gorm:"type:longtext" json:"before,omitempty"`
+	After      string    `gorm:"type:longtext" json:"after,omitempty"`
+	StatusCode int       `json:"status_code"`
+	IPAddress  string    `gorm:"size:100" json:"ip_address,omitempty"`
+	UserAgent  string    `gorm:"size:500" json:"user_agent,omitempty"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// Audit actor types
+const (
+	AuditActorAdmin      = "admin"
+	AuditActorClinicUser = "clinic_user"
+	AuditActorCustomer   = "customer"
+	AuditActorApplicant  = "applicant" // holder of a clinic application access token; the id is the application's
+	AuditActorSystem     = "system"    // background jobs
+)
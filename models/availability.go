@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// Schedule times are "HH:MM" wall-clock times and dates are "YYYY-MM-DD", both in the clinic's timezone.
+// Weekday follows time.Weekday (0 = Sunday).
+
+// ClinicOpeningHour is one opening period of a clinic on a weekday.
+// A clinic without any rows has no opening-hour restriction; otherwise weekdays without rows are closed.
+type ClinicOpeningHour struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID  uint64    `gorm:"not null;index" json:"clinic_id"`
+	Weekday   int       `gorm:"not null" json:"weekday"`
+	StartTime string    `gorm:"size:5;not null" json:"start_time"`
+	EndTime   string    `gorm:"size:5;not null" json:"end_time"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (ClinicOpeningHour) TableName() string {
+	return "clinic_opening_hours"
+}
+
+// ClinicHoliday closes the clinic for a whole day
+type ClinicHoliday struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID  uint64    `gorm:"not null;uniqueIndex:idx_clinic_holiday_date" json:"clinic_id"`
+	Date      string    `gorm:"size:10;not null;uniqueIndex:idx_clinic_holiday_date" json:"date"`
+	Name      string    `gorm:"size:100" json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ClinicHoliday) TableName() string {
+	return "clinic_holidays"
+}
+
+// PractitionerAvailability is one recurring weekly working period of a doctor/injector at a clinic
+type PractitionerAvailability struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID     uint64    `gorm:"not null;index" json:"clinic_id"`
+	ClinicUserID uint64    `gorm:"not null;index" json:"clinic_user_id"`
+	Weekday      int       `gorm:"not null" json:"weekday"`
+	StartTime    string    `gorm:"size:5;not null" json:"start_time"`
+	EndTime      string    `gorm:"size:5;not null" json:"end_time"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (PractitionerAvailability) TableName() string {
+	return "practitioner_availabilities"
+}
+
+// PractitionerAvailabilityException changes a practitioner's availability on one date:
+// "unavailable" removes the given period (or the whole day when no times are set), "available" adds extra hours.
+type PractitionerAvailabilityException struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID     uint64    `gorm:"not null;index" json:"clinic_id"`
+	ClinicUserID uint64    `gorm:"not null;index:idx_availability_exception_user_date" json:"clinic_user_id"`
+	Date         string    `gorm:"size:10;not null;index:idx_availability_exception_user_date" json:"date"`
+	Type         string    `gorm:"size:20;not null" json:"type"`
+	StartTime    string    `gorm:"size:5" json:"start_time,omitempty"`
+	EndTime      string    `gorm:"size:5" json:"end_time,omitempty"`
+	Reason       string    `gorm:"size:255" json:"reason,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (PractitionerAvailabilityException) TableName() string {
+	return "practitioner_availability_exceptions"
+}
+
+// Availability exception types
+const (
+	AvailabilityUnavailable = "unavailable"
+	AvailabilityAvailable   = "available"
+)
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// CalendarFeed is a secret-token ICS subscription URL listing an owner's upcoming appointments.
+// OwnerType is clinic_user (a practitioner's own bookings) or customer; ClinicID is 0 for customers.
+// Only the token's SHA-256 is stored; the URL is shown once when the feed is created.
+type CalendarFeed struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	OwnerType      string     `gorm:"size:20;not null;index:idx_calendar_feed_owner" json:"owner_type"`
+	OwnerID        uint64     `gorm:"not null;index:idx_calendar_feed_owner" json:"owner_id"`
+	ClinicID       uint64     `gorm:"not null;default:0;index" json:"clinic_id,omitempty"`
+	Name           string     `gorm:"size:100" json:"name,omitempty"` // label chosen by the owner, e.g. "Work phone"
+	TokenHash      string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokedByType  string     `gorm:"size:20" json:"revoked_by_type,omitempty"`
+	RevokedByID    *uint64    `json:"revoked_by_id,omitempty"`
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
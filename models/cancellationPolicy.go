@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// ClinicCancellationPolicy sets a clinic's cancellation terms. TreatmentID 0 is the clinic default;
+// a row for a treatment replaces the default for that treatment.
+// Fees and the deposit are either a fixed amount in the clinic currency or a percent of the appointment price.
+type ClinicCancellationPolicy struct {
+	ID                uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID          uint64    `gorm:"not null;uniqueIndex:idx_clinic_cancellation_policy" json:"clinic_id"`
+	TreatmentID       uint      `gorm:"not null;default:0;uniqueIndex:idx_clinic_cancellation_policy" json:"treatment_id,omitempty"`
+	FreeCancelHours   int       `gorm:"not null;default:0" json:"free_cancel_hours"` // customers cancel free until this many hours before the start
+	LateCancelFee     float64   `gorm:"not null;default:0" json:"late_cancel_fee"`
+	LateCancelFeeType string    `gorm:"size:10;not null;default:'fixed'" json:"late_cancel_fee_type"` // fixed, percent
+	NoShowFee         float64   `gorm:"not null;default:0" json:"no_show_fee"`
+	NoShowFeeType     string    `gorm:"size:10;not null;default:'fixed'" json:"no_show_fee_type"`
+	Deposit           float64   `gorm:"not null;default:0" json:"deposit"`
+	DepositType       string    `gorm:"size:10;not null;default:'fixed'" json:"deposit_type"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func (ClinicCancellationPolicy) TableName() string {
+	return "clinic_cancellation_policies"
+}
+
+// Policy fee types
+const (
+	PolicyFeeFixed   = "fixed"
+	PolicyFeePercent = "percent"
+)
+
+// AppointmentPolicy is the cancellation policy an appointment was booked under, with the fees
+// resolved to amounts, so later policy changes do not alter terms the customer agreed to
+type AppointmentPolicy struct {
+	AppointmentID   uint64    `gorm:"primaryKey;autoIncrement:false" json:"appointment_id"`
+	PolicyID        uint64    `gorm:"not null" json:"policy_id"`
+	FreeCancelHours int       `gorm:"not null" json:"free_cancel_hours"`
+	LateCancelFee   float64   `gorm:"not null" json:"late_cancel_fee"`
+	NoShowFee       float64   `gorm:"not null" json:"no_show_fee"`
+	Deposit         float64   `gorm:"not null" json:"deposit"`
+	Currency        string    `gorm:"size:3;not null" json:"currency"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (AppointmentPolicy) TableName() string {
+	return "appointment_policies"
+}
+
+// CustomerCharge is an amount owed by a customer for an appointment, collected through the payment provider.
+// The unique index records each kind of charge at most once per appointment.
+type CustomerCharge struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID      uint64     `gorm:"not null;index" json:"clinic_id"`
+	UserID        uint64     `gorm:"not null;index" json:"user_id"`
+	AppointmentID uint64     `gorm:"not null;uniqueIndex:idx_customer_charge_appointment_kind" json:"appointment_id"`
+	Kind          string     `gorm:"size:20;not null;uniqueIndex:idx_customer_charge_appointment_kind" json:"kind"` // deposit, late_cancel, no_show
+	Amount        float64    `gorm:"not null" json:"amount"`
+	Currency      string     `gorm:"size:3;not null" json:"currency"`
+	Status        string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	Provider      string     `gorm:"size:30" json:"provider,omitempty"`
+	ProviderRef   string     `gorm:"size:100" json:"provider_ref,omitempty"`
+	Error         string     `gorm:"size:255" json:"error,omitempty"`
+	Note          string     `gorm:"size:255" json:"note,omitempty"` // e.g. why it was waived
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CollectedAt   *time.Time `json:"collected_at,omitempty"`
+}
+
+func (CustomerCharge) TableName() string {
+	return "customer_charges"
+}
+
+// Customer charge kinds
+const (
+	ChargeDeposit    = "deposit"
+	ChargeLateCancel = "late_cancel"
+	ChargeNoShow     = "no_show"
+)
+
+// Customer charge statuses
+const (
+	ChargePending   = "pending" // not collected yet; the provider retries or staff collect it
+	ChargeCollected = "collected"
+	ChargeFailed    = "failed"
+	ChargeWaived    = "waived"
+	ChargeVoid      = "void"      // a deposit released before it was collected
+	ChargeRefunding = "refunding" // a collected deposit being returned
+	ChargeRefunded  = "refunded"
+)
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CatalogVersion is an immutable published snapshot of the treatment tree.
+// The treatments/areas/side_areas tables act as the draft; publishing copies
+// them into a new version which public endpoints serve.
+type CatalogVersion struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Version     int       `gorm:"not null;uniqueIndex" json:"version"`
+	Snapshot    string    `gorm:"type:longtext;not null" json:"-"` // JSON encoded []Treatment with areas and side areas
+	Notes       string    `gorm:"size:500" json:"notes,omitempty"`
+	PublishedBy uint64    `gorm:"index" json:"published_by"`  // admin_users.id
+	RolledBack  *int      `json:"rolled_back_from,omitempty"` // source version when created by a rollback
+	CreatedAt   time.Time `json:"published_at"`
+}
+
+func (CatalogVersion) TableName() string {
+	return "catalog_versions"
+}
@@ -2,20 +2,45 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Clinic represents a clinic/business entity
 type Clinic struct {
-	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string     `gorm:"size:255;not null" json:"name"`
-	Email     string     `gorm:"size:255;uniqueIndex;not null" json:"email"`
-	Phone     string     `gorm:"size:50" json:"phone,omitempty"`
-	Address   string     `gorm:"type:text" json:"address,omitempty"`
-	Logo      string     `gorm:"size:500" json:"logo,omitempty"`
-	Status    string     `gorm:"size:20;default:'active'" json:"status"` // active, inactive, suspended
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `gorm:"index" json:"-"`
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string         `gorm:"size:255;not null" json:"name"`
+	Email     string         `gorm:"size:255;uniqueIndex;not null" json:"email"`
+	Phone     string         `gorm:"size:50" json:"phone,omitempty"`
+	Address   string         `gorm:"type:text" json:"address,omitempty"` // single-line address, composed from the structured fields when they are set
+	Logo      string         `gorm:"size:500" json:"logo,omitempty"`
+	Status    string         `gorm:"size:20;default:'active'" json:"status"` // active, inactive, suspended
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Tokens issued to the clinic's staff before this time are rejected (suspension, deletion)
+	SessionsRevokedAt *time.Time `json:"-"`
+
+	// Profile and settings
+	Description  string            `gorm:"type:text" json:"description,omitempty"`
+	AddressLine1 string            `gorm:"size:255" json:"address_line1,omitempty"`
+	AddressLine2 string            `gorm:"size:255" json:"address_line2,omitempty"`
+	City         string            `gorm:"size:100" json:"city,omitempty"`
+	State        string            `gorm:"size:100" json:"state,omitempty"`
+	PostalCode   string            `gorm:"size:20" json:"postal_code,omitempty"`
+	Country      string            `gorm:"size:100" json:"country,omitempty"`
+	Website      string            `gorm:"size:500" json:"website,omitempty"`
+	SocialLinks  map[string]string `gorm:"serializer:json;type:text" json:"social_links,omitempty"` // network -> profile URL
+	Timezone     string            `gorm:"size:64;not null;default:'UTC'" json:"timezone"`
+	Currency     string            `gorm:"size:3;not null;default:'USD'" json:"currency"` // ISO 4217
+
+	// Booking settings
+	BookingBufferMinutes int `gorm:"not null;default:0" json:"booking_buffer_minutes"` // kept free after each appointment
+	SlotIntervalMinutes  int `gorm:"not null;default:15" json:"slot_interval_minutes"` // spacing of offered start times
+
+	// How customer names appear in practitioners' calendar feeds: full, initials or hidden
+	CalendarCustomerNames string `gorm:"size:10;not null;default:'initials'" json:"calendar_customer_names"`
 
 	// Relationships
 	Users []ClinicUser `gorm:"foreignKey:ClinicID" json:"users,omitempty"`
@@ -25,6 +50,20 @@ func (Clinic) TableName() string {
 	return "clinics"
 }
 
+// Calendar feed customer name policies
+const (
+	CalendarNamesFull     = "full"
+	CalendarNamesInitials = "initials"
+	CalendarNamesHidden   = "hidden"
+)
+
+// Clinic status constants
+const (
+	ClinicStatusActive    = "active"
+	ClinicStatusInactive  = "inactive"
+	ClinicStatusSuspended = "suspended"
+)
+
 // ClinicRole represents roles specific to clinic users (separate from admin roles)
 type ClinicRole struct {
 	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -50,7 +89,11 @@ type ClinicUser struct {
 	LastLogin    *time.Time `json:"last_login,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
-	DeletedAt    *time.Time `gorm:"index" json:"-"`
+	// Removed staff are kept so appointments and treatment records still resolve their practitioner
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Tokens issued to this user before this time are rejected (deactivation, removal)
+	SessionsRevokedAt *time.Time `json:"-"`
 
 	// Relationships
 	Clinic Clinic     `gorm:"foreignKey:ClinicID;constraint:OnDelete:CASCADE" json:"clinic,omitempty"`
@@ -61,6 +104,12 @@ func (ClinicUser) TableName() string {
 	return "clinic_users"
 }
 
+// Clinic user status constants
+const (
+	ClinicUserStatusActive   = "active"
+	ClinicUserStatusInactive = "inactive"
+)
+
 // Clinic role name constants
 const (
 	ClinicRoleOwner        = "owner"
@@ -106,6 +155,8 @@ type ClinicTreatment struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	DurationMinutes *int `json:"duration_minutes,omitempty"` // overrides the catalog base minutes when set
+
 	// Relationships
 	Clinic    Clinic    `gorm:"foreignKey:ClinicID;constraint:OnDelete:CASCADE" json:"clinic,omitempty"`
 	Treatment Treatment `gorm:"foreignKey:TreatmentID;constraint:OnDelete:CASCADE" json:"treatment,omitempty"`
@@ -144,6 +195,10 @@ type ClinicSideArea struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// Scheduling overrides of the catalog durations; nil uses the catalog values
+	DurationMinutes     *int `json:"duration_minutes,omitempty"`
+	ExtraSyringeMinutes *int `json:"extra_syringe_minutes,omitempty"`
+
 	SideArea SideArea `gorm:"foreignKey:SideAreaID" json:"-"`
 }
 
@@ -179,6 +234,8 @@ type ClinicUserProfile struct {
 	Image          string    `gorm:"size:500" json:"image,omitempty"`
 	Specialization string    `gorm:"size:255" json:"specialization,omitempty"`
 	Phone          string    `gorm:"size:50" json:"phone,omitempty"`
+	Bio            string    `gorm:"type:text" json:"bio,omitempty"`                            // doctors/injectors only
+	Qualifications []string  `gorm:"serializer:json;type:text" json:"qualifications,omitempty"` // doctors/injectors only
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 
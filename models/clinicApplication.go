@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// ClinicApplication is a self-service request from a clinic to join the platform.
+// Approval creates the Clinic and its owner ClinicUser.
+type ClinicApplication struct {
+	ID              uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicName      string     `gorm:"size:255;not null" json:"clinic_name"`
+	ClinicEmail     string     `gorm:"size:255;not null;index" json:"clinic_email"`
+	ClinicPhone     string     `gorm:"size:50" json:"clinic_phone,omitempty"`
+	ClinicAddress   string     `gorm:"type:text" json:"clinic_address,omitempty"`
+	ClinicLogo      string     `gorm:"size:500" json:"clinic_logo,omitempty"`
+	LicenseNumber   string     `gorm:"size:100;not null" json:"license_number"`
+	OwnerName       string     `gorm:"size:255;not null" json:"owner_name"`
+	OwnerEmail      string     `gorm:"size:255;not null" json:"owner_email"`
+	OwnerPhone      string     `gorm:"size:50" json:"owner_phone,omitempty"`
+	Message         string     `gorm:"type:text" json:"message,omitempty"` // latest note from the applicant
+	Status          string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	ReviewNote      string     `gorm:"type:text" json:"review_note,omitempty"` // latest note from the reviewer, shown to the applicant
+	ReviewedBy      *uint64    `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	ClinicID        *uint64    `json:"clinic_id,omitempty"` // set once approved
+	AccessTokenHash string     `gorm:"size:64;not null" json:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Documents []ClinicApplicationDocument `gorm:"foreignKey:ApplicationID" json:"documents,omitempty"`
+}
+
+func (ClinicApplication) TableName() string {
+	return "clinic_applications"
+}
+
+// Clinic application status values
+const (
+	ClinicApplicationPending       = "pending"
+	ClinicApplicationInfoRequested = "info_requested"
+	ClinicApplicationApproved      = "approved"
+	ClinicApplicationRejected      = "rejected"
+)
+
+// ClinicApplicationDocument is an uploaded license or supporting document
+type ClinicApplicationDocument struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ApplicationID uint64    `gorm:"not null;index" json:"application_id"`
+	FileName      string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType   string    `gorm:"size:100" json:"content_type"`
+	Size          int64     `json:"size"`
+	FilePath      string    `gorm:"size:500;not null" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (ClinicApplicationDocument) TableName() string {
+	return "clinic_application_documents"
+}
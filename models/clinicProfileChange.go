@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// ClinicPublicProfile holds proposed values for the clinic fields shown in discovery.
+// Nil fields are left unchanged.
+type ClinicPublicProfile struct {
+	Name         *string            `json:"name,omitempty"`
+	Phone        *string            `json:"phone,omitempty"`
+	Logo         *string            `json:"logo,omitempty"`
+	Description  *string            `json:"description,omitempty"`
+	Website      *string            `json:"website,omitempty"`
+	AddressLine1 *string            `json:"address_line1,omitempty"`
+	AddressLine2 *string            `json:"address_line2,omitempty"`
+	City         *string            `json:"city,omitempty"`
+	State        *string            `json:"state,omitempty"`
+	PostalCode   *string            `json:"postal_code,omitempty"`
+	Country      *string            `json:"country,omitempty"`
+	SocialLinks  *map[string]string `json:"social_links,omitempty"`
+}
+
+// ClinicProfileChange is a clinic's edit to its public profile waiting for admin approval.
+// A clinic has at most one pending change; further edits are merged into it.
+type ClinicProfileChange struct {
+	ID          uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID    uint64              `gorm:"not null;index" json:"clinic_id"`
+	Changes     ClinicPublicProfile `gorm:"serializer:json;type:text" json:"changes"`
+	Status      string              `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	RequestedBy uint64              `gorm:"not null" json:"requested_by"` // clinic user id
+	ReviewedBy  *uint64             `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time          `json:"reviewed_at,omitempty"`
+	ReviewNote  string              `gorm:"type:text" json:"review_note,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+func (ClinicProfileChange) TableName() string {
+	return "clinic_profile_changes"
+}
+
+// Clinic profile change status values
+const (
+	ClinicProfileChangePending   = "pending"
+	ClinicProfileChangeApproved  = "approved"
+	ClinicProfileChangeRejected  = "rejected"
+	ClinicProfileChangeWithdrawn = "withdrawn"
+)
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ExportJob is a background export whose result file can be downloaded once completed
+type ExportJob struct {
+	ID              uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Dataset         string     `gorm:"size:50;not null" json:"dataset"`
+	Format          string     `gorm:"size:10;not null" json:"format"` // csv, xlsx
+	Columns         string     `gorm:"size:1000" json:"columns"`       // comma separated column keys
+	RequestedByType string     `gorm:"size:20;not null;index:idx_export_requester" json:"requested_by_type"`
+	RequestedByID   uint64     `gorm:"not null;index:idx_export_requester" json:"requested_by_id"`
+	ClinicID        *uint64    `gorm:"index" json:"clinic_id,omitempty"` // set for clinic exports and admin exports filtered to one clinic
+	Status          string     `gorm:"size:20;not null;default:'pending'" json:"status"`
+	FileName        string     `gorm:"size:255" json:"file_name,omitempty"`
+	FilePath        string     `gorm:"size:500" json:"-"`
+	RowCount        int64      `json:"row_count"`
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
+
+// Export job status values
+const (
+	ExportJobPending   = "pending"
+	ExportJobRunning   = "running"
+	ExportJobCompleted = "completed"
+	ExportJobFailed    = "failed"
+	ExportJobExpired   = "expired" // file removed after the retention period
+)
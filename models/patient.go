@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClinicPatient is a clinic's record of a person it treats. Customers become patients when they
+// book at the clinic; walk-ins without an app account have no UserID. Notes and the medical fields
+// are private to the clinic. The customer's own profile and onboarding answers are only shown to
+// the clinic while ProfileSharedAt is set, which only the customer controls.
+type ClinicPatient struct {
+	ID                uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID          uint64         `gorm:"not null;uniqueIndex:idx_patient_clinic_user" json:"clinic_id"`
+	UserID            *uint64        `gorm:"uniqueIndex:idx_patient_clinic_user" json:"user_id,omitempty"` // nil for walk-ins
+	Name              string         `gorm:"size:100;not null" json:"name"`
+	Email             string         `gorm:"size:255;index" json:"email,omitempty"`
+	Phone             string         `gorm:"size:50" json:"phone,omitempty"`
+	DateOfBirth       string         `gorm:"size:10" json:"date_of_birth,omitempty"` // YYYY-MM-DD
+	Notes             string         `gorm:"type:text" json:"notes,omitempty"`
+	MedicalHistory    string         `gorm:"type:text" json:"medical_history,omitempty"`
+	Allergies         string         `gorm:"type:text" json:"allergies,omitempty"`
+	Contraindications string         `gorm:"type:text" json:"contraindications,omitempty"`
+	Source            string         `gorm:"size:20;not null;default:'booking'" json:"source"`
+	ProfileSharedAt   *time.Time     `json:"profile_shared_at,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (ClinicPatient) TableName() string {
+	return "clinic_patients"
+}
+
+// Patient record sources
+const (
+	PatientSourceBooking = "booking" // created when the customer first booked
+	PatientSourceWalkIn  = "walk_in" // created by clinic staff
+)
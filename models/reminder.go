@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ClinicReminderRule is one reminder a clinic sends before its appointments.
+// Subject and Body are templates with {{placeholders}}; empty ones use the default wording.
+// A clinic without any rules uses the default reminders; inactive rules keep their settings.
+type ClinicReminderRule struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID      uint64    `gorm:"not null;index" json:"clinic_id"`
+	OffsetMinutes int       `gorm:"not null" json:"offset_minutes"` // minutes before the appointment start
+	Channel       string    `gorm:"size:20;not null;default:'email'" json:"channel"`
+	Subject       string    `gorm:"size:200" json:"subject,omitempty"`
+	Body          string    `gorm:"type:text" json:"body,omitempty"`
+	IsActive      bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (ClinicReminderRule) TableName() string {
+	return "clinic_reminder_rules"
+}
+
+// AppointmentReminder records one reminder of an appointment. The unique index lets
+// each reminder go out at most once, even with several scheduler instances running.
+type AppointmentReminder struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	AppointmentID uint64     `gorm:"not null;uniqueIndex:idx_appointment_reminder" json:"appointment_id"`
+	OffsetMinutes int        `gorm:"not null;uniqueIndex:idx_appointment_reminder" json:"offset_minutes"`
+	Channel       string     `gorm:"size:20;not null;uniqueIndex:idx_appointment_reminder" json:"channel"`
+	Status        string     `gorm:"size:20;not null" json:"status"`
+	Error         string     `gorm:"size:255" json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+func (AppointmentReminder) TableName() string {
+	return "appointment_reminders"
+}
+
+// Reminder channels
+const (
+	ReminderChannelEmail = "email"
+)
+
+// Appointment reminder statuses
+const (
+	ReminderSending = "sending"
+	ReminderSent    = "sent"
+	ReminderFailed  = "failed"
+	ReminderSkipped = "skipped" // superseded by a later reminder or due before the booking was made
+)
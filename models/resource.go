@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// ClinicResource is a treatment room or device of a clinic that appointments occupy.
+// ResourceType links it to treatment requirements; Capacity is how many appointments
+// can use it at the same time (e.g. chairs in a shared room).
+type ClinicResource struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID     uint64    `gorm:"not null;index" json:"clinic_id"`
+	Kind         string    `gorm:"size:20;not null" json:"kind"` // room, equipment
+	ResourceType string    `gorm:"size:50;not null;index" json:"resource_type"`
+	Name         string    `gorm:"size:100;not null" json:"name"`
+	Capacity     int       `gorm:"not null;default:1" json:"capacity"`
+	Status       string    `gorm:"size:20;not null;default:'active'" json:"status"` // active, inactive
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (ClinicResource) TableName() string {
+	return "clinic_resources"
+}
+
+// Clinic resource statuses
+const (
+	ClinicResourceActive   = "active"
+	ClinicResourceInactive = "inactive"
+)
+
+// ClinicTreatmentRequirement adjusts a treatment's catalog resource requirements at one clinic.
+// It replaces the catalog requirement with the same side area and resource type; Quantity 0 waives it.
+type ClinicTreatmentRequirement struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID     uint64    `gorm:"not null;index:idx_clinic_treatment_requirement" json:"clinic_id"`
+	TreatmentID  uint      `gorm:"not null;index:idx_clinic_treatment_requirement" json:"treatment_id"`
+	SideAreaID   uint      `gorm:"not null;default:0" json:"side_area_id,omitempty"`
+	Kind         string    `gorm:"size:20;not null" json:"kind"`
+	ResourceType string    `gorm:"size:50;not null" json:"resource_type"`
+	Quantity     int       `gorm:"not null;default:1" json:"quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (ClinicTreatmentRequirement) TableName() string {
+	return "clinic_treatment_requirements"
+}
+
+// AppointmentResource is one unit of a clinic resource held by an appointment
+type AppointmentResource struct {
+	ID               uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	AppointmentID    uint64 `gorm:"not null;index" json:"appointment_id"`
+	ClinicResourceID uint64 `gorm:"not null;index" json:"clinic_resource_id"`
+	Unit             int    `gorm:"not null;default:1" json:"unit"`
+}
+
+func (AppointmentResource) TableName() string {
+	return "appointment_resources"
+}
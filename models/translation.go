@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ContentTranslation holds a localized value for one field of catalog or onboarding content.
+// The base columns (Treatment.Name, SkinConditionQuestion.QuestionText, ...) hold the default locale.
+type ContentTranslation struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	EntityType string    `gorm:"size:40;not null;uniqueIndex:idx_content_translation" json:"entity_type"` // treatment, area, side_area, question, option
+	EntityID   uint64    `gorm:"not null;uniqueIndex:idx_content_translation" json:"entity_id"`
+	Field      string    `gorm:"size:40;not null;uniqueIndex:idx_content_translation" json:"field"` // name, description, question_text, option_text
+	Locale     string    `gorm:"size:10;not null;uniqueIndex:idx_content_translation;index" json:"locale"`
+	Value      string    `gorm:"type:text;not null" json:"value"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (ContentTranslation) TableName() string {
+	return "content_translations"
+}
@@ -7,13 +7,18 @@ import (
 // Treatment represents main treatment types (Dermal Fillers, Botox, etc.)
 type Treatment struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
+	Code        *string   `gorm:"size:100;uniqueIndex" json:"code,omitempty"` // stable key used by catalog import/export
 	Name        string    `gorm:"size:100;not null;unique" json:"name"`
 	Icon        string    `gorm:"size:255" json:"icon,omitempty"`
 	Description string    `gorm:"type:text" json:"description,omitempty"`
 	IsArea      bool      `gorm:"default:false" json:"is_area"`
+	Status      string    `gorm:"size:20;default:'active'" json:"status"` // active, archived, deleted
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	Areas       []Area    `gorm:"foreignKey:TreatmentID" json:"areas,omitempty"`
+
+	// Scheduling: base minutes added once per appointment (consultation, preparation)
+	DurationMinutes int `gorm:"not null;default:0" json:"duration_minutes"`
 }
 
 func (Treatment) TableName() string {
@@ -24,16 +29,22 @@ func (Treatment) TableName() string {
 type Area struct {
 	ID          uint       `gorm:"primaryKey" json:"id"`
 	TreatmentID uint       `gorm:"not null;index" json:"treatment_id"`
+	Code        *string    `gorm:"size:100;uniqueIndex" json:"code,omitempty"` // stable key used by catalog import/export
 	Name        string     `gorm:"size:100;not null" json:"name"`
 	Icon        string     `gorm:"size:255" json:"icon,omitempty"`
 	Description string     `gorm:"type:text" json:"description,omitempty"`
 	IsSideArea  bool       `gorm:"default:false" json:"is_sidearea"`
 	MinSyringe  int        `gorm:"default:1" json:"min_syringe"`
 	MaxSyringe  int        `gorm:"default:1" json:"max_syringe"`
+	Status      string     `gorm:"size:20;default:'active'" json:"status"` // active, archived, deleted
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	Treatment   Treatment  `gorm:"foreignKey:TreatmentID;constraint:OnDelete:CASCADE" json:"-"`
 	SideAreas   []SideArea `gorm:"foreignKey:AreaID" json:"side_areas,omitempty"`
+
+	// Scheduling defaults for the area's side areas: minutes for the first syringe and for each extra one
+	DurationMinutes     int `gorm:"not null;default:0" json:"duration_minutes"`
+	ExtraSyringeMinutes int `gorm:"not null;default:0" json:"extra_syringe_minutes"`
 }
 
 func (Area) TableName() string {
@@ -45,17 +56,54 @@ type SideArea struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	TreatmentID uint      `gorm:"not null;index" json:"treatment_id"`
 	AreaID      uint      `gorm:"not null;index" json:"area_id"`
+	Code        *string   `gorm:"size:100;uniqueIndex" json:"code,omitempty"` // stable key used by catalog import/export
 	Name        string    `gorm:"size:200;not null" json:"name"`
 	Icon        string    `gorm:"size:255" json:"icon,omitempty"`
 	Description string    `gorm:"type:text" json:"description,omitempty"`
 	MinSyringe  int       `gorm:"default:1" json:"min_syringe"`
 	MaxSyringe  int       `gorm:"default:1" json:"max_syringe"`
+	Status      string    `gorm:"size:20;default:'active'" json:"status"` // active, archived, deleted
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	Treatment   Treatment `gorm:"foreignKey:TreatmentID;constraint:OnDelete:CASCADE" json:"-"`
 	Area        Area      `gorm:"foreignKey:AreaID;constraint:OnDelete:CASCADE" json:"-"`
+
+	// Scheduling: minutes for the first syringe and for each extra one; 0 uses the area's values
+	DurationMinutes     int `gorm:"not null;default:0" json:"duration_minutes"`
+	ExtraSyringeMinutes int `gorm:"not null;default:0" json:"extra_syringe_minutes"`
 }
 
 func (SideArea) TableName() string {
 	return "side_areas"
 }
+
+// Catalog node status constants
+const (
+	CatalogStatusActive   = "active"
+	CatalogStatusArchived = "archived"
+	CatalogStatusDeleted  = "deleted" // staged in the draft; removed when the catalog is next published
+)
+
+// TreatmentResourceRequirement is a room or piece of equipment a treatment needs while it is performed.
+// SideAreaID 0 applies to every booking of the treatment; otherwise only when that side area is booked.
+type TreatmentResourceRequirement struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	TreatmentID  uint      `gorm:"not null;index" json:"treatment_id"`
+	SideAreaID   uint      `gorm:"not null;default:0" json:"side_area_id,omitempty"`
+	Kind         string    `gorm:"size:20;not null" json:"kind"`          // room, equipment
+	ResourceType string    `gorm:"size:50;not null" json:"resource_type"` // e.g. procedure_room, co2_laser
+	Quantity     int       `gorm:"not null;default:1" json:"quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	Treatment Treatment `gorm:"foreignKey:TreatmentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (TreatmentResourceRequirement) TableName() string {
+	return "treatment_resource_requirements"
+}
+
+// Resource kinds
+const (
+	ResourceKindRoom      = "room"
+	ResourceKindEquipment = "equipment"
+)
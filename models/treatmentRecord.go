@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// TreatmentRecord is a practitioner's clinical record of a treatment performed on a patient,
+// usually for an appointment. Drafts can be edited; once signed the record is immutable and later
+// corrections are added as addenda.
+type TreatmentRecord struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID       uint64     `gorm:"not null;index" json:"clinic_id"`
+	PatientID      uint64     `gorm:"not null;index" json:"patient_id"`
+	AppointmentID  *uint64    `gorm:"uniqueIndex" json:"appointment_id,omitempty"` // nil for walk-ins treated without a booking
+	PractitionerID uint64     `gorm:"not null;index" json:"practitioner_id"`       // clinic user who performed the treatment
+	TreatmentID    uint       `gorm:"not null" json:"treatment_id"`
+	PerformedAt    time.Time  `gorm:"not null" json:"performed_at"`
+	InjectionNotes string     `gorm:"type:text" json:"injection_notes,omitempty"`
+	Aftercare      string     `gorm:"type:text" json:"aftercare,omitempty"` // aftercare advice given to the patient
+	Status         string     `gorm:"size:20;not null;default:'draft';index" json:"status"`
+	CreatedByID    uint64     `gorm:"not null" json:"created_by_id"`
+	SignedAt       *time.Time `json:"signed_at,omitempty"`
+	SignedByID     *uint64    `json:"signed_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	Items   []TreatmentRecordItem     `gorm:"foreignKey:RecordID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+	Addenda []TreatmentRecordAddendum `gorm:"foreignKey:RecordID;constraint:OnDelete:CASCADE" json:"addenda,omitempty"`
+}
+
+func (TreatmentRecord) TableName() string {
+	return "treatment_records"
+}
+
+// Treatment record statuses
+const (
+	TreatmentRecordDraft  = "draft"
+	TreatmentRecordSigned = "signed"
+)
+
+// TreatmentRecordItem is the product used on one side area
+type TreatmentRecordItem struct {
+	ID           uint64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	RecordID     uint64   `gorm:"not null;index" json:"record_id"`
+	AreaID       uint     `gorm:"not null" json:"area_id"`
+	SideAreaID   uint     `gorm:"not null" json:"side_area_id"`
+	Product      string   `gorm:"size:255;not null" json:"product"`
+	SyringeCount int      `gorm:"not null;default:1" json:"syringe_count"`
+	SyringeSize  int      `gorm:"not null;default:0" json:"syringe_size,omitempty"`
+	LotNumbers   []string `gorm:"serializer:json;type:text" json:"lot_numbers,omitempty"`
+	Notes        string   `gorm:"type:text" json:"notes,omitempty"`
+}
+
+func (TreatmentRecordItem) TableName() string {
+	return "treatment_record_items"
+}
+
+// TreatmentRecordAddendum is an amendment appended to a signed treatment record; addenda are never changed
+type TreatmentRecordAddendum struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	RecordID  uint64    `gorm:"not null;index" json:"record_id"`
+	AuthorID  uint64    `gorm:"not null" json:"author_id"` // clinic user
+	Note      string    `gorm:"type:text;not null" json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (TreatmentRecordAddendum) TableName() string {
+	return "treatment_record_addenda"
+}
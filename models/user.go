@@ -16,8 +16,17 @@ type User struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Tokens issued before this time are rejected (force logout, suspension, deletion)
+	SessionsRevokedAt *time.Time `json:"-"`
+
 	// Relations
 	AuthProviders []AuthProvider `gorm:"constraint:OnDelete:CASCADE"`
 	AuthTokens    []AuthToken    `gorm:"constraint:OnDelete:CASCADE"`
 	Roles         []Role         `gorm:"many2many:user_roles;constraint:OnDelete:CASCADE"`
 }
+
+// Customer account status values
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+)
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// WaitlistEntry is a customer waiting for a practitioner's slot for a treatment between two dates.
+// FromDate/ToDate are YYYY-MM-DD in the clinic's timezone. Entries are offered openings in the
+// order they joined.
+type WaitlistEntry struct {
+	ID             uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ClinicID       uint64    `gorm:"not null;index:idx_waitlist_practitioner" json:"clinic_id"`
+	UserID         uint64    `gorm:"not null;index" json:"user_id"`
+	PractitionerID uint64    `gorm:"not null;index:idx_waitlist_practitioner" json:"practitioner_id"`
+	TreatmentID    uint      `gorm:"not null" json:"treatment_id"`
+	FromDate       string    `gorm:"size:10;not null" json:"from_date"`
+	ToDate         string    `gorm:"size:10;not null" json:"to_date"`
+	Status         string    `gorm:"size:20;not null;default:'waiting';index" json:"status"`
+	AppointmentID  *uint64   `json:"appointment_id,omitempty"` // set once an offer is claimed
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// Relationships
+	Items []WaitlistItem `gorm:"foreignKey:EntryID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+// Waitlist entry statuses
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered" // holds an open offer; goes back to waiting if it is not claimed
+	WaitlistBooked    = "booked"
+	WaitlistCancelled = "cancelled"
+	WaitlistExpired   = "expired" // the date range passed
+)
+
+// WaitlistItem is one side area the waiting customer wants booked
+type WaitlistItem struct {
+	ID           uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	EntryID      uint64 `gorm:"not null;index" json:"entry_id"`
+	SideAreaID   uint   `gorm:"not null" json:"side_area_id"`
+	SyringeCount int    `gorm:"not null;default:1" json:"syringe_count"`
+	SyringeSize  int    `gorm:"not null;default:0" json:"syringe_size,omitempty"`
+}
+
+func (WaitlistItem) TableName() string {
+	return "waitlist_items"
+}
+
+// WaitlistOffer is a slot freed by a cancellation offered to one waitlist entry until ExpiresAt.
+// Offers of the same opening share PractitionerID, OpeningStart and OpeningEnd; when one lapses
+// the opening moves on to the next entry in line.
+type WaitlistOffer struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EntryID        uint64     `gorm:"not null;index" json:"entry_id"`
+	ClinicID       uint64     `gorm:"not null;index" json:"clinic_id"`
+	PractitionerID uint64     `gorm:"not null;index:idx_waitlist_offer_opening" json:"practitioner_id"`
+	OpeningStart   time.Time  `gorm:"not null;index:idx_waitlist_offer_opening" json:"opening_start"`
+	OpeningEnd     time.Time  `gorm:"not null" json:"opening_end"`
+	StartAt        time.Time  `gorm:"not null" json:"start_at"` // the slot offered within the opening
+	EndAt          time.Time  `gorm:"not null" json:"end_at"`
+	Status         string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	AppointmentID  *uint64    `json:"appointment_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+}
+
+func (WaitlistOffer) TableName() string {
+	return "waitlist_offers"
+}
+
+// Waitlist offer statuses
+const (
+	WaitlistOfferPending     = "pending"
+	WaitlistOfferClaimed     = "claimed"
+	WaitlistOfferDeclined    = "declined"
+	WaitlistOfferExpired     = "expired"
+	WaitlistOfferUnavailable = "unavailable" // the slot was taken before it was claimed
+)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"skinSync/controllers"
 	"skinSync/middlewares"
+	"skinSync/services"
 
 	"github.com/labstack/echo/v4"
 )
@@ -35,6 +36,12 @@ func SetupRoutes(e *echo.Group) {
 		public.POST("/clinic/forgot-password", controllers.ClinicForgotPasswordHandler)
 		public.POST("/clinic/reset-password", controllers.ClinicResetPasswordHandler)
 
+		// Clinic self-service applications (multipart; follow-ups use the access token returned on submit)
+		applicationUploadLimits := middlewares.ClinicApplicationUploadLimits()
+		public.POST("/clinic/applications", controllers.SubmitClinicApplicationHandler, applicationUploadLimits...)
+		public.GET("/clinic/applications/:id", controllers.GetApplicantClinicApplicationHandler)
+		public.POST("/clinic/applications/:id/respond", controllers.RespondToClinicApplicationHandler, applicationUploadLimits...)
+
 		// Public masters (onboarding only - no auth needed for initial app load)
 		public.GET("/onboarding/masters", controllers.GetOnboardingMastersHandler)
 
@@ -42,6 +49,18 @@ func SetupRoutes(e *echo.Group) {
 		public.GET("/treatments/masters", controllers.GetTreatmentMastersHandler)
 		public.GET("/treatments/:id/areas", controllers.GetAreasHandler)
 		public.GET("/treatments/:treatmentId/areas/:areaId/sideareas", controllers.GetSideAreasHandler)
+
+		// Signed confirm/cancel links from appointment reminders (the token is the credential)
+		public.GET("/appointments/actions", controllers.GetAppointmentActionHandler)
+		public.POST("/appointments/actions", controllers.ApplyAppointmentActionHandler)
+
+		// Waitlist slot offers behind emailed claim links (the token is the credential)
+		public.GET("/waitlist/offers", controllers.GetWaitlistOfferHandler)
+		public.POST("/waitlist/offers/claim", controllers.ClaimWaitlistOfferHandler)
+		public.POST("/waitlist/offers/decline", controllers.DeclineWaitlistOfferHandler)
+
+		// ICS calendar subscriptions (the secret token in the URL is the credential)
+		public.GET("/calendar/:token", controllers.GetCalendarFeedHandler)
 	}
 
 	// ========== UNIFIED AUTH ROUTES (Any valid token: customer/admin/clinic) ==========
@@ -55,10 +74,17 @@ func SetupRoutes(e *echo.Group) {
 		unified.GET("/clinics/:clinicId/treatments/:treatmentId/doctors", controllers.GetDoctorsByClinicAndTreatmentHandler)
 		unified.GET("/doctors/:doctorId/clinics/:clinicId/treatments", controllers.GetTreatmentsByDoctorAndClinicHandler)
 		unified.GET("/doctors/:doctorId/treatments/:treatmentId/clinics", controllers.GetClinicsByDoctorAndTreatmentHandler)
+
+		// Bookable appointment slots (clinic timezone; accounts for schedules, bookings and buffers)
+		unified.GET("/clinics/:clinicId/slots", controllers.GetClinicSlotsHandler)
+		// Appointment length and room/equipment needs of a treatment selection
+		unified.POST("/clinics/:clinicId/appointment-duration", controllers.EstimateAppointmentDurationHandler)
+		// Cancellation terms (free window, fees, deposit) shown before booking
+		unified.GET("/clinics/:clinicId/cancellation-policy", controllers.GetBookingPolicyHandler)
 	}
 
 	// ========== CUSTOMER ROUTES (Customer Auth Required) ==========
-	customer := e.Group("/v1", middlewares.AuthMiddleware)
+	customer := e.Group("/v1", middlewares.AuthMiddleware, middlewares.AuditTrail)
 	{
 		customer.POST("/auth/refresh", controllers.RefreshTokenHandler)
 
@@ -67,17 +93,46 @@ func SetupRoutes(e *echo.Group) {
 		customer.POST("/onboarding/profile", controllers.SaveProfileHandler)
 		customer.GET("/onboarding/fetchprofile", controllers.GetUserProfileHandler)
 		customer.GET("/onboarding/user", controllers.GetUserOnboardingHandler)
+
+		// Appointment booking (requests start as "requested" until the clinic confirms)
+		customer.POST("/appointments", controllers.BookAppointmentHandler)
+		customer.GET("/appointments", controllers.GetMyAppointmentsHandler)
+		customer.GET("/appointments/:id", controllers.GetMyAppointmentHandler)
+		customer.POST("/appointments/:id/cancel", controllers.CancelMyAppointmentHandler)
+		customer.GET("/charges", controllers.GetMyChargesHandler)
+
+		// Waitlist for a practitioner's slots; cancellations are offered in joining order
+		customer.POST("/waitlist", controllers.JoinWaitlistHandler)
+		customer.GET("/waitlist", controllers.GetMyWaitlistHandler)
+		customer.DELETE("/waitlist/:id", controllers.LeaveWaitlistHandler)
+
+		// Calendar subscription URLs for the customer's appointments
+		customer.GET("/calendar-feeds", controllers.GetCustomerCalendarFeedsHandler)
+		customer.POST("/calendar-feeds", controllers.CreateCustomerCalendarFeedHandler)
+		customer.DELETE("/calendar-feeds/:id", controllers.RevokeCustomerCalendarFeedHandler)
+
+		// Whether each clinic the customer booked at may see their profile and onboarding answers
+		customer.GET("/patient-consents", controllers.GetMyPatientConsentsHandler)
+		customer.PUT("/patient-consents/:clinicId", controllers.SetMyPatientConsentHandler)
 	}
 
 	// ========== ADMIN ROUTES (Permission-Based) ==========
-	admin := e.Group("/admin", middlewares.AdminAuthMiddleware)
+	admin := e.Group("/admin", middlewares.AdminAuthMiddleware, middlewares.AuditTrail)
 	{
 		// Profile
 		admin.GET("/me", controllers.GetAdminMeHandler, middlewares.RequirePermission("profile.view"))
+		admin.PUT("/me", controllers.UpdateAdminMeHandler, middlewares.RequirePermission("profile.edit"))
+		admin.POST("/change-password", controllers.AdminChangePasswordHandler, middlewares.RequirePermission("profile.edit"))
 
 		// Admin user management (super_admin only)
 		admin.POST("/register", controllers.AdminRegisterHandler, middlewares.RequirePermission("admins.create"))
 		admin.POST("/verify-password", controllers.VerifyPasswordHandler, middlewares.RequirePermission("admins.create"))
+		admin.GET("/admins", controllers.ListAdminUsersHandler, middlewares.RequirePermission("admins.view"))
+		admin.GET("/admins/:id", controllers.GetAdminUserHandler, middlewares.RequirePermission("admins.view"))
+		admin.PUT("/admins/:id", controllers.UpdateAdminUserHandler, middlewares.RequirePermission("admins.edit"))
+		admin.PATCH("/admins/:id/status", controllers.SetAdminStatusHandler, middlewares.RequirePermission("admins.edit"))
+		admin.POST("/admins/:id/reset-password", controllers.ResetAdminPasswordHandler, middlewares.RequirePermission("admins.edit"))
+		admin.DELETE("/admins/:id", controllers.DeleteAdminUserHandler, middlewares.RequirePermission("admins.delete"))
 
 		// Onboarding question management
 		admin.POST("/onboarding/question", controllers.AdminCreateQuestionHandler, middlewares.RequirePermission("onboarding.edit"))
@@ -86,26 +141,121 @@ func SetupRoutes(e *echo.Group) {
 		admin.DELETE("/onboarding/question/:id", controllers.AdminDeleteQuestionHandler, middlewares.RequirePermission("onboarding.delete"))
 		admin.DELETE("/onboarding/question/:qid/options/:optionId", controllers.AdminDeleteOptionHandler, middlewares.RequirePermission("onboarding.delete"))
 
-		// User management (future)
-		// admin.GET("/users", controllers.GetUsers, middlewares.RequirePermission("users.view"))
-		// admin.PUT("/users/:id", controllers.UpdateUser, middlewares.RequirePermission("users.edit"))
-		// admin.DELETE("/users/:id", controllers.DeleteUser, middlewares.RequirePermission("users.delete"))
-
-		// Clinic management (super_admin only)
+		// Onboarding translations (body: {"question_text": "..."} / {"option_text": "..."}; blank value removes)
+		admin.GET("/onboarding/question/:id/translations", controllers.ListTranslationsHandler(services.TranslationEntityQuestion), middlewares.RequirePermission("onboarding.view"))
+		admin.PUT("/onboarding/question/:id/translations/:locale", controllers.UpsertTranslationsHandler(services.TranslationEntityQuestion), middlewares.RequirePermission("onboarding.edit"))
+		admin.DELETE("/onboarding/question/:id/translations/:locale", controllers.DeleteTranslationsHandler(services.TranslationEntityQuestion), middlewares.RequirePermission("onboarding.edit"))
+		admin.GET("/onboarding/options/:id/translations", controllers.ListTranslationsHandler(services.TranslationEntityOption), middlewares.RequirePermission("onboarding.view"))
+		admin.PUT("/onboarding/options/:id/translations/:locale", controllers.UpsertTranslationsHandler(services.TranslationEntityOption), middlewares.RequirePermission("onboarding.edit"))
+		admin.DELETE("/onboarding/options/:id/translations/:locale", controllers.DeleteTranslationsHandler(services.TranslationEntityOption), middlewares.RequirePermission("onboarding.edit"))
+
+		// Customer user management
+		admin.GET("/users", controllers.SearchCustomersHandler, middlewares.RequirePermission("users.view"))
+		admin.GET("/users/:id", controllers.GetCustomerDetailHandler, middlewares.RequirePermission("users.view"))
+		admin.PATCH("/users/:id/status", controllers.SetCustomerStatusHandler, middlewares.RequirePermission("users.edit"))
+		admin.POST("/users/:id/logout", controllers.ForceLogoutCustomerHandler, middlewares.RequirePermission("users.edit"))
+		admin.DELETE("/users/:id", controllers.DeleteCustomerHandler, middlewares.RequirePermission("users.delete"))
+		admin.POST("/users/:id/restore", controllers.RestoreCustomerHandler, middlewares.RequirePermission("users.delete"))
+
+		// Clinic management (register: super_admin only)
 		admin.POST("/clinic/register", controllers.RegisterClinicHandler, middlewares.RequirePermission("clinics.create"))
-
-		// Treatment CRUD (super_admin only)
-		// TODO: Implement treatment CRUD controllers
-		// admin.POST("/treatments", controllers.CreateTreatmentHandler, middlewares.RequirePermission("treatments.edit"))
-		// admin.PUT("/treatments/:id", controllers.UpdateTreatmentHandler, middlewares.RequirePermission("treatments.edit"))
-		// admin.DELETE("/treatments/:id", controllers.DeleteTreatmentHandler, middlewares.RequirePermission("treatments.delete"))
+		admin.GET("/clinics", controllers.ListClinicsHandler, middlewares.RequirePermission("clinics.view"))
+		admin.GET("/clinics/:id", controllers.GetClinicAdminDetailHandler, middlewares.RequirePermission("clinics.view"))
+		admin.PUT("/clinics/:id", controllers.AdminUpdateClinicHandler, middlewares.RequirePermission("clinics.edit"))
+		admin.PATCH("/clinics/:id/status", controllers.SetClinicStatusHandler, middlewares.RequirePermission("clinics.edit"))
+		admin.DELETE("/clinics/:id", controllers.DeleteClinicHandler, middlewares.RequirePermission("clinics.delete"))
+		admin.POST("/clinics/:id/restore", controllers.RestoreClinicHandler, middlewares.RequirePermission("clinics.delete"))
+
+		// Clinic public profile edits awaiting approval
+		admin.GET("/clinic-profile-changes", controllers.ListClinicProfileChangesHandler, middlewares.RequirePermission("clinics.view"))
+		admin.POST("/clinic-profile-changes/:id/approve", controllers.ApproveClinicProfileChangeHandler, middlewares.RequirePermission("clinics.edit"))
+		admin.POST("/clinic-profile-changes/:id/reject", controllers.RejectClinicProfileChangeHandler, middlewares.RequirePermission("clinics.edit"))
+
+		// Clinic application review queue (approval registers the clinic and owner)
+		admin.GET("/clinic-applications", controllers.ListClinicApplicationsHandler, middlewares.RequirePermission("clinics.view"))
+		admin.GET("/clinic-applications/:id", controllers.GetClinicApplicationHandler, middlewares.RequirePermission("clinics.view"))
+		admin.GET("/clinic-applications/:id/documents/:documentId", controllers.DownloadClinicApplicationDocumentHandler, middlewares.RequirePermission("clinics.view"))
+		admin.POST("/clinic-applications/:id/approve", controllers.ApproveClinicApplicationHandler, middlewares.RequirePermission("clinics.create"))
+		admin.POST("/clinic-applications/:id/reject", controllers.RejectClinicApplicationHandler, middlewares.RequirePermission("clinics.create"))
+		admin.POST("/clinic-applications/:id/request-info", controllers.RequestClinicApplicationInfoHandler, middlewares.RequirePermission("clinics.create"))
+
+		// Treatment catalog CRUD (Treatment -> Area -> SideArea)
+		// Deletes return 409 while clinics/doctors use the node; use the status endpoints to archive instead
+		admin.POST("/treatments", controllers.CreateTreatmentHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PUT("/treatments/:id", controllers.UpdateTreatmentHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PATCH("/treatments/:id/status", controllers.SetTreatmentStatusHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/treatments/:id", controllers.DeleteTreatmentHandler, middlewares.RequirePermission("treatments.delete"))
+
+		admin.POST("/treatments/:id/areas", controllers.CreateAreaHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PUT("/areas/:id", controllers.UpdateAreaHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PATCH("/areas/:id/status", controllers.SetAreaStatusHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/areas/:id", controllers.DeleteAreaHandler, middlewares.RequirePermission("treatments.delete"))
+
+		admin.POST("/areas/:id/sideareas", controllers.CreateSideAreaHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PUT("/sideareas/:id", controllers.UpdateSideAreaHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.PATCH("/sideareas/:id/status", controllers.SetSideAreaStatusHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/sideareas/:id", controllers.DeleteSideAreaHandler, middlewares.RequirePermission("treatments.delete"))
+
+		// Room/equipment requirements of a treatment (PUT replaces the whole set)
+		admin.GET("/treatments/:id/requirements", controllers.GetTreatmentRequirementsHandler, middlewares.RequirePermission("treatments.view"))
+		admin.PUT("/treatments/:id/requirements", controllers.SetTreatmentRequirementsHandler, middlewares.RequirePermission("treatments.edit"))
+
+		// Catalog translations (body: {"name": "...", "description": "..."}; blank value removes)
+		admin.GET("/treatments/:id/translations", controllers.ListTranslationsHandler(services.TranslationEntityTreatment), middlewares.RequirePermission("treatments.view"))
+		admin.PUT("/treatments/:id/translations/:locale", controllers.UpsertTranslationsHandler(services.TranslationEntityTreatment), middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/treatments/:id/translations/:locale", controllers.DeleteTranslationsHandler(services.TranslationEntityTreatment), middlewares.RequirePermission("treatments.edit"))
+		admin.GET("/areas/:id/translations", controllers.ListTranslationsHandler(services.TranslationEntityArea), middlewares.RequirePermission("treatments.view"))
+		admin.PUT("/areas/:id/translations/:locale", controllers.UpsertTranslationsHandler(services.TranslationEntityArea), middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/areas/:id/translations/:locale", controllers.DeleteTranslationsHandler(services.TranslationEntityArea), middlewares.RequirePermission("treatments.edit"))
+		admin.GET("/sideareas/:id/translations", controllers.ListTranslationsHandler(services.TranslationEntitySideArea), middlewares.RequirePermission("treatments.view"))
+		admin.PUT("/sideareas/:id/translations/:locale", controllers.UpsertTranslationsHandler(services.TranslationEntitySideArea), middlewares.RequirePermission("treatments.edit"))
+		admin.DELETE("/sideareas/:id/translations/:locale", controllers.DeleteTranslationsHandler(services.TranslationEntitySideArea), middlewares.RequirePermission("treatments.edit"))
+
+		// Catalog versioning: edits above change the draft; public endpoints serve the latest published version
+		admin.GET("/catalog/draft", controllers.GetCatalogDraftHandler, middlewares.RequirePermission("treatments.view"))
+		admin.GET("/catalog/draft/diff", controllers.GetCatalogDraftDiffHandler, middlewares.RequirePermission("treatments.view"))
+		admin.POST("/catalog/publish", controllers.PublishCatalogHandler, middlewares.RequirePermission("treatments.edit"))
+		admin.GET("/catalog/versions", controllers.ListCatalogVersionsHandler, middlewares.RequirePermission("treatments.view"))
+		admin.POST("/catalog/versions/:version/rollback", controllers.RollbackCatalogHandler, middlewares.RequirePermission("treatments.edit"))
+
+		// Catalog spreadsheet import/export (CSV or JSON); imports upsert into the draft by stable code
+		admin.GET("/catalog/export", controllers.ExportCatalogHandler, middlewares.RequirePermission("treatments.view"))
+		admin.POST("/catalog/import", controllers.ImportCatalogHandler, middlewares.RequirePermission("treatments.edit"))
+
+		// Platform analytics (aggregated in SQL)
+		admin.GET("/analytics/signups", controllers.GetSignupAnalyticsHandler, middlewares.RequirePermission("analytics.view"))
+		admin.GET("/analytics/onboarding", controllers.GetOnboardingAnalyticsHandler, middlewares.RequirePermission("analytics.view"))
+		admin.GET("/analytics/clinics", controllers.GetClinicAnalyticsHandler, middlewares.RequirePermission("analytics.view"))
+		admin.GET("/analytics/clinic-treatments", controllers.GetClinicTreatmentAnalyticsHandler, middlewares.RequirePermission("analytics.view"))
+		admin.GET("/analytics/prices", controllers.GetPriceAnalyticsHandler, middlewares.RequirePermission("analytics.view"))
+
+		// CSV/XLSX list exports; large exports become background jobs
+		admin.GET("/exports", controllers.ListExportDatasetsHandler(false), middlewares.RequirePermission("analytics.export"))
+		admin.GET("/exports/jobs", controllers.ListExportJobsHandler(false), middlewares.RequirePermission("analytics.export"))
+		admin.GET("/exports/jobs/:id", controllers.GetExportJobHandler(false), middlewares.RequirePermission("analytics.export"))
+		admin.GET("/exports/jobs/:id/download", controllers.DownloadExportJobHandler(false), middlewares.RequirePermission("analytics.export"))
+		admin.GET("/exports/:dataset", controllers.ExportHandler(false), middlewares.RequirePermission("analytics.export"))
+		admin.POST("/exports/:dataset/jobs", controllers.CreateExportJobHandler(false), middlewares.RequirePermission("analytics.export"))
+
+		// Appointments across all clinics
+		admin.GET("/appointments", controllers.ListAdminAppointmentsHandler, middlewares.RequirePermission("appointments.view"))
+		admin.GET("/appointments/:id", controllers.GetAdminAppointmentHandler, middlewares.RequirePermission("appointments.view"))
+		admin.PATCH("/appointments/:id/status", controllers.SetAdminAppointmentStatusHandler, middlewares.RequirePermission("appointments.edit"))
+
+		// Audit log (append-only; every successful mutating request under /admin, /clinic and /v1 is recorded)
+		admin.GET("/audit-logs", controllers.ListAuditLogsHandler, middlewares.RequirePermission("audit.view"))
 	}
 
 	// ========== CLINIC ROUTES (Clinic Auth Required) ==========
-	clinic := e.Group("/clinic", middlewares.ClinicAuthMiddleware)
+	clinic := e.Group("/clinic", middlewares.ClinicAuthMiddleware, middlewares.AuditTrail)
 	{
 		// Staff management (owner only)
 		clinic.POST("/users/register", controllers.RegisterClinicUserHandler, middlewares.RequireClinicPermission("staff.create"))
+		clinic.GET("/users", controllers.GetClinicUsersHandler, middlewares.RequireClinicPermission("staff.view"))
+		clinic.GET("/users/:id", controllers.GetClinicUserHandler, middlewares.RequireClinicPermission("staff.view"))
+		clinic.PUT("/users/:id", controllers.UpdateClinicUserHandler, middlewares.RequireClinicPermission("staff.edit"))
+		clinic.PATCH("/users/:id/status", controllers.SetClinicUserStatusHandler, middlewares.RequireClinicPermission("staff.edit"))
+		clinic.DELETE("/users/:id", controllers.DeleteClinicUserHandler, middlewares.RequireClinicPermission("staff.delete"))
 
 		// Doctor/Injector registration (owner only) - treatments optional
 		clinic.POST("/doctors/register", controllers.RegisterDoctorHandler, middlewares.RequireClinicPermission("staff.create"))
@@ -138,6 +288,26 @@ func SetupRoutes(e *echo.Group) {
 		clinic.GET("/roles", controllers.GetClinicRolesHandler)
 		// Get treatments with side area prices for clinic
 		clinic.GET("/treatments", controllers.GetTreatmentByClinicHandler)
+		// Appointment duration overrides for a treatment (PUT replaces them; unlisted side areas use the catalog)
+		clinic.GET("/treatments/:treatmentId/durations", controllers.GetClinicTreatmentDurationsHandler)
+		clinic.PUT("/treatments/:treatmentId/durations", controllers.SetClinicTreatmentDurationsHandler, middlewares.RequireClinicPermission("areas.edit"))
+
+		// Own profile, role, clinic and effective permissions
+		clinic.GET("/profile/me", controllers.GetClinicUserProfileHandler, middlewares.RequireClinicPermission("profile.view"))
+		clinic.PUT("/profile/me", controllers.UpdateClinicUserProfileHandler, middlewares.RequireClinicPermission("profile.edit"))
+
+		// Own weekly availability and leave (doctors/injectors)
+		clinic.GET("/profile/me/availability", controllers.GetPractitionerAvailabilityHandler(true), middlewares.RequireClinicPermission("profile.view"))
+		clinic.PUT("/profile/me/availability", controllers.SetPractitionerAvailabilityHandler(true), middlewares.RequireClinicPermission("profile.edit"))
+		clinic.POST("/profile/me/availability/exceptions", controllers.CreateAvailabilityExceptionHandler(true), middlewares.RequireClinicPermission("profile.edit"))
+		clinic.DELETE("/profile/me/availability/exceptions/:exceptionId", controllers.DeleteAvailabilityExceptionHandler(true), middlewares.RequireClinicPermission("profile.edit"))
+
+		// Own calendar subscription URLs; staff managers can list and revoke anyone's
+		clinic.GET("/profile/me/calendar-feeds", controllers.GetMyCalendarFeedsHandler, middlewares.RequireClinicPermission("profile.view"))
+		clinic.POST("/profile/me/calendar-feeds", controllers.CreateMyCalendarFeedHandler, middlewares.RequireClinicPermission("profile.edit"))
+		clinic.DELETE("/profile/me/calendar-feeds/:id", controllers.RevokeMyCalendarFeedHandler, middlewares.RequireClinicPermission("profile.edit"))
+		clinic.GET("/calendar-feeds", controllers.GetClinicCalendarFeedsHandler, middlewares.RequireClinicPermission("staff.view"))
+		clinic.DELETE("/calendar-feeds/:id", controllers.RevokeClinicCalendarFeedHandler, middlewares.RequireClinicPermission("staff.edit"))
 
 		// Change password (requires auth)
 		clinic.POST("/change-password", controllers.ClinicChangePasswordHandler)
@@ -145,11 +315,82 @@ func SetupRoutes(e *echo.Group) {
 		// Logout (requires auth)
 		clinic.POST("/logout", controllers.ClinicLogoutHandler)
 
-		// TODO: Add more clinic endpoints
-		// clinic.GET("/profile/me", controllers.GetClinicUserProfileHandler, middlewares.RequireClinicPermission("profile.view"))
-		// clinic.GET("/users", controllers.GetClinicUsersHandler, middlewares.RequireClinicPermission("staff.view"))
-		// clinic.PUT("/users/:id", controllers.UpdateClinicUserHandler, middlewares.RequireClinicPermission("staff.edit"))
-		// clinic.DELETE("/users/:id", controllers.DeleteClinicUserHandler, middlewares.RequireClinicPermission("staff.delete"))
+		// CSV/XLSX exports of this clinic's doctors and price lists
+		clinic.GET("/exports", controllers.ListExportDatasetsHandler(true), middlewares.RequireClinicPermission("reports.export"))
+		clinic.GET("/exports/jobs", controllers.ListExportJobsHandler(true), middlewares.RequireClinicPermission("reports.export"))
+		clinic.GET("/exports/jobs/:id", controllers.GetExportJobHandler(true), middlewares.RequireClinicPermission("reports.export"))
+		clinic.GET("/exports/jobs/:id/download", controllers.DownloadExportJobHandler(true), middlewares.RequireClinicPermission("reports.export"))
+		clinic.GET("/exports/:dataset", controllers.ExportHandler(true), middlewares.RequireClinicPermission("reports.export"))
+		clinic.POST("/exports/:dataset/jobs", controllers.CreateExportJobHandler(true), middlewares.RequireClinicPermission("reports.export"))
+
+		// Audit log for this clinic's changes (owner only)
+		clinic.GET("/audit-logs", controllers.ListClinicAuditLogsHandler, middlewares.RequireClinicPermission("audit.view"))
+
+		// Clinic profile and settings; public profile edits may wait for admin approval (CLINIC_PROFILE_REVIEW_REQUIRED)
+		clinic.GET("/settings", controllers.GetClinicSettingsHandler, middlewares.RequireClinicPermission("clinic.view"))
+		clinic.PUT("/settings", controllers.UpdateClinicSettingsHandler, middlewares.RequireClinicPermission("clinic.edit"))
+
+		// Appointment reminder timing and templates; no rules means the 48h and 2h email defaults
+		clinic.GET("/reminders", controllers.GetClinicReminderRulesHandler, middlewares.RequireClinicPermission("clinic.view"))
+		clinic.PUT("/reminders", controllers.SetClinicReminderRulesHandler, middlewares.RequireClinicPermission("clinic.edit"))
+
+		// Cancellation policies (clinic default, optionally per treatment) and the deposits and fees they charge
+		clinic.GET("/cancellation-policies", controllers.GetClinicCancellationPoliciesHandler, middlewares.RequireClinicPermission("clinic.view"))
+		clinic.PUT("/cancellation-policy", controllers.SetClinicCancellationPolicyHandler, middlewares.RequireClinicPermission("clinic.edit"))
+		clinic.DELETE("/cancellation-policy", controllers.DeleteClinicCancellationPolicyHandler, middlewares.RequireClinicPermission("clinic.edit"))
+		clinic.PUT("/treatments/:treatmentId/cancellation-policy", controllers.SetClinicCancellationPolicyHandler, middlewares.RequireClinicPermission("clinic.edit"))
+		clinic.DELETE("/treatments/:treatmentId/cancellation-policy", controllers.DeleteClinicCancellationPolicyHandler, middlewares.RequireClinicPermission("clinic.edit"))
+		clinic.GET("/charges", controllers.GetClinicChargesHandler, middlewares.RequireClinicPermission("payments.view"))
+		clinic.POST("/charges/:id/collect", controllers.CollectClinicChargeHandler, middlewares.RequireClinicPermission("payments.edit"))
+		clinic.POST("/charges/:id/waive", controllers.WaiveClinicChargeHandler, middlewares.RequireClinicPermission("payments.edit"))
+		clinic.DELETE("/settings/pending", controllers.WithdrawClinicProfileChangeHandler, middlewares.RequireClinicPermission("clinic.edit"))
+
+		// Appointments; status moves requested -> confirmed -> checked_in -> completed (or cancelled / no_show)
+		clinic.GET("/appointments", controllers.GetClinicAppointmentsHandler, middlewares.RequireClinicPermission("appointments.view"))
+		clinic.GET("/appointments/:id", controllers.GetClinicAppointmentHandler, middlewares.RequireClinicPermission("appointments.view"))
+		clinic.POST("/appointments", controllers.CreateClinicAppointmentHandler, middlewares.RequireClinicPermission("appointments.create"))
+		clinic.PUT("/appointments/:id", controllers.RescheduleClinicAppointmentHandler, middlewares.RequireClinicPermission("appointments.edit"))
+		clinic.PATCH("/appointments/:id/status", controllers.SetClinicAppointmentStatusHandler, middlewares.RequireClinicPermission("appointments.edit"))
+		clinic.POST("/appointments/:id/cancel", controllers.CancelClinicAppointmentHandler, middlewares.RequireClinicPermission("appointments.delete"))
+		clinic.GET("/appointments/:id/reminders", controllers.GetClinicAppointmentRemindersHandler, middlewares.RequireClinicPermission("appointments.view"))
+		clinic.GET("/slots", controllers.GetOwnClinicSlotsHandler, middlewares.RequireClinicPermission("appointments.view"))
+		clinic.GET("/waitlist", controllers.GetClinicWaitlistHandler, middlewares.RequireClinicPermission("appointments.view"))
+
+		// Patient records: customers who booked here and walk-ins
+		clinic.GET("/patients", controllers.GetClinicPatientsHandler, middlewares.RequireClinicPermission("patients.view"))
+		clinic.POST("/patients", controllers.CreateClinicPatientHandler, middlewares.RequireClinicPermission("patients.create"))
+		clinic.GET("/patients/:id", controllers.GetClinicPatientHandler, middlewares.RequireClinicPermission("patients.view"))
+		clinic.PUT("/patients/:id", controllers.UpdateClinicPatientHandler, middlewares.RequireClinicPermission("patients.edit"))
+		clinic.DELETE("/patients/:id", controllers.DeleteClinicPatientHandler, middlewares.RequireClinicPermission("patients.delete"))
+
+		// Treatment records: drafts are editable until signed, then only addenda can be added.
+		// Signing needs only create, so injectors can sign their own records.
+		clinic.GET("/treatment-records", controllers.GetTreatmentRecordsHandler, middlewares.RequireClinicPermission("treatment_records.view"))
+		clinic.POST("/treatment-records", controllers.CreateTreatmentRecordHandler, middlewares.RequireClinicPermission("treatment_records.create"))
+		clinic.GET("/treatment-records/:id", controllers.GetTreatmentRecordHandler, middlewares.RequireClinicPermission("treatment_records.view"))
+		clinic.PUT("/treatment-records/:id", controllers.UpdateTreatmentRecordHandler, middlewares.RequireClinicPermission("treatment_records.edit"))
+		clinic.POST("/treatment-records/:id/sign", controllers.SignTreatmentRecordHandler, middlewares.RequireClinicPermission("treatment_records.create"))
+		clinic.POST("/treatment-records/:id/addenda", controllers.AddTreatmentRecordAddendumHandler, middlewares.RequireClinicPermission("treatment_records.edit"))
+
+		// Schedules: opening hours, holidays and practitioner availability (times in the clinic's timezone)
+		clinic.GET("/opening-hours", controllers.GetClinicOpeningHoursHandler, middlewares.RequireClinicPermission("schedules.view"))
+		clinic.PUT("/opening-hours", controllers.SetClinicOpeningHoursHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.GET("/holidays", controllers.GetClinicHolidaysHandler, middlewares.RequireClinicPermission("schedules.view"))
+		clinic.POST("/holidays", controllers.CreateClinicHolidayHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.DELETE("/holidays/:id", controllers.DeleteClinicHolidayHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.GET("/practitioners/:id/availability", controllers.GetPractitionerAvailabilityHandler(false), middlewares.RequireClinicPermission("schedules.view"))
+		clinic.PUT("/practitioners/:id/availability", controllers.SetPractitionerAvailabilityHandler(false), middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.POST("/practitioners/:id/availability/exceptions", controllers.CreateAvailabilityExceptionHandler(false), middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.DELETE("/practitioners/:id/availability/exceptions/:exceptionId", controllers.DeleteAvailabilityExceptionHandler(false), middlewares.RequireClinicPermission("schedules.edit"))
+
+		// Rooms and devices booked alongside practitioners; deletes return 409 while upcoming appointments hold them
+		clinic.GET("/resources", controllers.GetClinicResourcesHandler, middlewares.RequireClinicPermission("schedules.view"))
+		clinic.POST("/resources", controllers.CreateClinicResourceHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.PUT("/resources/:id", controllers.UpdateClinicResourceHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		clinic.DELETE("/resources/:id", controllers.DeleteClinicResourceHandler, middlewares.RequireClinicPermission("schedules.edit"))
+		// Clinic adjustments of a treatment's room/equipment requirements (PUT replaces them; quantity 0 waives a catalog requirement)
+		clinic.GET("/treatments/:treatmentId/requirements", controllers.GetClinicTreatmentRequirementsHandler, middlewares.RequireClinicPermission("schedules.view"))
+		clinic.PUT("/treatments/:treatmentId/requirements", controllers.SetClinicTreatmentRequirementsHandler, middlewares.RequireClinicPermission("schedules.edit"))
 	}
 
 }
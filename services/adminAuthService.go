@@ -19,6 +19,7 @@ func GenerateAdminJWT(email string, adminID uint64, role string) (string, error)
 		"email":    email,
 		"admin_id": adminID,
 		"role":     role,
+		"iat":      tokenIssuedAtClaim(),
 		"exp":      time.Now().Add(accessTokenDuration).Unix(),
 	})
 	return token.SignedString(jwtSecret)
@@ -42,7 +43,7 @@ func AdminRegister(req reqdto.AdminRegisterRequest) (resdto.BaseResponse, error)
 
 	// Check if email already exists
 	var existingUser models.AdminUser
-	if err := db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
+	if err := db.Unscoped().Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
 		return resdto.BaseResponse{IsSuccess: false, Message: "email already exists"}, errors.New("email already exists")
 	}
 
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/permissions"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== ADMIN USER ADMINISTRATION ====================
+
+var (
+	// ErrLastSuperAdmin is returned when a change would leave no active super_admin
+	ErrLastSuperAdmin = errors.New("cannot remove the last active super_admin")
+	// ErrAdminSelfAction is returned when an admin tries to deactivate or delete their own account
+	ErrAdminSelfAction = errors.New("you cannot deactivate or delete your own account")
+)
+
+// minAdminPasswordLength matches the validation on AdminRegisterRequest
+const minAdminPasswordLength = 8
+
+// ListAdminUsers returns admin accounts filtered by name/email search, role name and status
+func ListAdminUsers(term, roleName, status string, page, pageSize int) (*resdto.AdminUserListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.AdminUser{})
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		query = query.Where("admin_users.name LIKE ? OR admin_users.email LIKE ?", like, like)
+	}
+	if roleName != "" {
+		query = query.Where("admin_users.role_id IN (?)", db.Model(&models.Role{}).Select("id").Where("name = ?", roleName))
+	}
+	if status != "" {
+		query = query.Where("admin_users.status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	admins := []models.AdminUser{}
+	if err := query.Preload("Role").Order("admin_users.id DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&admins).Error; err != nil {
+		return nil, err
+	}
+
+	return &resdto.AdminUserListResponse{
+		Items: admins,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// ensureOtherSuperAdmin locks the active super_admin rows and fails with ErrLastSuperAdmin when
+// adminID is the only one. Admins that are not active super_admins pass. Callers run it and their
+// change in one transaction, so concurrent demotions and deactivations are checked one at a time.
+func ensureOtherSuperAdmin(tx *gorm.DB, adminID uint64) error {
+	var ids []uint64
+	if err := tx.Model(&models.AdminUser{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN roles ON roles.id = admin_users.role_id").
+		Where("roles.name = ? AND admin_users.status = ?", models.AdminRoleSuperAdmin, models.AdminStatusActive).
+		Order("admin_users.id").
+		Pluck("admin_users.id", &ids).Error; err != nil {
+		return err
+	}
+	isSuperAdmin := false
+	for _, id := range ids {
+		if id == adminID {
+			isSuperAdmin = true
+		}
+	}
+	if isSuperAdmin && len(ids) == 1 {
+		return ErrLastSuperAdmin
+	}
+	return nil
+}
+
+// checkAdminEmailAvailable rejects emails used by any other admin, including deleted ones
+func checkAdminEmailAvailable(db *gorm.DB, email string, adminID uint64) error {
+	var existing models.AdminUser
+	err := db.Unscoped().Where("email = ? AND id <> ?", email, adminID).First(&existing).Error
+	if err == nil {
+		return errors.New("email already exists")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// UpdateAdminUser edits another admin's name, email or role.
+// Role changes take effect immediately: cached permissions are dropped and existing tokens revoked.
+func UpdateAdminUser(adminID uint64, req reqdto.UpdateAdminUserRequest) (*models.AdminUser, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, errors.New("name must be at least 2 characters")
+		}
+		updates["name"] = name
+	}
+	if req.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		if !strings.Contains(email, "@") {
+			return nil, errors.New("invalid email")
+		}
+		if err := checkAdminEmailAvailable(db, email, adminID); err != nil {
+			return nil, err
+		}
+		updates["email"] = email
+	}
+
+	roleChanged := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Moving an admin off super_admin locks the super_admin rows first, in the same order as
+		// SetAdminStatus and DeleteAdminUser, so concurrent changes wait instead of deadlocking
+		if req.RoleName != nil && *req.RoleName != models.AdminRoleSuperAdmin {
+			if err := ensureOtherSuperAdmin(tx, adminID); err != nil {
+				return err
+			}
+		}
+		// Lock the row so a concurrent deactivation or delete is not overwritten
+		var admin models.AdminUser
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Role").First(&admin, adminID).Error; err != nil {
+			return err
+		}
+
+		if req.RoleName != nil && *req.RoleName != admin.Role.Name {
+			var role models.Role
+			if err := tx.Where("name = ?", *req.RoleName).First(&role).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("invalid role name")
+				}
+				return err
+			}
+			updates["role_id"] = role.ID
+			roleChanged = true
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&admin).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if roleChanged {
+		permissions.InvalidatePermissionCache(adminID)
+		if err := RevokeAdminSessions(adminID); err != nil {
+			return nil, err
+		}
+	}
+	return GetAdminUserByID(adminID)
+}
+
+// SetAdminStatus activates or deactivates an admin. Deactivating ends all of their sessions.
+func SetAdminStatus(actorID, adminID uint64, status string) (*models.AdminUser, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if status != models.AdminStatusActive && status != models.AdminStatusInactive {
+		return nil, errors.New("status must be 'active' or 'inactive'")
+	}
+
+	var admin models.AdminUser
+	if err := db.Preload("Role").First(&admin, adminID).Error; err != nil {
+		return nil, err
+	}
+
+	if status == models.AdminStatusInactive && actorID == adminID {
+		return nil, ErrAdminSelfAction
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if status == models.AdminStatusInactive {
+			if err := ensureOtherSuperAdmin(tx, adminID); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&admin).Update("status", status).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == models.AdminStatusInactive {
+		if err := RevokeAdminSessions(adminID); err != nil {
+			return nil, err
+		}
+	}
+	return GetAdminUserByID(adminID)
+}
+
+// DeleteAdminUser soft-deletes an admin account and ends all of their sessions
+func DeleteAdminUser(actorID, adminID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	if actorID == adminID {
+		return ErrAdminSelfAction
+	}
+
+	var admin models.AdminUser
+	if err := db.Preload("Role").First(&admin, adminID).Error; err != nil {
+		return err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureOtherSuperAdmin(tx, adminID); err != nil {
+			return err
+		}
+		return tx.Delete(&admin).Error
+	})
+	if err != nil {
+		return err
+	}
+	permissions.InvalidatePermissionCache(adminID)
+	return RevokeAdminSessions(adminID)
+}
+
+// generateTemporaryPassword returns a random URL-safe password
+func generateTemporaryPassword() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ResetAdminPassword sets a new password for an admin and ends all of their sessions.
+// When newPassword is empty a temporary password is generated and returned.
+func ResetAdminPassword(adminID uint64, newPassword string) (string, error) {
+	db := config.DB
+	if db == nil {
+		return "", errors.New("database not initialized")
+	}
+
+	var admin models.AdminUser
+	if err := db.First(&admin, adminID).Error; err != nil {
+		return "", err
+	}
+
+	temporary := ""
+	if newPassword == "" {
+		generated, err := generateTemporaryPassword()
+		if err != nil {
+			return "", err
+		}
+		newPassword = generated
+		temporary = generated
+	} else if len(newPassword) < minAdminPasswordLength {
+		return "", errors.New("new password must be at least 8 characters")
+	}
+
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return "", errors.New("failed to hash password")
+	}
+	if err := db.Model(&admin).Update("password_hash", hashedPassword).Error; err != nil {
+		return "", errors.New("failed to update password")
+	}
+	if err := RevokeAdminSessions(adminID); err != nil {
+		return "", err
+	}
+	return temporary, nil
+}
+
+// UpdateAdminProfile lets an admin edit their own name and email
+func UpdateAdminProfile(adminID uint64, req reqdto.UpdateAdminProfileRequest) (*models.AdminUser, error) {
+	return UpdateAdminUser(adminID, reqdto.UpdateAdminUserRequest{Name: req.Name, Email: req.Email})
+}
+
+// AdminChangePassword verifies the current password and sets a new one (authenticated)
+func AdminChangePassword(adminID uint64, oldPassword, newPassword string) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var admin models.AdminUser
+	if err := db.First(&admin, adminID).Error; err != nil {
+		return errors.New("user not found")
+	}
+
+	if !CheckPasswordHash(oldPassword, admin.PasswordHash) {
+		return errors.New("incorrect old password")
+	}
+	if len(newPassword) < minAdminPasswordLength {
+		return errors.New("new password must be at least 8 characters")
+	}
+
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	if err := db.Model(&admin).Update("password_hash", hashedPassword).Error; err != nil {
+		return errors.New("failed to update password")
+	}
+	// Sign out every existing session, including the one that made the change
+	return RevokeAdminSessions(adminID)
+}
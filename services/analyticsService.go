@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"skinSync/config"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== ADMIN ANALYTICS ====================
+// All figures are computed in SQL with GROUP BY/COUNT/AVG; no rows are loaded into Go.
+
+// AnalyticsRange limits analytics to records created in [From, To)
+type AnalyticsRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// analyticsPeriodFormats maps time grouping names to MySQL DATE_FORMAT patterns
+var analyticsPeriodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%x-W%v",
+	"month": "%Y-%m",
+	"year":  "%Y",
+}
+
+// apply filters column to the range
+func (r AnalyticsRange) apply(query *gorm.DB, column string) *gorm.DB {
+	if r.From != nil {
+		query = query.Where(column+" >= ?", *r.From)
+	}
+	if r.To != nil {
+		query = query.Where(column+" < ?", *r.To)
+	}
+	return query
+}
+
+// dto echoes the range and grouping in responses
+func (r AnalyticsRange) dto(groupBy string) resdto.AnalyticsRange {
+	out := resdto.AnalyticsRange{GroupBy: groupBy}
+	if r.From != nil {
+		out.From = r.From.Format(time.RFC3339)
+	}
+	if r.To != nil {
+		out.To = r.To.Format(time.RFC3339)
+	}
+	return out
+}
+
+// roundTo rounds to the given number of decimals for display
+func roundTo(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
+
+// GetSignupAnalytics counts customer signups per period and login provider.
+// groupBy is day, week, month or year; provider optionally limits to one provider.
+func GetSignupAnalytics(r AnalyticsRange, groupBy, provider string) (*resdto.SignupAnalyticsResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if groupBy == "" {
+		groupBy = "day"
+	}
+	format, ok := analyticsPeriodFormats[groupBy]
+	if !ok {
+		return nil, errors.New("group_by must be 'day', 'week', 'month' or 'year'")
+	}
+
+	users := r.apply(db.Model(&models.User{}), "users.created_at")
+	if provider != "" {
+		users = users.Where("users.id IN (?)", db.Model(&models.AuthProvider{}).Select("user_id").Where("provider = ?", provider))
+	}
+
+	resp := &resdto.SignupAnalyticsResponse{Range: r.dto(groupBy), Buckets: []resdto.SignupBucket{}}
+	if err := users.Count(&resp.Total).Error; err != nil {
+		return nil, err
+	}
+
+	// format comes from the whitelist above, so it is safe to inline
+	query := r.apply(db.Model(&models.User{}), "users.created_at").
+		Select(fmt.Sprintf("DATE_FORMAT(users.created_at, '%s') AS period, auth_providers.provider AS provider, COUNT(DISTINCT users.id) AS count", format)).
+		Joins("JOIN auth_providers ON auth_providers.user_id = users.id")
+	if provider != "" {
+		query = query.Where("auth_providers.provider = ?", provider)
+	}
+	if err := query.Group("period, provider").Order("period, provider").Scan(&resp.Buckets).Error; err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOnboardingAnalytics reports how far customers who signed up in the range got through onboarding.
+// A customer has completed onboarding once they answered every current question.
+func GetOnboardingAnalytics(r AnalyticsRange) (*resdto.OnboardingAnalyticsResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	resp := &resdto.OnboardingAnalyticsResponse{Range: r.dto(""), Questions: []resdto.OnboardingQuestionStat{}}
+	usersInRange := r.apply(db.Model(&models.User{}).Select("users.id"), "users.created_at")
+
+	if err := r.apply(db.Model(&models.User{}), "users.created_at").Count(&resp.Customers).Error; err != nil {
+		return nil, err
+	}
+
+	var questions int64
+	if err := db.Model(&models.SkinConditionQuestion{}).Count(&questions).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&models.SkinConditionQuestionAnswer{}).
+		Where("user_id IN (?)", usersInRange).
+		Distinct("user_id").Count(&resp.Started).Error; err != nil {
+		return nil, err
+	}
+
+	if questions > 0 {
+		completedUsers := db.Model(&models.SkinConditionQuestionAnswer{}).
+			Select("skin_condition_question_answers.user_id").
+			Joins("JOIN skin_condition_questions ON skin_condition_questions.id = skin_condition_question_answers.question_id").
+			Where("skin_condition_question_answers.user_id IN (?)", usersInRange).
+			Group("skin_condition_question_answers.user_id").
+			Having("COUNT(DISTINCT skin_condition_question_answers.question_id) >= ?", questions)
+		if err := db.Table("(?) AS completed_users", completedUsers).Count(&resp.Completed).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.Model(&models.UserProfile{}).
+		Where("user_id IN (?)", usersInRange).
+		Distinct("user_id").Count(&resp.WithProfile).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&models.SkinConditionQuestion{}).
+		Select("skin_condition_questions.id AS question_id, skin_condition_questions.question_text AS question_text, COUNT(DISTINCT skin_condition_question_answers.user_id) AS answered").
+		Joins("LEFT JOIN skin_condition_question_answers ON skin_condition_question_answers.question_id = skin_condition_questions.id AND skin_condition_question_answers.user_id IN (?)", usersInRange).
+		Group("skin_condition_questions.id, skin_condition_questions.question_text").
+		Order("skin_condition_questions.id").
+		Scan(&resp.Questions).Error; err != nil {
+		return nil, err
+	}
+
+	if resp.Customers > 0 {
+		resp.CompletionRate = roundTo(float64(resp.Completed)/float64(resp.Customers), 4)
+	}
+	return resp, nil
+}
+
+// GetClinicAnalytics counts clinics (grouped by status or creation period) and practitioners by role.
+// Soft-deleted clinics and their staff are excluded.
+func GetClinicAnalytics(r AnalyticsRange, groupBy string) (*resdto.ClinicAnalyticsResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if groupBy == "" {
+		groupBy = "status"
+	}
+
+	keyExpr := "clinics.status"
+	if groupBy != "status" {
+		format, ok := analyticsPeriodFormats[groupBy]
+		if !ok {
+			return nil, errors.New("group_by must be 'status', 'day', 'week', 'month' or 'year'")
+		}
+		keyExpr = fmt.Sprintf("DATE_FORMAT(clinics.created_at, '%s')", format)
+	}
+
+	resp := &resdto.ClinicAnalyticsResponse{
+		Range:         r.dto(groupBy),
+		ClinicsBy:     []resdto.CountBucket{},
+		DoctorsByRole: []resdto.CountBucket{},
+		StaffByRole:   []resdto.CountBucket{},
+	}
+
+	clinics := r.apply(db.Model(&models.Clinic{}), "clinics.created_at")
+	if err := clinics.Count(&resp.Clinics).Error; err != nil {
+		return nil, err
+	}
+	if err := r.apply(db.Model(&models.Clinic{}), "clinics.created_at").
+		Select(keyExpr + " AS `key`, COUNT(*) AS count").
+		Group("`key`").Order("`key`").
+		Scan(&resp.ClinicsBy).Error; err != nil {
+		return nil, err
+	}
+
+	staff := r.apply(db.Model(&models.ClinicUser{}), "clinic_users.created_at").
+		Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
+		Joins("JOIN clinics ON clinics.id = clinic_users.clinic_id AND clinics.deleted_at IS NULL").
+		Where("clinic_users.deleted_at IS NULL")
+	if err := staff.
+		Select("clinic_roles.name AS `key`, COUNT(*) AS count").
+		Group("clinic_roles.name").Order("clinic_roles.name").
+		Scan(&resp.StaffByRole).Error; err != nil {
+		return nil, err
+	}
+
+	doctorRoles := map[string]bool{models.ClinicRoleDoctor: true, models.ClinicRoleInjector: true}
+	for _, b := range resp.StaffByRole {
+		if doctorRoles[b.Key] {
+			resp.DoctorsByRole = append(resp.DoctorsByRole, b)
+			resp.Doctors += b.Count
+		}
+	}
+	return resp, nil
+}
+
+// GetClinicTreatmentAnalytics lists how many treatments and side areas each clinic offers, most first
+func GetClinicTreatmentAnalytics(page, pageSize int) (*resdto.ClinicTreatmentAnalyticsResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	resp := &resdto.ClinicTreatmentAnalyticsResponse{Items: []resdto.ClinicTreatmentCount{}}
+	if err := db.Model(&models.Clinic{}).Count(&resp.Clinics).Error; err != nil {
+		return nil, err
+	}
+
+	var offered int64
+	if err := db.Model(&models.ClinicTreatment{}).
+		Joins("JOIN clinics ON clinics.id = clinic_treatments.clinic_id AND clinics.deleted_at IS NULL").
+		Where("clinic_treatments.status = ?", "active").
+		Count(&offered).Error; err != nil {
+		return nil, err
+	}
+	if resp.Clinics > 0 {
+		resp.AvgTreatments = roundTo(float64(offered)/float64(resp.Clinics), 2)
+	}
+
+	treatments := db.Model(&models.ClinicTreatment{}).Select("COUNT(*)").
+		Where("clinic_treatments.clinic_id = clinics.id AND clinic_treatments.status = ?", "active")
+	sideAreas := db.Model(&models.ClinicSideArea{}).Select("COUNT(DISTINCT clinic_side_areas.side_area_id)").
+		Where("clinic_side_areas.clinic_id = clinics.id AND clinic_side_areas.status = ?", "active")
+	if err := db.Model(&models.Clinic{}).
+		Select("clinics.id AS clinic_id, clinics.name AS clinic_name, (?) AS treatments, (?) AS side_areas", treatments, sideAreas).
+		Order("treatments DESC, clinics.id").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Scan(&resp.Items).Error; err != nil {
+		return nil, err
+	}
+
+	resp.Meta = resdto.PageMeta{Page: page, PageSize: pageSize, Total: resp.Clinics}
+	return resp, nil
+}
+
+// GetPriceAnalytics summarizes active clinic prices (min/max/avg/std dev) per side area, syringe size, area or treatment
+func GetPriceAnalytics(groupBy string, treatmentID, areaID uint64) (*resdto.PriceAnalyticsResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if groupBy == "" {
+		groupBy = "side_area"
+	}
+
+	var selectCols, groupCols, nameJoin string
+	switch groupBy {
+	case "treatment":
+		selectCols = "clinic_side_areas.treatment_id AS treatment_id, treatments.name AS name"
+		groupCols = "clinic_side_areas.treatment_id, treatments.name"
+		nameJoin = "JOIN treatments ON treatments.id = clinic_side_areas.treatment_id"
+	case "area":
+		selectCols = "clinic_side_areas.treatment_id AS treatment_id, clinic_side_areas.area_id AS area_id, areas.name AS name"
+		groupCols = "clinic_side_areas.treatment_id, clinic_side_areas.area_id, areas.name"
+		nameJoin = "JOIN areas ON areas.id = clinic_side_areas.area_id"
+	case "side_area":
+		selectCols = "clinic_side_areas.treatment_id AS treatment_id, clinic_side_areas.area_id AS area_id, clinic_side_areas.side_area_id AS side_area_id, side_areas.name AS name"
+		groupCols = "clinic_side_areas.treatment_id, clinic_side_areas.area_id, clinic_side_areas.side_area_id, side_areas.name"
+		nameJoin = "JOIN side_areas ON side_areas.id = clinic_side_areas.side_area_id"
+	case "syringe_size":
+		selectCols = "clinic_side_areas.treatment_id AS treatment_id, clinic_side_areas.area_id AS area_id, clinic_side_areas.side_area_id AS side_area_id, clinic_side_areas.syringe_size AS syringe_size, side_areas.name AS name"
+		groupCols = "clinic_side_areas.treatment_id, clinic_side_areas.area_id, clinic_side_areas.side_area_id, clinic_side_areas.syringe_size, side_areas.name"
+		nameJoin = "JOIN side_areas ON side_areas.id = clinic_side_areas.side_area_id"
+	default:
+		return nil, errors.New("group_by must be 'side_area', 'syringe_size', 'area' or 'treatment'")
+	}
+
+	query := db.Model(&models.ClinicSideArea{}).
+		Select(selectCols+", COUNT(DISTINCT clinic_side_areas.clinic_id) AS clinics, MIN(clinic_side_areas.price) AS `min`, MAX(clinic_side_areas.price) AS `max`, AVG(clinic_side_areas.price) AS `avg`, COALESCE(STDDEV_POP(clinic_side_areas.price), 0) AS std_dev").
+		Joins("JOIN clinics ON clinics.id = clinic_side_areas.clinic_id AND clinics.deleted_at IS NULL").
+		Joins(nameJoin).
+		Where("clinic_side_areas.price IS NOT NULL AND clinic_side_areas.status = ?", "active")
+	if treatmentID != 0 {
+		query = query.Where("clinic_side_areas.treatment_id = ?", treatmentID)
+	}
+	if areaID != 0 {
+		query = query.Where("clinic_side_areas.area_id = ?", areaID)
+	}
+
+	resp := &resdto.PriceAnalyticsResponse{GroupBy: groupBy, Items: []resdto.PriceStat{}}
+	if err := query.Group(groupCols).Order(groupCols).Scan(&resp.Items).Error; err != nil {
+		return nil, err
+	}
+	for i := range resp.Items {
+		resp.Items[i].Avg = roundTo(resp.Items[i].Avg, 2)
+		resp.Items[i].StdDev = roundTo(resp.Items[i].StdDev, 2)
+	}
+	return resp, nil
+}
@@ -0,0 +1,957 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"gorm.io/gorm"
+)
+
+// ==================== APPOINTMENT BOOKING ====================
+
+// appointmentBlock is the slot lock granularity; appointments start and end on block boundaries
+const appointmentBlock = 5 * time.Minute
+
+const (
+	maxAppointmentMinutes  = 8 * 60
+	maxExtraSyringeMinutes = 2 * 60
+	maxAppointmentNotes    = 2000
+)
+
+var (
+	// ErrAppointmentSlotTaken is returned when the practitioner already has a booking overlapping the time
+	ErrAppointmentSlotTaken = errors.New("the practitioner is already booked at this time")
+	// ErrAppointmentCustomerBusy is returned when the customer already has a booking overlapping the time
+	ErrAppointmentCustomerBusy = errors.New("the customer already has an appointment at this time")
+	// ErrAppointmentStatusChange is returned for a status change the workflow does not allow
+	ErrAppointmentStatusChange = errors.New("appointment cannot move to the requested status")
+	// ErrAppointmentNotReschedulable is returned when moving an appointment that is no longer requested or confirmed
+	ErrAppointmentNotReschedulable = errors.New("only requested or confirmed appointments can be rescheduled")
+	// ErrClinicNotBookable is returned when the clinic is not active
+	ErrClinicNotBookable = errors.New("clinic is not accepting bookings")
+	// ErrPractitionerNotBookable is returned when the practitioner is not an active doctor/injector of the clinic
+	ErrPractitionerNotBookable = errors.New("practitioner is not available for booking at this clinic")
+	// ErrPractitionerNotQualified is returned when the practitioner is not assigned the treatment or side area
+	ErrPractitionerNotQualified = errors.New("practitioner does not perform the selected treatment or side area")
+)
+
+// AppointmentFilter narrows appointment lists; zero values are ignored
+type AppointmentFilter struct {
+	ClinicID       uint64
+	UserID         uint64
+	PractitionerID uint64
+	Status         string
+	From           *time.Time
+	To             *time.Time
+}
+
+// getDefaultAppointmentDuration returns the default appointment length from env (default 30 minutes)
+func getDefaultAppointmentDuration() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("APPOINTMENT_DEFAULT_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = 30
+	}
+	d := time.Duration(minutes) * time.Minute
+	if rem := d % appointmentBlock; rem != 0 {
+		d += appointmentBlock - rem
+	}
+	return d
+}
+
+// appointmentDuration returns the appointment length; override (minutes) comes from clinic staff,
+// otherwise the length is computed from the treatment and side area durations
+func appointmentDuration(db *gorm.DB, clinicID uint64, treatmentID uint, items []models.AppointmentItem, override int) (time.Duration, error) {
+	if override == 0 {
+		estimate, err := estimateAppointmentDuration(db, clinicID, treatmentID, items)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(estimate.TotalMinutes) * time.Minute, nil
+	}
+	if override < 5 || override > maxAppointmentMinutes || override%5 != 0 {
+		return 0, fmt.Errorf("duration_minutes must be a multiple of 5 between 5 and %d", maxAppointmentMinutes)
+	}
+	return time.Duration(override) * time.Minute, nil
+}
+
+// validateAppointmentStart checks that start is in the future and on a block boundary
+func validateAppointmentStart(start time.Time) (time.Time, error) {
+	if start.IsZero() {
+		return start, errors.New("start_at is required")
+	}
+	start = start.UTC()
+	if !start.After(time.Now()) {
+		return start, errors.New("start_at must be in the future")
+	}
+	if !start.Truncate(appointmentBlock).Equal(start) {
+		return start, errors.New("start_at must be on a 5-minute boundary")
+	}
+	return start, nil
+}
+
+// checkBookableClinic returns the clinic when it exists and is active
+func checkBookableClinic(db *gorm.DB, clinicID uint64) (*models.Clinic, error) {
+	var clinic models.Clinic
+	if err := db.First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	if clinic.Status != models.ClinicStatusActive {
+		return nil, ErrClinicNotBookable
+	}
+	return &clinic, nil
+}
+
+// checkBookablePractitioner ensures the clinic user is an active doctor or injector of the clinic
+func checkBookablePractitioner(db *gorm.DB, clinicID, practitionerID uint64) error {
+	var user models.ClinicUser
+	err := db.Preload("Role").Where("id = ? AND clinic_id = ?", practitionerID, clinicID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPractitionerNotBookable
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != models.ClinicUserStatusActive || !isPractitionerRole(user.Role.Name) {
+		return ErrPractitionerNotBookable
+	}
+	return nil
+}
+
+// checkBookableCustomer ensures the customer account exists and is active
+func checkBookableCustomer(db *gorm.DB, userID uint64) error {
+	var user models.User
+	if err := db.Select("id", "status").First(&user, userID).Error; err != nil {
+		return err
+	}
+	if user.Status != models.UserStatusActive {
+		return errors.New("customer account is not active")
+	}
+	return nil
+}
+
+// checkPractitionerQualified ensures the practitioner is assigned every booked side area,
+// or the treatment itself when nothing is selected
+func checkPractitionerQualified(db *gorm.DB, clinicID, practitionerID uint64, treatmentID uint, items []models.AppointmentItem) error {
+	var count int64
+	if len(items) == 0 {
+		if err := db.Model(&models.ClinicUserTreatment{}).
+			Where("clinic_user_id = ? AND treatment_id = ?", practitionerID, treatmentID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrPractitionerNotQualified
+		}
+		return nil
+	}
+	for _, item := range items {
+		if err := db.Model(&models.ClinicUserSideArea{}).
+			Where("clinic_user_id = ? AND clinic_id = ? AND treatment_id = ? AND side_area_id = ?", practitionerID, clinicID, treatmentID, item.SideAreaID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrPractitionerNotQualified
+		}
+	}
+	return nil
+}
+
+// resolveAppointmentItems validates the side area selection against the published catalog and the
+// clinic's offering, and prices it. The total is nil when any selected price is not set.
+func resolveAppointmentItems(db *gorm.DB, clinicID uint64, treatmentID uint, req []reqdto.AppointmentItemRequest) ([]models.AppointmentItem, *float64, error) {
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := catalog.activeTreatment(treatmentID); !ok {
+		return nil, nil, errors.New("treatment is not available")
+	}
+
+	if len(req) == 0 {
+		var sideAreaPrices int64
+		if err := db.Model(&models.ClinicSideArea{}).
+			Where("clinic_id = ? AND treatment_id = ? AND status = ?", clinicID, treatmentID, "active").
+			Count(&sideAreaPrices).Error; err != nil {
+			return nil, nil, err
+		}
+		if sideAreaPrices > 0 {
+			return nil, nil, errors.New("select at least one side area for this treatment")
+		}
+		var offered models.ClinicTreatment
+		err := db.Where("clinic_id = ? AND treatment_id = ? AND status = ?", clinicID, treatmentID, "active").First(&offered).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("clinic does not offer this treatment")
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, offered.Price, nil
+	}
+
+	items := make([]models.AppointmentItem, 0, len(req))
+	seen := make(map[uint]bool, len(req))
+	total := 0.0
+	priced := true
+	for _, r := range req {
+		if seen[r.SideAreaID] {
+			return nil, nil, fmt.Errorf("side area %d is selected more than once", r.SideAreaID)
+		}
+		seen[r.SideAreaID] = true
+
+		sideArea, ok := catalog.activeSideArea(treatmentID, r.SideAreaID)
+		if !ok {
+			return nil, nil, fmt.Errorf("side area %d is not part of this treatment", r.SideAreaID)
+		}
+
+		count := r.SyringeCount
+		if count == 0 {
+			count = sideArea.MinSyringe
+		}
+		if count < 1 || count < sideArea.MinSyringe || (sideArea.MaxSyringe > 0 && count > sideArea.MaxSyringe) {
+			return nil, nil, fmt.Errorf("syringe_count for %s must be between %d and %d", sideArea.Name, sideArea.MinSyringe, sideArea.MaxSyringe)
+		}
+
+		query := db.Where("clinic_id = ? AND treatment_id = ? AND side_area_id = ? AND status = ?", clinicID, treatmentID, r.SideAreaID, "active")
+		if r.SyringeSize != 0 {
+			query = query.Where("syringe_size = ?", r.SyringeSize)
+		}
+		var offers []models.ClinicSideArea
+		if err := query.Order("syringe_size").Find(&offers).Error; err != nil {
+			return nil, nil, err
+		}
+		if len(offers) == 0 {
+			return nil, nil, fmt.Errorf("clinic does not offer %s at this syringe size", sideArea.Name)
+		}
+		if len(offers) > 1 {
+			return nil, nil, fmt.Errorf("syringe_size is required for %s", sideArea.Name)
+		}
+		offer := offers[0]
+
+		if offer.Price == nil {
+			priced = false
+		} else {
+			total += *offer.Price * float64(count)
+		}
+		items = append(items, models.AppointmentItem{
+			AreaID:       sideArea.AreaID,
+			SideAreaID:   sideArea.ID,
+			SyringeCount: count,
+			SyringeSize:  offer.SyringeSize,
+			UnitPrice:    offer.Price,
+		})
+	}
+	if !priced {
+		return items, nil, nil
+	}
+	return items, &total, nil
+}
+
+// validateAppointmentNotes trims notes and enforces the length limit
+func validateAppointmentNotes(field, notes string) (string, error) {
+	notes = strings.TrimSpace(notes)
+	if len(notes) > maxAppointmentNotes {
+		return "", fmt.Errorf("%s must be at most %d characters", field, maxAppointmentNotes)
+	}
+	return notes, nil
+}
+
+// appointmentSlotBlocks lists the lock block starts covering [start, end)
+func appointmentSlotBlocks(start, end time.Time) []time.Time {
+	var blocks []time.Time
+	for t := start.UTC(); t.Before(end); t = t.Add(appointmentBlock) {
+		blocks = append(blocks, t)
+	}
+	return blocks
+}
+
+// insertSlotLocks claims every block from the appointment start to end for one resource.
+// A unique index violation means another active appointment holds an overlapping block.
+func insertSlotLocks(tx *gorm.DB, resourceKey string, appt *models.Appointment, end time.Time, conflict error) error {
+	blocks := appointmentSlotBlocks(appt.StartAt, end)
+	locks := make([]models.AppointmentSlotLock, 0, len(blocks))
+	for _, b := range blocks {
+		locks = append(locks, models.AppointmentSlotLock{ResourceKey: resourceKey, BlockStart: b, AppointmentID: appt.ID})
+	}
+	if err := tx.Create(&locks).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return conflict
+		}
+		return err
+	}
+	return nil
+}
+
+// lockAppointmentSlots reserves the appointment's time for its practitioner and required rooms/devices
+// (including the buffer) and for its customer
+func lockAppointmentSlots(tx *gorm.DB, appt *models.Appointment) error {
+	practitionerEnd := appt.EndAt.Add(time.Duration(appt.BufferMinutes) * time.Minute)
+	if err := insertSlotLocks(tx, fmt.Sprintf("practitioner:%d", appt.PractitionerID), appt, practitionerEnd, ErrAppointmentSlotTaken); err != nil {
+		return err
+	}
+	if err := insertSlotLocks(tx, fmt.Sprintf("customer:%d", appt.UserID), appt, appt.EndAt, ErrAppointmentCustomerBusy); err != nil {
+		return err
+	}
+	return claimAppointmentResources(tx, appt)
+}
+
+// releaseAppointmentSlots frees every block and resource unit held by the appointment
+func releaseAppointmentSlots(tx *gorm.DB, appointmentID uint64) error {
+	if err := tx.Where("appointment_id = ?", appointmentID).Delete(&models.AppointmentResource{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("appointment_id = ?", appointmentID).Delete(&models.AppointmentSlotLock{}).Error
+}
+
+// createAppointment validates a new booking and stores it with its slot locks in one transaction,
+// making the customer a patient of the clinic. Customer bookings must fall inside the practitioner's
+// availability; staff may book outside it.
+func createAppointment(db *gorm.DB, appt *models.Appointment, items []reqdto.AppointmentItemRequest, durationMinutes int, checkAvailability bool) error {
+	if err := checkBookableCustomer(db, appt.UserID); err != nil {
+		return err
+	}
+	clinic, err := checkBookableClinic(db, appt.ClinicID)
+	if err != nil {
+		return err
+	}
+	if err := checkBookablePractitioner(db, appt.ClinicID, appt.PractitionerID); err != nil {
+		return err
+	}
+	resolved, price, err := resolveAppointmentItems(db, appt.ClinicID, appt.TreatmentID, items)
+	if err != nil {
+		return err
+	}
+	if err := checkPractitionerQualified(db, appt.ClinicID, appt.PractitionerID, appt.TreatmentID, resolved); err != nil {
+		return err
+	}
+	duration, err := appointmentDuration(db, appt.ClinicID, appt.TreatmentID, resolved, durationMinutes)
+	if err != nil {
+		return err
+	}
+	start, err := validateAppointmentStart(appt.StartAt)
+	if err != nil {
+		return err
+	}
+
+	if checkAvailability {
+		if err := checkWithinAvailability(db, clinic, appt.PractitionerID, start, start.Add(duration)); err != nil {
+			return err
+		}
+	}
+
+	appt.StartAt = start
+	appt.EndAt = start.Add(duration)
+	appt.BufferMinutes = clinic.BookingBufferMinutes
+	appt.Price = price
+	appt.Items = resolved
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(appt).Error; err != nil {
+			return err
+		}
+		if err := lockAppointmentSlots(tx, appt); err != nil {
+			return err
+		}
+		if err := ensureClinicPatient(tx, appt.ClinicID, appt.UserID, appt.CreatedByType == models.AuditActorCustomer); err != nil {
+			return err
+		}
+		return applyCancellationPolicy(tx, appt, clinic.Currency)
+	})
+}
+
+// loadAppointment fetches an appointment with its items; non-zero clinicID/userID scope the lookup
+func loadAppointment(db *gorm.DB, clinicID, userID, id uint64) (*models.Appointment, error) {
+	query := db.Preload("Items").Preload("Resources")
+	if clinicID != 0 {
+		query = query.Where("clinic_id = ?", clinicID)
+	}
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	var appt models.Appointment
+	if err := query.First(&appt, id).Error; err != nil {
+		return nil, err
+	}
+	return &appt, nil
+}
+
+// appointmentNextStatuses lists the statuses the viewer may move the appointment to
+func appointmentNextStatuses(appt *models.Appointment, viewer string) []string {
+	now := time.Now()
+	next := []string{}
+	for _, status := range models.AppointmentTransitions[appt.Status] {
+		if status == models.AppointmentNoShow && now.Before(appt.StartAt) {
+			continue
+		}
+		if viewer == models.AuditActorCustomer && (status != models.AppointmentCancelled || !now.Before(appt.StartAt)) {
+			continue
+		}
+		next = append(next, status)
+	}
+	return next
+}
+
+// appointmentDTOs adds clinic, customer, practitioner and catalog names to appointments.
+// Clinic notes are left out for customers.
+func appointmentDTOs(db *gorm.DB, appts []models.Appointment, viewer string) ([]resdto.AppointmentDTO, error) {
+	out := make([]resdto.AppointmentDTO, 0, len(appts))
+	if len(appts) == 0 {
+		return out, nil
+	}
+
+	var clinicIDs, userIDs, practitionerIDs []uint64
+	var treatmentIDs, sideAreaIDs []uint
+	var resourceIDs []uint64
+	for _, a := range appts {
+		clinicIDs = append(clinicIDs, a.ClinicID)
+		userIDs = append(userIDs, a.UserID)
+		practitionerIDs = append(practitionerIDs, a.PractitionerID)
+		treatmentIDs = append(treatmentIDs, a.TreatmentID)
+		for _, item := range a.Items {
+			sideAreaIDs = append(sideAreaIDs, item.SideAreaID)
+		}
+		for _, r := range a.Resources {
+			resourceIDs = append(resourceIDs, r.ClinicResourceID)
+		}
+	}
+
+	var clinics []models.Clinic
+	if err := db.Unscoped().Select("id", "name", "timezone", "currency").Where("id IN ?", clinicIDs).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	clinicByID := make(map[uint64]models.Clinic, len(clinics))
+	for _, c := range clinics {
+		clinicByID[c.ID] = c
+	}
+
+	var users []models.User
+	if err := db.Unscoped().Select("id", "primary_email").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	emailByUser := make(map[uint64]string, len(users))
+	for _, u := range users {
+		if u.PrimaryEmail != nil {
+			emailByUser[u.ID] = *u.PrimaryEmail
+		}
+	}
+
+	var profiles []models.UserProfile
+	if err := db.Select("user_id", "name").Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	nameByUser := make(map[uint64]string, len(profiles))
+	for _, p := range profiles {
+		nameByUser[p.UserID] = p.Name
+	}
+
+	var practitioners []models.ClinicUser
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", practitionerIDs).Find(&practitioners).Error; err != nil {
+		return nil, err
+	}
+	practitionerByID := make(map[uint64]string, len(practitioners))
+	for _, p := range practitioners {
+		practitionerByID[p.ID] = p.Name
+	}
+
+	var treatments []models.Treatment
+	if err := db.Select("id", "name").Where("id IN ?", treatmentIDs).Find(&treatments).Error; err != nil {
+		return nil, err
+	}
+	treatmentByID := make(map[uint]string, len(treatments))
+	for _, t := range treatments {
+		treatmentByID[t.ID] = t.Name
+	}
+
+	sideAreaByID := make(map[uint]models.SideArea)
+	if len(sideAreaIDs) > 0 {
+		var sideAreas []models.SideArea
+		if err := db.Preload("Area").Where("id IN ?", sideAreaIDs).Find(&sideAreas).Error; err != nil {
+			return nil, err
+		}
+		for _, s := range sideAreas {
+			sideAreaByID[s.ID] = s
+		}
+	}
+
+	resourceByID := make(map[uint64]models.ClinicResource)
+	if len(resourceIDs) > 0 {
+		var resources []models.ClinicResource
+		if err := db.Where("id IN ?", resourceIDs).Find(&resources).Error; err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			resourceByID[r.ID] = r
+		}
+	}
+
+	apptIDs := make([]uint64, 0, len(appts))
+	for _, a := range appts {
+		apptIDs = append(apptIDs, a.ID)
+	}
+	var policies []models.AppointmentPolicy
+	if err := db.Where("appointment_id IN ?", apptIDs).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	policyByAppt := make(map[uint64]*models.AppointmentPolicy, len(policies))
+	for i, p := range policies {
+		policyByAppt[p.AppointmentID] = &policies[i]
+	}
+	var charges []models.CustomerCharge
+	if err := db.Where("appointment_id IN ?", apptIDs).Order("id").Find(&charges).Error; err != nil {
+		return nil, err
+	}
+	chargesByAppt := make(map[uint64][]models.CustomerCharge)
+	for _, c := range charges {
+		chargesByAppt[c.AppointmentID] = append(chargesByAppt[c.AppointmentID], c)
+	}
+
+	now := time.Now()
+	for _, a := range appts {
+		clinic := clinicByID[a.ClinicID]
+		dto := resdto.AppointmentDTO{
+			Appointment:      a,
+			ClinicName:       clinic.Name,
+			Timezone:         clinic.Timezone,
+			Currency:         clinic.Currency,
+			CustomerName:     nameByUser[a.UserID],
+			CustomerEmail:    emailByUser[a.UserID],
+			PractitionerName: practitionerByID[a.PractitionerID],
+			TreatmentName:    treatmentByID[a.TreatmentID],
+			Items:            make([]resdto.AppointmentItemDTO, 0, len(a.Items)),
+			Resources:        make([]resdto.AppointmentResourceDTO, 0, len(a.Resources)),
+			NextStatuses:     appointmentNextStatuses(&a, viewer),
+			Policy:           policyByAppt[a.ID],
+			Charges:          chargesByAppt[a.ID],
+		}
+		dto.LateCancelFeeApplies = now.Before(a.StartAt) && lateCancelFeeApplies(&a, dto.Policy, now)
+		dto.Appointment.Items = nil
+		dto.Appointment.Resources = nil
+		if viewer == models.AuditActorCustomer {
+			dto.ClinicNotes = ""
+		}
+		for _, item := range a.Items {
+			sideArea := sideAreaByID[item.SideAreaID]
+			dto.Items = append(dto.Items, resdto.AppointmentItemDTO{
+				AppointmentItem: item,
+				AreaName:        sideArea.Area.Name,
+				SideAreaName:    sideArea.Name,
+			})
+		}
+		for _, r := range a.Resources {
+			resource := resourceByID[r.ClinicResourceID]
+			dto.Resources = append(dto.Resources, resdto.AppointmentResourceDTO{
+				ClinicResourceID: r.ClinicResourceID,
+				Name:             resource.Name,
+				Kind:             resource.Kind,
+				ResourceType:     resource.ResourceType,
+				Unit:             r.Unit,
+			})
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+// appointmentDTO reloads an appointment and builds its DTO for the viewer
+func appointmentDTO(db *gorm.DB, id uint64, viewer string) (*resdto.AppointmentDTO, error) {
+	appt, err := loadAppointment(db, 0, 0, id)
+	if err != nil {
+		return nil, err
+	}
+	dtos, err := appointmentDTOs(db, []models.Appointment{*appt}, viewer)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// formatAppointmentTime renders an appointment start in the clinic's timezone for customer messages
+func formatAppointmentTime(start time.Time, timezone string) string {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return start.In(loc).Format("Mon, 02 Jan 2006 15:04 MST")
+}
+
+// notifyAppointmentCustomer emails the customer about the appointment with a calendar attachment; failures are only logged
+func notifyAppointmentCustomer(id uint64, status, note string) {
+	dto, err := appointmentDTO(config.DB, id, models.AuditActorCustomer)
+	if err != nil {
+		log.Printf("appointment %d: failed to load for %s email: %v", id, status, err)
+		return
+	}
+	if dto.CustomerEmail == "" {
+		return
+	}
+	name := dto.CustomerName
+	if name == "" {
+		name = "there"
+	}
+	when := formatAppointmentTime(dto.StartAt, dto.Timezone)
+	ics, err := appointmentICS(config.DB, dto)
+	if err != nil {
+		log.Printf("appointment %d: failed to build calendar attachment: %v", id, err)
+	}
+	if err := utils.SendAppointmentStatusEmail(dto.CustomerEmail, name, dto.ClinicName, dto.TreatmentName, dto.PractitionerName, when, id, status, note, ics); err != nil {
+		log.Printf("appointment %d: failed to send %s email: %v", id, status, err)
+	}
+}
+
+// BookAppointment creates a customer's appointment request; the clinic confirms it later
+func BookAppointment(userID uint64, req reqdto.CreateAppointmentRequest) (*resdto.AppointmentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	notes, err := validateAppointmentNotes("notes", req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	appt := models.Appointment{
+		ClinicID:       req.ClinicID,
+		UserID:         userID,
+		PractitionerID: req.PractitionerID,
+		TreatmentID:    req.TreatmentID,
+		StartAt:        req.StartAt,
+		Status:         models.AppointmentRequested,
+		CustomerNotes:  notes,
+		CreatedByType:  models.AuditActorCustomer,
+		CreatedByID:    userID,
+	}
+	if err := createAppointment(db, &appt, req.Items, 0, true); err != nil {
+		return nil, err
+	}
+	if req.ShareProfile {
+		if _, err := setPatientConsent(db, userID, appt.ClinicID, true); err != nil {
+			log.Printf("appointment %d: failed to record profile sharing: %v", appt.ID, err)
+		}
+	}
+	processAppointmentCharges(db, appt.ID)
+	notifyAppointmentCustomer(appt.ID, models.AppointmentRequested, "")
+	return appointmentDTO(db, appt.ID, models.AuditActorCustomer)
+}
+
+// ClinicCreateAppointment books a confirmed appointment for one of the clinic's patients from the clinic side
+func ClinicCreateAppointment(clinicID, clinicUserID uint64, req reqdto.ClinicCreateAppointmentRequest) (*resdto.AppointmentDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	customerNotes, err := validateAppointmentNotes("customer_notes", req.CustomerNotes)
+	if err != nil {
+		return nil, err
+	}
+	clinicNotes, err := validateAppointmentNotes("clinic_notes", req.ClinicNotes)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := clinicPatientUserID(clinicID, req.PatientID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	appt := models.Appointment{
+		ClinicID:       clinicID,
+		UserID:         userID,
+		PractitionerID: req.PractitionerID,
+		TreatmentID:    req.TreatmentID,
+		StartAt:        req.StartAt,
+		Status:         models.AppointmentConfirmed,
+		CustomerNotes:  customerNotes,
+		ClinicNotes:    clinicNotes,
+		CreatedByType:  models.AuditActorClinicUser,
+		CreatedByID:    clinicUserID,
+		ConfirmedAt:    &now,
+	}
+	if err := createAppointment(db, &appt, req.Items, req.DurationMinutes, false); err != nil {
+		return nil, err
+	}
+	processAppointmentCharges(db, appt.ID)
+	notifyAppointmentCustomer(appt.ID, models.AppointmentConfirmed, "")
+	return appointmentDTO(db, appt.ID, models.AuditActorClinicUser)
+}
+
+// ListAppointments returns a page of appointments ordered by start time
+func ListAppointments(f AppointmentFilter, viewer string, page, pageSize int) (*resdto.AppointmentListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	// Clinic listings use the clinic's scoped handle; customer and admin listings span clinics
+	if f.ClinicID != 0 {
+		db = config.ClinicDB(f.ClinicID)
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.Appointment{})
+	if f.UserID != 0 {
+		query = query.Where("user_id = ?", f.UserID)
+	}
+	if f.PractitionerID != 0 {
+		query = query.Where("practitioner_id = ?", f.PractitionerID)
+	}
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
+	}
+	if f.From != nil {
+		query = query.Where("start_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("start_at < ?", *f.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var appts []models.Appointment
+	if err := query.Preload("Items").Preload("Resources").Order("start_at ASC, id ASC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&appts).Error; err != nil {
+		return nil, err
+	}
+	items, err := appointmentDTOs(db, appts, viewer)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.AppointmentListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// GetAppointment returns one appointment; non-zero clinicID/userID restrict it to that clinic or customer
+func GetAppointment(clinicID, userID, id uint64, viewer string) (*resdto.AppointmentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	appt, err := loadAppointment(db, clinicID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	dtos, err := appointmentDTOs(db, []models.Appointment{*appt}, viewer)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// changeAppointmentStatus moves the appointment along the workflow, releasing its slots when cancelled
+func changeAppointmentStatus(db *gorm.DB, appt *models.Appointment, status, actorType, reason string) error {
+	allowed := false
+	for _, next := range models.AppointmentTransitions[appt.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s -> %s", ErrAppointmentStatusChange, appt.Status, status)
+	}
+
+	now := time.Now().UTC()
+	updates := map[string]interface{}{"status": status}
+	switch status {
+	case models.AppointmentConfirmed:
+		updates["confirmed_at"] = now
+	case models.AppointmentCheckedIn:
+		updates["checked_in_at"] = now
+	case models.AppointmentCompleted:
+		updates["completed_at"] = now
+	case models.AppointmentNoShow:
+		if now.Before(appt.StartAt) {
+			return fmt.Errorf("%w: a no-show can only be recorded after the start time", ErrAppointmentStatusChange)
+		}
+		updates["no_show_at"] = now
+	case models.AppointmentCancelled:
+		reason, err := validateAppointmentNotes("reason", reason)
+		if err != nil {
+			return err
+		}
+		updates["cancelled_at"] = now
+		updates["cancel_reason"] = reason
+		updates["cancelled_by_type"] = actorType
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Guard on the current status so concurrent changes cannot both apply
+		res := tx.Model(&models.Appointment{}).Where("id = ? AND status = ?", appt.ID, appt.Status).Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAppointmentStatusChange
+		}
+		if err := assessAppointmentFees(tx, appt, status, actorType, now); err != nil {
+			return err
+		}
+		if status == models.AppointmentCancelled {
+			return releaseAppointmentSlots(tx, appt.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if status == models.AppointmentCancelled || status == models.AppointmentNoShow {
+		processAppointmentCharges(db, appt.ID)
+	}
+	if status == models.AppointmentCancelled {
+		// The freed time goes to the practitioner's waitlist
+		offerWaitlistOpening(db, appt.ClinicID, appt.PractitionerID, appt.StartAt, appt.EndAt)
+	}
+	return nil
+}
+
+// UpdateAppointmentStatus changes an appointment's status for clinic staff or admins (clinicID 0)
+func UpdateAppointmentStatus(clinicID, id uint64, actorType, status, reason string) (*resdto.AppointmentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	appt, err := loadAppointment(db, clinicID, 0, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := changeAppointmentStatus(db, appt, status, actorType, reason); err != nil {
+		return nil, err
+	}
+	if status == models.AppointmentConfirmed || status == models.AppointmentCancelled {
+		notifyAppointmentCustomer(id, status, strings.TrimSpace(reason))
+	}
+	return appointmentDTO(db, id, actorType)
+}
+
+// CancelCustomerAppointment cancels the customer's own appointment before it starts
+func CancelCustomerAppointment(userID, id uint64, reason string) (*resdto.AppointmentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	appt, err := loadAppointment(db, 0, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(appt.StartAt) {
+		return nil, fmt.Errorf("%w: appointments can only be cancelled before they start", ErrAppointmentStatusChange)
+	}
+	if err := changeAppointmentStatus(db, appt, models.AppointmentCancelled, models.AuditActorCustomer, reason); err != nil {
+		return nil, err
+	}
+	notifyAppointmentCustomer(id, models.AppointmentCancelled, "")
+	return appointmentDTO(db, id, models.AuditActorCustomer)
+}
+
+// RescheduleAppointment moves a requested or confirmed appointment to a new time, length or practitioner,
+// re-claiming its slots in one transaction
+func RescheduleAppointment(clinicID, id uint64, req reqdto.RescheduleAppointmentRequest) (*resdto.AppointmentDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	appt, err := loadAppointment(db, clinicID, 0, id)
+	if err != nil {
+		return nil, err
+	}
+	if appt.Status != models.AppointmentRequested && appt.Status != models.AppointmentConfirmed {
+		return nil, ErrAppointmentNotReschedulable
+	}
+
+	updates := map[string]interface{}{}
+	if req.ClinicNotes != nil {
+		notes, err := validateAppointmentNotes("clinic_notes", *req.ClinicNotes)
+		if err != nil {
+			return nil, err
+		}
+		updates["clinic_notes"] = notes
+	}
+
+	moved := false
+	if req.PractitionerID != nil && *req.PractitionerID != appt.PractitionerID {
+		if err := checkBookablePractitioner(db, clinicID, *req.PractitionerID); err != nil {
+			return nil, err
+		}
+		if err := checkPractitionerQualified(db, clinicID, *req.PractitionerID, appt.TreatmentID, appt.Items); err != nil {
+			return nil, err
+		}
+		appt.PractitionerID = *req.PractitionerID
+		moved = true
+	}
+	start, duration := appt.StartAt, appt.EndAt.Sub(appt.StartAt)
+	if req.StartAt != nil && !req.StartAt.Equal(appt.StartAt) {
+		start = *req.StartAt
+		moved = true
+	}
+	if req.DurationMinutes != nil {
+		d, err := appointmentDuration(db, clinicID, appt.TreatmentID, appt.Items, *req.DurationMinutes)
+		if err != nil {
+			return nil, err
+		}
+		if d != duration {
+			duration = d
+			moved = true
+		}
+	}
+	if moved {
+		if start, err = validateAppointmentStart(start); err != nil {
+			return nil, err
+		}
+		appt.StartAt = start
+		appt.EndAt = start.Add(duration)
+		updates["practitioner_id"] = appt.PractitionerID
+		updates["start_at"] = appt.StartAt
+		updates["end_at"] = appt.EndAt
+		// The customer confirmed the old time; reminders go out again for the new one
+		updates["customer_confirmed_at"] = nil
+	}
+	if len(updates) == 0 {
+		return appointmentDTO(db, id, models.AuditActorClinicUser)
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Appointment{}).Where("id = ? AND status = ?", appt.ID, appt.Status).Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrAppointmentNotReschedulable
+		}
+		if !moved {
+			return nil
+		}
+		if err := tx.Where("appointment_id = ?", appt.ID).Delete(&models.AppointmentReminder{}).Error; err != nil {
+			return err
+		}
+		if err := releaseAppointmentSlots(tx, appt.ID); err != nil {
+			return err
+		}
+		return lockAppointmentSlots(tx, appt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if moved {
+		notifyAppointmentCustomer(id, "rescheduled", "")
+	}
+	return appointmentDTO(db, id, models.AuditActorClinicUser)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"skinSync/models"
+)
+
+func TestChangeAppointmentStatusRejectsInvalidTransitions(t *testing.T) {
+	all := []string{
+		models.AppointmentRequested, models.AppointmentConfirmed, models.AppointmentCheckedIn,
+		models.AppointmentCompleted, models.AppointmentCancelled, models.AppointmentNoShow,
+	}
+	allowed := map[[2]string]bool{
+		{models.AppointmentRequested, models.AppointmentConfirmed}: true,
+		{models.AppointmentRequested, models.AppointmentCancelled}: true,
+		{models.AppointmentConfirmed, models.AppointmentCheckedIn}: true,
+		{models.AppointmentConfirmed, models.AppointmentCancelled}: true,
+		{models.AppointmentConfirmed, models.AppointmentNoShow}:    true,
+		{models.AppointmentCheckedIn, models.AppointmentCompleted}: true,
+	}
+	for _, from := range all {
+		for _, to := range all {
+			ok := false
+			for _, next := range models.AppointmentTransitions[from] {
+				ok = ok || next == to
+			}
+			if ok != allowed[[2]string{from, to}] {
+				t.Errorf("transition %s -> %s allowed = %v, want %v", from, to, ok, !ok)
+			}
+			if ok {
+				continue
+			}
+			// Rejected transitions fail before touching the database
+			appt := &models.Appointment{Status: from}
+			err := changeAppointmentStatus(nil, appt, to, models.AuditActorClinicUser, "")
+			if !errors.Is(err, ErrAppointmentStatusChange) {
+				t.Errorf("changeAppointmentStatus(%s -> %s) error = %v, want ErrAppointmentStatusChange", from, to, err)
+			}
+		}
+	}
+}
+
+func TestChangeAppointmentStatusRejectsEarlyNoShow(t *testing.T) {
+	appt := &models.Appointment{Status: models.AppointmentConfirmed, StartAt: time.Now().Add(time.Hour)}
+	err := changeAppointmentStatus(nil, appt, models.AppointmentNoShow, models.AuditActorClinicUser, "")
+	if !errors.Is(err, ErrAppointmentStatusChange) {
+		t.Errorf("no-show before start error = %v, want ErrAppointmentStatusChange", err)
+	}
+}
+
+func TestAppointmentNextStatuses(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name   string
+		status string
+		start  time.Time
+		viewer string
+		want   []string
+	}{
+		{"clinic requested", models.AppointmentRequested, future, models.AuditActorClinicUser,
+			[]string{models.AppointmentConfirmed, models.AppointmentCancelled}},
+		{"clinic confirmed before start", models.AppointmentConfirmed, future, models.AuditActorClinicUser,
+			[]string{models.AppointmentCheckedIn, models.AppointmentCancelled}},
+		{"clinic confirmed after start", models.AppointmentConfirmed, past, models.AuditActorClinicUser,
+			[]string{models.AppointmentCheckedIn, models.AppointmentCancelled, models.AppointmentNoShow}},
+		{"clinic checked in", models.AppointmentCheckedIn, past, models.AuditActorClinicUser,
+			[]string{models.AppointmentCompleted}},
+		{"clinic completed", models.AppointmentCompleted, past, models.AuditActorClinicUser, []string{}},
+		{"customer before start", models.AppointmentConfirmed, future, models.AuditActorCustomer,
+			[]string{models.AppointmentCancelled}},
+		{"customer after start", models.AppointmentConfirmed, past, models.AuditActorCustomer, []string{}},
+		{"customer checked in", models.AppointmentCheckedIn, past, models.AuditActorCustomer, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appt := &models.Appointment{Status: tt.status, StartAt: tt.start}
+			if got := appointmentNextStatuses(appt, tt.viewer); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appointmentNextStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
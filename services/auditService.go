@@ -0,0 +1,595 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== AUDIT LOG ====================
+
+// AuditActor identifies who made a change, taken from the auth context
+type AuditActor struct {
+	Type     string
+	ID       uint64
+	Email    string
+	ClinicID *uint64
+}
+
+// AuditTarget describes how to identify and snapshot the entity a route changes.
+// The id comes from the route Param, the JSON BodyField, the actor itself when FromActor is set,
+// or the actor's clinic when FromClinic is set.
+type AuditTarget struct {
+	EntityType string
+	Param      string
+	BodyField  string
+	FromActor  bool
+	FromClinic bool
+	Load       func(db *gorm.DB, actor AuditActor, id uint64) (interface{}, error)
+}
+
+// loadModel returns a loader that fetches one row (including soft-deleted rows) of the given model
+func loadModel(newModel func() interface{}) func(*gorm.DB, AuditActor, uint64) (interface{}, error) {
+	return func(db *gorm.DB, _ AuditActor, id uint64) (interface{}, error) {
+		m := newModel()
+		if err := db.Unscoped().First(m, id).Error; err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+// loadClinicModel is loadModel restricted to rows of the actor's clinic
+func loadClinicModel(newModel func() interface{}) func(*gorm.DB, AuditActor, uint64) (interface{}, error) {
+	return func(_ *gorm.DB, actor AuditActor, id uint64) (interface{}, error) {
+		if actor.ClinicID == nil {
+			return nil, errors.New("clinic not in context")
+		}
+		m := newModel()
+		if err := config.ClinicDB(*actor.ClinicID).First(m, id).Error; err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+}
+
+// loadTranslationsFor returns a loader for the translations of one translatable entity
+func loadTranslationsFor(entityType string) func(*gorm.DB, AuditActor, uint64) (interface{}, error) {
+	return func(_ *gorm.DB, _ AuditActor, id uint64) (interface{}, error) {
+		return GetEntityTranslations(entityType, id)
+	}
+}
+
+// clinicTreatmentPrices is the audited state of one treatment's prices at a clinic
+type clinicTreatmentPrices struct {
+	Treatment *models.ClinicTreatment `json:"treatment,omitempty"`
+	SideAreas []models.ClinicSideArea `json:"side_areas"`
+}
+
+// loadClinicTreatmentPrices snapshots a clinic's treatment price and side-area prices
+func loadClinicTreatmentPrices(_ *gorm.DB, actor AuditActor, treatmentID uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	db := config.ClinicDB(*actor.ClinicID)
+	prices := clinicTreatmentPrices{SideAreas: []models.ClinicSideArea{}}
+
+	var ct models.ClinicTreatment
+	if err := db.Where("treatment_id = ?", treatmentID).First(&ct).Error; err == nil {
+		prices.Treatment = &ct
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := db.Where("treatment_id = ?", treatmentID).Order("side_area_id, syringe_size").
+		Find(&prices.SideAreas).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// loadOnboardingAnswers snapshots a customer's onboarding answers
+func loadOnboardingAnswers(db *gorm.DB, _ AuditActor, userID uint64) (interface{}, error) {
+	var answers []models.SkinConditionQuestionAnswer
+	if err := db.Where("user_id = ?", userID).Order("question_id, option_id").Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]uint64, 0, len(answers))
+	for _, a := range answers {
+		rows = append(rows, map[string]uint64{"question_id": a.QuestionID, "option_id": a.OptionID})
+	}
+	return map[string]interface{}{"answers": rows}, nil
+}
+
+// loadClinicUserProfile snapshots a clinic user's name and practitioner profile
+func loadClinicUserProfile(_ *gorm.DB, actor AuditActor, clinicUserID uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	db := config.ClinicDB(*actor.ClinicID)
+	var user models.ClinicUser
+	if err := db.First(&user, clinicUserID).Error; err != nil {
+		return nil, err
+	}
+	snapshot := map[string]interface{}{"name": user.Name}
+	var profile models.ClinicUserProfile
+	if err := db.Where("clinic_user_id = ?", clinicUserID).First(&profile).Error; err == nil {
+		snapshot["profile"] = profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// loadTreatmentRequirements snapshots a treatment's room and equipment requirements
+func loadTreatmentRequirements(db *gorm.DB, _ AuditActor, treatmentID uint64) (interface{}, error) {
+	requirements := []models.TreatmentResourceRequirement{}
+	if err := db.Where("treatment_id = ?", treatmentID).Order("side_area_id, id").Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+// loadClinicTreatmentDurations snapshots a clinic's duration overrides for a treatment
+func loadClinicTreatmentDurations(db *gorm.DB, actor AuditActor, treatmentID uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	return clinicTreatmentDurations(db, *actor.ClinicID, uint(treatmentID))
+}
+
+// loadClinicTreatmentRequirements snapshots a clinic's requirement adjustments for a treatment
+func loadClinicTreatmentRequirements(db *gorm.DB, actor AuditActor, treatmentID uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	adjustments := []models.ClinicTreatmentRequirement{}
+	if err := db.Where("clinic_id = ? AND treatment_id = ?", *actor.ClinicID, treatmentID).Order("side_area_id, id").Find(&adjustments).Error; err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
+
+// loadClinicOpeningHours snapshots a clinic's weekly opening hours
+func loadClinicOpeningHours(db *gorm.DB, _ AuditActor, clinicID uint64) (interface{}, error) {
+	hours := []models.ClinicOpeningHour{}
+	if err := db.Where("clinic_id = ?", clinicID).Order("weekday, start_time").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// loadClinicReminderRules snapshots a clinic's reminder rules
+func loadClinicReminderRules(db *gorm.DB, _ AuditActor, clinicID uint64) (interface{}, error) {
+	rules := []models.ClinicReminderRule{}
+	if err := db.Where("clinic_id = ?", clinicID).Order("offset_minutes DESC, channel").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// loadClinicCancellationPolicy snapshots a clinic's policy for one treatment (0 for the clinic default)
+func loadClinicCancellationPolicy(clinicDefault bool) func(*gorm.DB, AuditActor, uint64) (interface{}, error) {
+	return func(db *gorm.DB, actor AuditActor, id uint64) (interface{}, error) {
+		if actor.ClinicID == nil {
+			return nil, errors.New("clinic not in context")
+		}
+		treatmentID := id
+		if clinicDefault {
+			treatmentID = 0
+		}
+		var policy models.ClinicCancellationPolicy
+		if err := db.Where("clinic_id = ? AND treatment_id = ?", *actor.ClinicID, treatmentID).First(&policy).Error; err != nil {
+			return nil, err
+		}
+		return policy, nil
+	}
+}
+
+// loadPractitionerAvailability snapshots a practitioner's weekly hours and exceptions
+func loadPractitionerAvailability(_ *gorm.DB, actor AuditActor, clinicUserID uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	db := config.ClinicDB(*actor.ClinicID)
+	weekly := []models.PractitionerAvailability{}
+	if err := db.Where("clinic_user_id = ?", clinicUserID).Order("weekday, start_time").Find(&weekly).Error; err != nil {
+		return nil, err
+	}
+	exceptions := []models.PractitionerAvailabilityException{}
+	if err := db.Where("clinic_user_id = ?", clinicUserID).Order("date, start_time").Find(&exceptions).Error; err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"weekly": weekly, "exceptions": exceptions}, nil
+}
+
+// loadUserProfile snapshots a customer's profile
+func loadUserProfile(db *gorm.DB, _ AuditActor, userID uint64) (interface{}, error) {
+	var profile models.UserProfile
+	if err := db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// loadAuditTreatmentRecord snapshots a treatment record with its items and addenda
+func loadAuditTreatmentRecord(_ *gorm.DB, actor AuditActor, id uint64) (interface{}, error) {
+	if actor.ClinicID == nil {
+		return nil, errors.New("clinic not in context")
+	}
+	return loadTreatmentRecord(config.ClinicDB(*actor.ClinicID), id)
+}
+
+// loadPatientConsent snapshots whether the customer shares their profile with a clinic
+func loadPatientConsent(db *gorm.DB, actor AuditActor, clinicID uint64) (interface{}, error) {
+	var patient models.ClinicPatient
+	if err := db.Select("id", "clinic_id", "user_id", "profile_shared_at").
+		Where("clinic_id = ? AND user_id = ?", clinicID, actor.ID).First(&patient).Error; err != nil {
+		return nil, err
+	}
+	return patient, nil
+}
+
+// auditTargets maps route prefixes to the entity they change. The longest matching prefix wins.
+var auditTargets = map[string]AuditTarget{
+	"/admin/admins/:id":                 {EntityType: "admin_user", Param: "id", Load: loadModel(func() interface{} { return &models.AdminUser{} })},
+	"/admin/users/:id":                  {EntityType: "customer", Param: "id", Load: loadModel(func() interface{} { return &models.User{} })},
+	"/admin/clinics/:id":                {EntityType: "clinic", Param: "id", Load: loadModel(func() interface{} { return &models.Clinic{} })},
+	"/admin/clinic-profile-changes/:id": {EntityType: "clinic_profile_change", Param: "id", Load: loadModel(func() interface{} { return &models.ClinicProfileChange{} })},
+	"/admin/clinic-applications/:id":    {EntityType: "clinic_application", Param: "id", Load: loadModel(func() interface{} { return &models.ClinicApplication{} })},
+	"/admin/appointments/:id":           {EntityType: "appointment", Param: "id", Load: loadModel(func() interface{} { return &models.Appointment{} })},
+	"/admin/treatments/:id":             {EntityType: TranslationEntityTreatment, Param: "id", Load: loadModel(func() interface{} { return &models.Treatment{} })},
+	"/admin/areas/:id":                  {EntityType: TranslationEntityArea, Param: "id", Load: loadModel(func() interface{} { return &models.Area{} })},
+	"/admin/sideareas/:id":              {EntityType: TranslationEntitySideArea, Param: "id", Load: loadModel(func() interface{} { return &models.SideArea{} })},
+
+	"/admin/onboarding/question/:id":                    {EntityType: TranslationEntityQuestion, Param: "id", Load: loadModel(func() interface{} { return &models.SkinConditionQuestion{} })},
+	"/admin/onboarding/question/:qid/options/:optionId": {EntityType: TranslationEntityOption, Param: "optionId", Load: loadModel(func() interface{} { return &models.SkinConditionQuestionOption{} })},
+
+	"/admin/treatments/:id/translations":          {EntityType: "treatment_translations", Param: "id", Load: loadTranslationsFor(TranslationEntityTreatment)},
+	"/admin/treatments/:id/requirements":          {EntityType: "treatment_requirements", Param: "id", Load: loadTreatmentRequirements},
+	"/admin/areas/:id/translations":               {EntityType: "area_translations", Param: "id", Load: loadTranslationsFor(TranslationEntityArea)},
+	"/admin/sideareas/:id/translations":           {EntityType: "side_area_translations", Param: "id", Load: loadTranslationsFor(TranslationEntitySideArea)},
+	"/admin/onboarding/question/:id/translations": {EntityType: "question_translations", Param: "id", Load: loadTranslationsFor(TranslationEntityQuestion)},
+	"/admin/onboarding/options/:id/translations":  {EntityType: "option_translations", Param: "id", Load: loadTranslationsFor(TranslationEntityOption)},
+
+	"/clinic/users/:id":           {EntityType: "clinic_user", Param: "id", Load: loadClinicModel(func() interface{} { return &models.ClinicUser{} })},
+	"/clinic/profile/me":          {EntityType: "clinic_user_profile", FromActor: true, Load: loadClinicUserProfile},
+	"/clinic/settings":            {EntityType: "clinic", FromClinic: true, Load: loadModel(func() interface{} { return &models.Clinic{} })},
+	"/clinic/side-areas/bulk":     {EntityType: "clinic_treatment_prices", BodyField: "treatment_id", Load: loadClinicTreatmentPrices},
+	"/clinic/appointments/:id":    {EntityType: "appointment", Param: "id", Load: loadClinicModel(func() interface{} { return &models.Appointment{} })},
+	"/clinic/opening-hours":       {EntityType: "clinic_opening_hours", FromClinic: true, Load: loadClinicOpeningHours},
+	"/clinic/holidays/:id":        {EntityType: "clinic_holiday", Param: "id", Load: loadClinicModel(func() interface{} { return &models.ClinicHoliday{} })},
+	"/clinic/reminders":           {EntityType: "clinic_reminder_rules", FromClinic: true, Load: loadClinicReminderRules},
+	"/clinic/cancellation-policy": {EntityType: "clinic_cancellation_policy", FromClinic: true, Load: loadClinicCancellationPolicy(true)},
+	"/clinic/charges/:id":         {EntityType: "customer_charge", Param: "id", Load: loadClinicModel(func() interface{} { return &models.CustomerCharge{} })},
+	"/clinic/calendar-feeds/:id":  {EntityType: "calendar_feed", Param: "id", Load: loadClinicModel(func() interface{} { return &models.CalendarFeed{} })},
+	"/clinic/patients/:id":        {EntityType: "clinic_patient", Param: "id", Load: loadClinicModel(func() interface{} { return &models.ClinicPatient{} })},
+	"/clinic/treatment-records":   {EntityType: "treatment_record", Param: "id", Load: loadAuditTreatmentRecord},
+
+	"/clinic/practitioners/:id/availability":              {EntityType: "practitioner_availability", Param: "id", Load: loadPractitionerAvailability},
+	"/clinic/profile/me/availability":                     {EntityType: "practitioner_availability", FromActor: true, Load: loadPractitionerAvailability},
+	"/clinic/profile/me/calendar-feeds":                   {EntityType: "calendar_feed", Param: "id", Load: loadClinicModel(func() interface{} { return &models.CalendarFeed{} })},
+	"/clinic/treatments/:treatmentId/durations":           {EntityType: "clinic_treatment_durations", Param: "treatmentId", Load: loadClinicTreatmentDurations},
+	"/clinic/treatments/:treatmentId/requirements":        {EntityType: "clinic_treatment_requirements", Param: "treatmentId", Load: loadClinicTreatmentRequirements},
+	"/clinic/treatments/:treatmentId/cancellation-policy": {EntityType: "clinic_cancellation_policy", Param: "treatmentId", Load: loadClinicCancellationPolicy(false)},
+	"/clinic/resources/:id":                               {EntityType: "clinic_resource", Param: "id", Load: loadClinicModel(func() interface{} { return &models.ClinicResource{} })},
+
+	"/v1/onboarding/answer":  {EntityType: "onboarding_answers", FromActor: true, Load: loadOnboardingAnswers},
+	"/v1/onboarding/profile": {EntityType: "user_profile", FromActor: true, Load: loadUserProfile},
+	"/v1/appointments/:id":   {EntityType: "appointment", Param: "id", Load: loadModel(func() interface{} { return &models.Appointment{} })},
+	"/v1/waitlist/:id":       {EntityType: "waitlist_entry", Param: "id", Load: loadModel(func() interface{} { return &models.WaitlistEntry{} })},
+	"/v1/calendar-feeds":     {EntityType: "calendar_feed", Param: "id", Load: loadModel(func() interface{} { return &models.CalendarFeed{} })},
+	"/v1/patient-consents":   {EntityType: "patient_consent", Param: "clinicId", Load: loadPatientConsent},
+}
+
+// auditSkippedRoutes are mutating routes that change no business data
+var auditSkippedRoutes = map[string]bool{
+	"/v1/auth/refresh": true,
+}
+
+// ShouldAuditRoute reports whether mutating requests to a route are recorded
+func ShouldAuditRoute(route string) bool {
+	return !auditSkippedRoutes[route]
+}
+
+// ResolveAuditTarget finds the registered target for a route by longest prefix
+func ResolveAuditTarget(route string) (AuditTarget, bool) {
+	best := ""
+	for prefix := range auditTargets {
+		if (route == prefix || strings.HasPrefix(route, prefix+"/")) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return AuditTarget{}, false
+	}
+	return auditTargets[best], true
+}
+
+// SnapshotAuditEntity loads the current state of an audited entity as a flat JSON object
+func SnapshotAuditEntity(target AuditTarget, actor AuditActor, entityID uint64) map[string]interface{} {
+	db := config.DB
+	if db == nil || target.Load == nil || entityID == 0 {
+		return nil
+	}
+	entity, err := target.Load(db, actor, entityID)
+	if err != nil {
+		return nil
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	snapshot := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// auditIgnoredFields change on every write and would drown real changes
+var auditIgnoredFields = map[string]bool{"updated_at": true, "UpdatedAt": true}
+
+// DiffAuditSnapshots returns only the top-level fields that differ between two snapshots
+func DiffAuditSnapshots(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if before == nil || after == nil {
+		return before, after
+	}
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for k, v := range before {
+		if auditIgnoredFields[k] {
+			continue
+		}
+		if av, ok := after[k]; !ok || !reflect.DeepEqual(v, av) {
+			changedBefore[k] = v
+			changedAfter[k] = after[k]
+		}
+	}
+	for k, v := range after {
+		if _, ok := before[k]; !ok && !auditIgnoredFields[k] {
+			changedAfter[k] = v
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// auditSensitiveKeys are redacted from request payloads before they are stored
+var auditSensitiveKeys = []string{"password", "token", "otp", "secret", "hash"}
+
+// RedactAuditPayload replaces sensitive values in a decoded JSON payload
+func RedactAuditPayload(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			lower := strings.ToLower(k)
+			redacted := false
+			for _, s := range auditSensitiveKeys {
+				if strings.Contains(lower, s) {
+					t[k] = "[redacted]"
+					redacted = true
+					break
+				}
+			}
+			if !redacted {
+				t[k] = RedactAuditPayload(val)
+			}
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = RedactAuditPayload(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// AuditEntityID resolves the target id from a raw string or JSON number
+func AuditEntityID(v interface{}) uint64 {
+	switch t := v.(type) {
+	case float64:
+		if t > 0 {
+			return uint64(t)
+		}
+	case string:
+		id, _ := strconv.ParseUint(t, 10, 64)
+		return id
+	}
+	return 0
+}
+
+// encodeAuditJSON stores nil and empty objects as an empty column
+func encodeAuditJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// AuditEntry is one change to record
+type AuditEntry struct {
+	Actor      AuditActor
+	ClinicID   *uint64
+	Action     string
+	Method     string
+	Route      string
+	EntityType string
+	EntityID   uint64
+	Before     interface{}
+	After      interface{}
+	StatusCode int
+	IPAddress  string
+	UserAgent  string
+}
+
+// RecordAudit appends an entry to the audit log. Entries are never updated or deleted.
+func RecordAudit(entry AuditEntry) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	log := models.AuditLog{
+		ActorType:  entry.Actor.Type,
+		ActorID:    entry.Actor.ID,
+		ActorEmail: entry.Actor.Email,
+		ClinicID:   entry.ClinicID,
+		Action:     entry.Action,
+		Method:     entry.Method,
+		Route:      entry.Route,
+		EntityType: entry.EntityType,
+		Before:     encodeAuditJSON(entry.Before),
+		After:      encodeAuditJSON(entry.After),
+		StatusCode: entry.StatusCode,
+		IPAddress:  entry.IPAddress,
+		UserAgent:  entry.UserAgent,
+	}
+	if log.ClinicID == nil {
+		log.ClinicID = entry.Actor.ClinicID
+	}
+	if entry.EntityID != 0 {
+		log.EntityID = strconv.FormatUint(entry.EntityID, 10)
+		if entry.EntityType == "clinic" && log.ClinicID == nil {
+			clinicID := entry.EntityID
+			log.ClinicID = &clinicID
+		}
+	}
+	if len(log.UserAgent) > 500 {
+		log.UserAgent = log.UserAgent[:500]
+	}
+	return db.Create(&log).Error
+}
+
+// auditSource is who made a change outside the audit middleware and through which entry point:
+// a signed link, a public form or a background job
+type auditSource struct {
+	Actor  AuditActor
+	Method string
+	Route  string
+}
+
+// systemAuditSource is the source of changes made by a background job
+func systemAuditSource(job string) auditSource {
+	return auditSource{Actor: AuditActor{Type: models.AuditActorSystem}, Method: "JOB", Route: job}
+}
+
+// record appends one change to the audit log. Like the middleware, a failure is only logged
+// so the change itself is not undone.
+func (s auditSource) record(action, entityType string, entityID uint64, clinicID *uint64, before, after interface{}) {
+	status := http.StatusOK
+	if action == "create" {
+		status = http.StatusCreated
+	}
+	if s.Method == "JOB" {
+		status = 0
+	}
+	entry := AuditEntry{
+		Actor:      s.Actor,
+		ClinicID:   clinicID,
+		Action:     action,
+		Method:     s.Method,
+		Route:      s.Route,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+		StatusCode: status,
+	}
+	if err := RecordAudit(entry); err != nil {
+		log.Printf("audit: failed to record %s %s %d: %v", action, entityType, entityID, err)
+	}
+}
+
+// AuditLogFilter narrows an audit log query
+type AuditLogFilter struct {
+	ActorType  string
+	ActorID    uint64
+	EntityType string
+	EntityID   string
+	Action     string
+	From       *time.Time
+	To         *time.Time
+}
+
+// applyAuditFilter adds filter conditions to an audit log query
+func applyAuditFilter(query *gorm.DB, f AuditLogFilter) *gorm.DB {
+	if f.ActorType != "" {
+		query = query.Where("actor_type = ?", f.ActorType)
+	}
+	if f.ActorID != 0 {
+		query = query.Where("actor_id = ?", f.ActorID)
+	}
+	if f.EntityType != "" {
+		query = query.Where("entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != "" {
+		query = query.Where("entity_id = ?", f.EntityID)
+	}
+	if f.Action != "" {
+		query = query.Where("action = ?", f.Action)
+	}
+	if f.From != nil {
+		query = query.Where("created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		query = query.Where("created_at < ?", *f.To)
+	}
+	return query
+}
+
+// listAuditLogs runs a filtered, paginated audit log query on db
+func listAuditLogs(db *gorm.DB, f AuditLogFilter, page, pageSize int) (*resdto.AuditLogListResponse, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	query := applyAuditFilter(db.Model(&models.AuditLog{}), f)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	logs := []models.AuditLog{}
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.AuditLogListResponse{
+		Items: logs,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// ListAuditLogs returns platform-wide audit entries
+func ListAuditLogs(f AuditLogFilter, page, pageSize int) (*resdto.AuditLogListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return listAuditLogs(db, f, page, pageSize)
+}
+
+// ListClinicAuditLogs returns audit entries belonging to one clinic
+func ListClinicAuditLogs(clinicID uint64, f AuditLogFilter, page, pageSize int) (*resdto.AuditLogListResponse, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return listAuditLogs(db, f, page, pageSize)
+}
@@ -12,6 +12,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrAccountSuspended is returned when a suspended customer tries to sign in
+var ErrAccountSuspended = errors.New("account is suspended")
+
+// ensureCustomerActive blocks token issuance for suspended customers
+func ensureCustomerActive(user models.User) error {
+	if user.Status == models.UserStatusSuspended {
+		return ErrAccountSuspended
+	}
+	return nil
+}
+
 // Login handles unified login/register flow for providers: email, phone, google, apple
 func Login(r reqdto.LoginRequest) (*resdto.LoginResponse, error) {
 	if r.Provider == "" {
@@ -150,6 +161,10 @@ func Login(r reqdto.LoginRequest) (*resdto.LoginResponse, error) {
 		return nil, errors.New("invalid provider")
 	}
 
+	if err = ensureCustomerActive(user); err != nil {
+		return nil, err
+	}
+
 	// create tokens and persist AuthToken
 	var emailStr string
 	if user.PrimaryEmail != nil {
@@ -201,7 +216,7 @@ func RefreshToken(r reqdto.RefreshRequest) (*resdto.LoginResponse, error) {
 
 	// find a non-expired auth token whose hashed refresh token matches the provided raw token
 	var tokens []models.AuthToken
-	if err := db.Where("refresh_expires_at > ?", time.Now()).Find(&tokens).Error; err != nil {
+	if err := db.Where("refresh_expires_at > ? AND is_revoked = ?", time.Now(), false).Find(&tokens).Error; err != nil {
 		return nil, err
 	}
 
@@ -224,6 +239,9 @@ func RefreshToken(r reqdto.RefreshRequest) (*resdto.LoginResponse, error) {
 	if err := db.First(&user, found.UserID).Error; err != nil {
 		return nil, err
 	}
+	if err := ensureCustomerActive(user); err != nil {
+		return nil, err
+	}
 
 	// generate new tokens
 	var emailStr string
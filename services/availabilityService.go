@@ -0,0 +1,763 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== AVAILABILITY & SLOTS ====================
+
+const (
+	scheduleDateLayout = "2006-01-02"
+	maxSlotDays        = 14
+)
+
+var (
+	// ErrNotPractitioner is returned when a schedule is set for staff who do not perform treatments
+	ErrNotPractitioner = errors.New("staff member is not a doctor or injector")
+	// ErrClinicHolidayExists is returned when the date is already a holiday
+	ErrClinicHolidayExists = errors.New("the clinic already has a holiday on this date")
+	// ErrOutsideAvailability is returned when a customer books outside the practitioner's available hours
+	ErrOutsideAvailability = errors.New("the practitioner is not available at this time")
+)
+
+// timeWindow is a half-open [start, end) interval
+type timeWindow struct {
+	start, end time.Time
+}
+
+// parseClock parses "HH:MM" (00:00 to 24:00) into minutes after midnight
+func parseClock(field, value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	if err != nil || len(value) != 5 {
+		return 0, fmt.Errorf("%s must be HH:MM", field)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseScheduleDate parses a YYYY-MM-DD date
+func parseScheduleDate(value string) (time.Time, error) {
+	d, err := time.Parse(scheduleDateLayout, value)
+	if err != nil {
+		return d, errors.New("date must be YYYY-MM-DD")
+	}
+	return d, nil
+}
+
+// clinicLocation returns the clinic's timezone, falling back to UTC
+func clinicLocation(clinic *models.Clinic) *time.Location {
+	loc, err := time.LoadLocation(clinic.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// clockOn returns the instant at minutes after midnight on the local day
+func clockOn(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
+
+// validateWeeklyPeriods checks weekdays, times and that periods on the same day do not overlap
+func validateWeeklyPeriods(periods []reqdto.WeeklyPeriodRequest) error {
+	type span struct{ start, end int }
+	byDay := make(map[int][]span)
+	for _, p := range periods {
+		if p.Weekday < 0 || p.Weekday > 6 {
+			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		start, err := parseClock("start_time", p.StartTime)
+		if err != nil {
+			return err
+		}
+		end, err := parseClock("end_time", p.EndTime)
+		if err != nil {
+			return err
+		}
+		if start%5 != 0 || end%5 != 0 {
+			return errors.New("times must be on a 5-minute boundary")
+		}
+		if end <= start {
+			return errors.New("end_time must be after start_time")
+		}
+		for _, other := range byDay[p.Weekday] {
+			if start < other.end && other.start < end {
+				return fmt.Errorf("periods on weekday %d overlap", p.Weekday)
+			}
+		}
+		byDay[p.Weekday] = append(byDay[p.Weekday], span{start, end})
+	}
+	return nil
+}
+
+// normalizeWindows sorts windows and merges overlapping or touching ones
+func normalizeWindows(ws []timeWindow) []timeWindow {
+	sort.Slice(ws, func(i, j int) bool { return ws[i].start.Before(ws[j].start) })
+	out := make([]timeWindow, 0, len(ws))
+	for _, w := range ws {
+		if !w.start.Before(w.end) {
+			continue
+		}
+		if n := len(out); n > 0 && !w.start.After(out[n-1].end) {
+			if w.end.After(out[n-1].end) {
+				out[n-1].end = w.end
+			}
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// subtractWindow removes cut from every window
+func subtractWindow(ws []timeWindow, cut timeWindow) []timeWindow {
+	out := make([]timeWindow, 0, len(ws))
+	for _, w := range ws {
+		if !cut.start.Before(w.end) || !w.start.Before(cut.end) {
+			out = append(out, w)
+			continue
+		}
+		if w.start.Before(cut.start) {
+			out = append(out, timeWindow{w.start, cut.start})
+		}
+		if cut.end.Before(w.end) {
+			out = append(out, timeWindow{cut.end, w.end})
+		}
+	}
+	return out
+}
+
+// intersectWindows returns the parts of a covered by b; both must be normalized
+func intersectWindows(a, b []timeWindow) []timeWindow {
+	var out []timeWindow
+	for _, x := range a {
+		for _, y := range b {
+			start, end := x.start, x.end
+			if y.start.After(start) {
+				start = y.start
+			}
+			if y.end.Before(end) {
+				end = y.end
+			}
+			if start.Before(end) {
+				out = append(out, timeWindow{start, end})
+			}
+		}
+	}
+	return normalizeWindows(out)
+}
+
+// clinicSchedule holds the schedule rows needed to compute practitioner windows over a date range
+type clinicSchedule struct {
+	loc             *time.Location
+	openingHours    map[int][]models.ClinicOpeningHour
+	hasOpeningHours bool
+	holidays        map[string]bool
+	weekly          map[uint64]map[int][]models.PractitionerAvailability
+	exceptions      map[uint64]map[string][]models.PractitionerAvailabilityException
+}
+
+// loadClinicSchedule loads opening hours, holidays and the practitioners' availability for [fromDate, toDate]
+func loadClinicSchedule(db *gorm.DB, clinic *models.Clinic, practitionerIDs []uint64, fromDate, toDate string) (*clinicSchedule, error) {
+	s := &clinicSchedule{
+		loc:          clinicLocation(clinic),
+		openingHours: make(map[int][]models.ClinicOpeningHour),
+		holidays:     make(map[string]bool),
+		weekly:       make(map[uint64]map[int][]models.PractitionerAvailability),
+		exceptions:   make(map[uint64]map[string][]models.PractitionerAvailabilityException),
+	}
+
+	var hours []models.ClinicOpeningHour
+	if err := db.Where("clinic_id = ?", clinic.ID).Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	for _, h := range hours {
+		s.openingHours[h.Weekday] = append(s.openingHours[h.Weekday], h)
+	}
+	s.hasOpeningHours = len(hours) > 0
+
+	var holidays []models.ClinicHoliday
+	if err := db.Where("clinic_id = ? AND date BETWEEN ? AND ?", clinic.ID, fromDate, toDate).Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	for _, h := range holidays {
+		s.holidays[h.Date] = true
+	}
+
+	if len(practitionerIDs) == 0 {
+		return s, nil
+	}
+	var weekly []models.PractitionerAvailability
+	if err := db.Where("clinic_id = ? AND clinic_user_id IN ?", clinic.ID, practitionerIDs).Find(&weekly).Error; err != nil {
+		return nil, err
+	}
+	for _, w := range weekly {
+		if s.weekly[w.ClinicUserID] == nil {
+			s.weekly[w.ClinicUserID] = make(map[int][]models.PractitionerAvailability)
+		}
+		s.weekly[w.ClinicUserID][w.Weekday] = append(s.weekly[w.ClinicUserID][w.Weekday], w)
+	}
+
+	var exceptions []models.PractitionerAvailabilityException
+	if err := db.Where("clinic_id = ? AND clinic_user_id IN ? AND date BETWEEN ? AND ?", clinic.ID, practitionerIDs, fromDate, toDate).
+		Find(&exceptions).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range exceptions {
+		if s.exceptions[e.ClinicUserID] == nil {
+			s.exceptions[e.ClinicUserID] = make(map[string][]models.PractitionerAvailabilityException)
+		}
+		s.exceptions[e.ClinicUserID][e.Date] = append(s.exceptions[e.ClinicUserID][e.Date], e)
+	}
+	return s, nil
+}
+
+// clockWindow converts stored "HH:MM" times on a local day into a window
+func clockWindow(day time.Time, start, end string) (timeWindow, bool) {
+	startMin, err1 := parseClock("start_time", start)
+	endMin, err2 := parseClock("end_time", end)
+	if err1 != nil || err2 != nil {
+		return timeWindow{}, false
+	}
+	return timeWindow{clockOn(day, startMin), clockOn(day, endMin)}, true
+}
+
+// practitionerWindows returns when the practitioner can be booked on a local day:
+// weekly hours plus extra hours, minus leave, within the clinic's opening hours and outside holidays
+func (s *clinicSchedule) practitionerWindows(practitionerID uint64, day time.Time) []timeWindow {
+	date := day.Format(scheduleDateLayout)
+	if s.holidays[date] {
+		return nil
+	}
+
+	var windows []timeWindow
+	for _, p := range s.weekly[practitionerID][int(day.Weekday())] {
+		if w, ok := clockWindow(day, p.StartTime, p.EndTime); ok {
+			windows = append(windows, w)
+		}
+	}
+	exceptions := s.exceptions[practitionerID][date]
+	for _, e := range exceptions {
+		if e.Type != models.AvailabilityAvailable {
+			continue
+		}
+		if w, ok := clockWindow(day, e.StartTime, e.EndTime); ok {
+			windows = append(windows, w)
+		}
+	}
+	windows = normalizeWindows(windows)
+	for _, e := range exceptions {
+		if e.Type != models.AvailabilityUnavailable {
+			continue
+		}
+		if e.StartTime == "" {
+			return nil
+		}
+		if w, ok := clockWindow(day, e.StartTime, e.EndTime); ok {
+			windows = subtractWindow(windows, w)
+		}
+	}
+
+	if !s.hasOpeningHours {
+		return windows
+	}
+	var open []timeWindow
+	for _, h := range s.openingHours[int(day.Weekday())] {
+		if w, ok := clockWindow(day, h.StartTime, h.EndTime); ok {
+			open = append(open, w)
+		}
+	}
+	return intersectWindows(windows, normalizeWindows(open))
+}
+
+// checkWithinAvailability ensures [start, end) falls inside one of the practitioner's windows
+func checkWithinAvailability(db *gorm.DB, clinic *models.Clinic, practitionerID uint64, start, end time.Time) error {
+	loc := clinicLocation(clinic)
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	date := day.Format(scheduleDateLayout)
+	schedule, err := loadClinicSchedule(db, clinic, []uint64{practitionerID}, date, date)
+	if err != nil {
+		return err
+	}
+	for _, w := range schedule.practitionerWindows(practitionerID, day) {
+		if !start.Before(w.start) && !end.After(w.end) {
+			return nil
+		}
+	}
+	return ErrOutsideAvailability
+}
+
+// loadPractitioner returns a doctor/injector of the clinic
+func loadPractitioner(clinicID, id uint64) (*models.ClinicUser, error) {
+	user, err := loadClinicStaff(clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isPractitionerRole(user.Role.Name) {
+		return nil, ErrNotPractitioner
+	}
+	return user, nil
+}
+
+// ==================== CLINIC OPENING HOURS & HOLIDAYS ====================
+
+// GetClinicOpeningHours returns the clinic's weekly opening hours
+func GetClinicOpeningHours(clinicID uint64) (*resdto.ClinicOpeningHoursDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var clinic models.Clinic
+	if err := db.Select("id", "timezone").First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	hours := []models.ClinicOpeningHour{}
+	if err := db.Order("weekday, start_time").Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.ClinicOpeningHoursDTO{Timezone: clinic.Timezone, OpeningHours: hours}, nil
+}
+
+// SetClinicOpeningHours replaces the clinic's weekly opening hours
+func SetClinicOpeningHours(clinicID uint64, req reqdto.SetWeeklyScheduleRequest) (*resdto.ClinicOpeningHoursDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateWeeklyPeriods(req.Periods); err != nil {
+		return nil, err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ClinicOpeningHour{}).Error; err != nil {
+			return err
+		}
+		if len(req.Periods) == 0 {
+			return nil
+		}
+		rows := make([]models.ClinicOpeningHour, 0, len(req.Periods))
+		for _, p := range req.Periods {
+			rows = append(rows, models.ClinicOpeningHour{ClinicID: clinicID, Weekday: p.Weekday, StartTime: p.StartTime, EndTime: p.EndTime})
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetClinicOpeningHours(clinicID)
+}
+
+// ListClinicHolidays returns the clinic's holidays from the given date (YYYY-MM-DD, optional)
+func ListClinicHolidays(clinicID uint64, from string) ([]models.ClinicHoliday, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	query := db
+	if from != "" {
+		if _, err := parseScheduleDate(from); err != nil {
+			return nil, err
+		}
+		query = query.Where("date >= ?", from)
+	}
+	holidays := []models.ClinicHoliday{}
+	if err := query.Order("date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// AddClinicHoliday closes the clinic on a date
+func AddClinicHoliday(clinicID uint64, req reqdto.CreateClinicHolidayRequest) (*models.ClinicHoliday, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if _, err := parseScheduleDate(req.Date); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if len(name) > 100 {
+		return nil, errors.New("name must be at most 100 characters")
+	}
+	holiday := models.ClinicHoliday{ClinicID: clinicID, Date: req.Date, Name: name}
+	if err := db.Create(&holiday).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrClinicHolidayExists
+		}
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// DeleteClinicHoliday removes one of the clinic's holidays
+func DeleteClinicHoliday(clinicID, id uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	res := db.Delete(&models.ClinicHoliday{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ==================== PRACTITIONER AVAILABILITY ====================
+
+// GetPractitionerAvailability returns a practitioner's weekly hours and exceptions from today on
+func GetPractitionerAvailability(clinicID, practitionerID uint64) (*resdto.PractitionerAvailabilityDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	user, err := loadPractitioner(clinicID, practitionerID)
+	if err != nil {
+		return nil, err
+	}
+	var clinic models.Clinic
+	if err := db.Select("id", "timezone").First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	today := time.Now().In(clinicLocation(&clinic)).Format(scheduleDateLayout)
+
+	dto := &resdto.PractitionerAvailabilityDTO{
+		PractitionerID: user.ID,
+		Name:           user.Name,
+		Timezone:       clinic.Timezone,
+		Weekly:         []models.PractitionerAvailability{},
+		Exceptions:     []models.PractitionerAvailabilityException{},
+	}
+	if err := db.Where("clinic_user_id = ?", practitionerID).
+		Order("weekday, start_time").Find(&dto.Weekly).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("clinic_user_id = ? AND date >= ?", practitionerID, today).
+		Order("date, start_time").Find(&dto.Exceptions).Error; err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
+// SetPractitionerAvailability replaces a practitioner's weekly hours
+func SetPractitionerAvailability(clinicID, practitionerID uint64, req reqdto.SetWeeklyScheduleRequest) (*resdto.PractitionerAvailabilityDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if _, err := loadPractitioner(clinicID, practitionerID); err != nil {
+		return nil, err
+	}
+	if err := validateWeeklyPeriods(req.Periods); err != nil {
+		return nil, err
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("clinic_user_id = ?", practitionerID).
+			Delete(&models.PractitionerAvailability{}).Error; err != nil {
+			return err
+		}
+		if len(req.Periods) == 0 {
+			return nil
+		}
+		rows := make([]models.PractitionerAvailability, 0, len(req.Periods))
+		for _, p := range req.Periods {
+			rows = append(rows, models.PractitionerAvailability{
+				ClinicID: clinicID, ClinicUserID: practitionerID, Weekday: p.Weekday, StartTime: p.StartTime, EndTime: p.EndTime,
+			})
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetPractitionerAvailability(clinicID, practitionerID)
+}
+
+// AddAvailabilityException records leave or extra hours for a practitioner on a date
+func AddAvailabilityException(clinicID, practitionerID uint64, req reqdto.CreateAvailabilityExceptionRequest) (*models.PractitionerAvailabilityException, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if _, err := loadPractitioner(clinicID, practitionerID); err != nil {
+		return nil, err
+	}
+	if _, err := parseScheduleDate(req.Date); err != nil {
+		return nil, err
+	}
+	if req.Type != models.AvailabilityUnavailable && req.Type != models.AvailabilityAvailable {
+		return nil, errors.New("type must be unavailable or available")
+	}
+	wholeDay := req.StartTime == "" && req.EndTime == ""
+	if wholeDay && req.Type == models.AvailabilityAvailable {
+		return nil, errors.New("start_time and end_time are required for extra hours")
+	}
+	if !wholeDay {
+		if err := validateWeeklyPeriods([]reqdto.WeeklyPeriodRequest{{StartTime: req.StartTime, EndTime: req.EndTime}}); err != nil {
+			return nil, err
+		}
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 255 {
+		return nil, errors.New("reason must be at most 255 characters")
+	}
+
+	exception := models.PractitionerAvailabilityException{
+		ClinicID:     clinicID,
+		ClinicUserID: practitionerID,
+		Date:         req.Date,
+		Type:         req.Type,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Reason:       reason,
+	}
+	if err := db.Create(&exception).Error; err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
+// DeleteAvailabilityException removes one of a practitioner's exceptions
+func DeleteAvailabilityException(clinicID, practitionerID, id uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	res := db.Where("clinic_user_id = ?", practitionerID).Delete(&models.PractitionerAvailabilityException{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ==================== SLOT GENERATION ====================
+
+// SlotQuery selects the slots to generate; Date defaults to today in the clinic's timezone.
+// Items size the slots for a side area selection; without them only the treatment's base length applies.
+type SlotQuery struct {
+	TreatmentID    uint
+	PractitionerID uint64
+	Date           string
+	Days           int
+	Items          []reqdto.AppointmentItemRequest
+}
+
+// qualifiedPractitioners returns the clinic's active doctors/injectors assigned the treatment
+func qualifiedPractitioners(db *gorm.DB, clinicID uint64, treatmentID uint, practitionerID uint64) ([]models.ClinicUser, error) {
+	query := db.Joins("Role").
+		Where("clinic_users.clinic_id = ? AND clinic_users.status = ?", clinicID, models.ClinicUserStatusActive).
+		Where("Role.name IN ?", []string{models.ClinicRoleDoctor, models.ClinicRoleInjector}).
+		Where("clinic_users.id IN (?) OR clinic_users.id IN (?)",
+			db.Model(&models.ClinicUserTreatment{}).Select("clinic_user_id").Where("treatment_id = ?", treatmentID),
+			db.Model(&models.ClinicUserSideArea{}).Select("clinic_user_id").Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID))
+	if practitionerID != 0 {
+		query = query.Where("clinic_users.id = ?", practitionerID)
+	}
+	var users []models.ClinicUser
+	if err := query.Order("clinic_users.name").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// slotStarts returns the start times of slots of the given duration inside a window on a local day.
+// Starts are wall-clock multiples of the interval from local midnight, so they stay on the same
+// clock times on days when daylight saving time begins or ends; times skipped by the change are left out.
+func slotStarts(day time.Time, w timeWindow, interval, duration time.Duration) []time.Time {
+	step := int(interval / time.Minute)
+	if step <= 0 {
+		return nil
+	}
+	local := w.start.In(day.Location())
+	minutes := local.Hour()*60 + local.Minute()
+	if rem := minutes % step; rem != 0 {
+		minutes += step - rem
+	}
+
+	var starts []time.Time
+	for ; minutes < 24*60; minutes += step {
+		start := clockOn(day, minutes)
+		if start.Hour()*60+start.Minute() != minutes || start.Before(w.start) {
+			continue
+		}
+		if start.Add(duration).After(w.end) {
+			break
+		}
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// ListAvailableSlots generates bookable start times for a treatment at a clinic. Slots follow the clinic's
+// slot interval inside each practitioner's windows and skip times that would overlap an existing booking
+// or its buffer.
+func ListAvailableSlots(clinicID uint64, q SlotQuery) (*resdto.AvailableSlotsResponse, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if q.TreatmentID == 0 {
+		return nil, errors.New("treatment_id is required")
+	}
+	clinic, err := checkBookableClinic(db, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	loc := clinicLocation(clinic)
+	now := time.Now()
+
+	today := now.In(loc)
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+	first := today
+	if q.Date != "" {
+		d, err := parseScheduleDate(q.Date)
+		if err != nil {
+			return nil, err
+		}
+		first = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		if first.Before(today) {
+			return nil, errors.New("date must not be in the past")
+		}
+	}
+	days := q.Days
+	if days < 1 {
+		days = 1
+	}
+	if days > maxSlotDays {
+		return nil, fmt.Errorf("days must be at most %d", maxSlotDays)
+	}
+	last := first.AddDate(0, 0, days-1)
+
+	var items []models.AppointmentItem
+	if len(q.Items) > 0 {
+		if items, _, err = resolveAppointmentItems(db, clinicID, q.TreatmentID, q.Items); err != nil {
+			return nil, err
+		}
+	}
+	estimate, err := estimateAppointmentDuration(db, clinicID, q.TreatmentID, items)
+	if err != nil {
+		return nil, err
+	}
+	duration := time.Duration(estimate.TotalMinutes) * time.Minute
+	buffer := time.Duration(clinic.BookingBufferMinutes) * time.Minute
+	interval := time.Duration(clinic.SlotIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	resp := &resdto.AvailableSlotsResponse{
+		ClinicID:        clinicID,
+		Timezone:        clinic.Timezone,
+		TreatmentID:     q.TreatmentID,
+		DurationMinutes: int(duration / time.Minute),
+		From:            first.Format(scheduleDateLayout),
+		To:              last.Format(scheduleDateLayout),
+		Slots:           []resdto.AvailableSlotDTO{},
+	}
+
+	practitioners, err := qualifiedPractitioners(db, clinicID, q.TreatmentID, q.PractitionerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		qualified := practitioners[:0]
+		for _, p := range practitioners {
+			err := checkPractitionerQualified(db, clinicID, p.ID, q.TreatmentID, items)
+			if errors.Is(err, ErrPractitionerNotQualified) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			qualified = append(qualified, p)
+		}
+		practitioners = qualified
+	}
+	if len(practitioners) == 0 {
+		return resp, nil
+	}
+	ids := make([]uint64, 0, len(practitioners))
+	for _, p := range practitioners {
+		ids = append(ids, p.ID)
+	}
+
+	schedule, err := loadClinicSchedule(db, clinic, ids, resp.From, resp.To)
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing bookings hold the practitioner until their end plus their buffer
+	rangeStart, rangeEnd := first, last.AddDate(0, 0, 1)
+	var booked []models.Appointment
+	if err := db.Select("practitioner_id", "start_at", "end_at", "buffer_minutes").
+		Where("practitioner_id IN ? AND status <> ? AND start_at < ? AND end_at > ?", ids, models.AppointmentCancelled, rangeEnd, rangeStart.Add(-2*time.Hour)).
+		Find(&booked).Error; err != nil {
+		return nil, err
+	}
+	busy := make(map[uint64][]timeWindow)
+	for _, a := range booked {
+		end := a.EndAt.Add(time.Duration(a.BufferMinutes) * time.Minute)
+		busy[a.PractitionerID] = append(busy[a.PractitionerID], timeWindow{a.StartAt, end})
+	}
+
+	// Required rooms and devices must also have free units for the whole slot
+	needs, err := resourceNeeds(db, clinicID, estimate.Requirements)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := loadResourceCalendar(db, clinicID, needs, rangeStart.Add(-2*time.Hour), rangeEnd.Add(duration+buffer))
+	if err != nil {
+		return nil, err
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		for _, p := range practitioners {
+			for _, w := range schedule.practitionerWindows(p.ID, day) {
+				for _, start := range slotStarts(day, w, interval, duration) {
+					if !start.After(now) {
+						continue
+					}
+					slot := timeWindow{start, start.Add(duration + buffer)}
+					free := true
+					for _, b := range busy[p.ID] {
+						if slot.start.Before(b.end) && b.start.Before(slot.end) {
+							free = false
+							break
+						}
+					}
+					if !free || !resources.free(slot) {
+						continue
+					}
+					resp.Slots = append(resp.Slots, resdto.AvailableSlotDTO{
+						StartAt:          start.UTC(),
+						EndAt:            start.Add(duration).UTC(),
+						LocalStart:       start.In(loc).Format("2006-01-02T15:04"),
+						PractitionerID:   p.ID,
+						PractitionerName: p.Name,
+					})
+				}
+			}
+		}
+	}
+	sort.SliceStable(resp.Slots, func(i, j int) bool { return resp.Slots[i].StartAt.Before(resp.Slots[j].StartAt) })
+	return resp, nil
+}
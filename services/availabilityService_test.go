@@ -0,0 +1,133 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// at returns a time on a fixed day at hh:mm UTC
+func at(hh, mm int) time.Time {
+	return time.Date(2026, 3, 2, hh, mm, 0, 0, time.UTC)
+}
+
+func win(fromH, fromM, toH, toM int) timeWindow {
+	return timeWindow{at(fromH, fromM), at(toH, toM)}
+}
+
+func TestNormalizeWindows(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []timeWindow
+		want []timeWindow
+	}{
+		{"empty", nil, []timeWindow{}},
+		{"sorts", []timeWindow{win(13, 0, 14, 0), win(9, 0, 10, 0)}, []timeWindow{win(9, 0, 10, 0), win(13, 0, 14, 0)}},
+		{"merges overlap", []timeWindow{win(9, 0, 11, 0), win(10, 0, 12, 0)}, []timeWindow{win(9, 0, 12, 0)}},
+		{"merges touching", []timeWindow{win(9, 0, 10, 0), win(10, 0, 11, 0)}, []timeWindow{win(9, 0, 11, 0)}},
+		{"keeps contained", []timeWindow{win(9, 0, 17, 0), win(10, 0, 11, 0)}, []timeWindow{win(9, 0, 17, 0)}},
+		{"drops empty and inverted", []timeWindow{win(9, 0, 9, 0), win(12, 0, 11, 0), win(14, 0, 15, 0)}, []timeWindow{win(14, 0, 15, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeWindows(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubtractWindow(t *testing.T) {
+	day := []timeWindow{win(9, 0, 17, 0)}
+	tests := []struct {
+		name string
+		in   []timeWindow
+		cut  timeWindow
+		want []timeWindow
+	}{
+		{"before", day, win(7, 0, 8, 0), day},
+		{"touching end", day, win(17, 0, 18, 0), day},
+		{"splits", day, win(12, 0, 13, 0), []timeWindow{win(9, 0, 12, 0), win(13, 0, 17, 0)}},
+		{"trims start", day, win(8, 0, 10, 0), []timeWindow{win(10, 0, 17, 0)}},
+		{"trims end", day, win(16, 0, 18, 0), []timeWindow{win(9, 0, 16, 0)}},
+		{"covers all", day, win(8, 0, 18, 0), []timeWindow{}},
+		{"several windows", []timeWindow{win(9, 0, 12, 0), win(13, 0, 17, 0)}, win(11, 0, 14, 0),
+			[]timeWindow{win(9, 0, 11, 0), win(14, 0, 17, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subtractWindow(tt.in, tt.cut); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subtractWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectWindows(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []timeWindow
+		want []timeWindow
+	}{
+		{"disjoint", []timeWindow{win(9, 0, 10, 0)}, []timeWindow{win(11, 0, 12, 0)}, []timeWindow{}},
+		{"touching", []timeWindow{win(9, 0, 10, 0)}, []timeWindow{win(10, 0, 11, 0)}, []timeWindow{}},
+		{"overlap", []timeWindow{win(9, 0, 12, 0)}, []timeWindow{win(11, 0, 14, 0)}, []timeWindow{win(11, 0, 12, 0)}},
+		{"contained", []timeWindow{win(9, 0, 17, 0)}, []timeWindow{win(10, 0, 11, 0), win(13, 0, 14, 0)},
+			[]timeWindow{win(10, 0, 11, 0), win(13, 0, 14, 0)}},
+		{"opening hours over two shifts", []timeWindow{win(8, 0, 12, 0), win(13, 0, 20, 0)}, []timeWindow{win(9, 0, 18, 0)},
+			[]timeWindow{win(9, 0, 12, 0), win(13, 0, 18, 0)}},
+		{"empty", nil, []timeWindow{win(9, 0, 17, 0)}, []timeWindow{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := intersectWindows(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("intersectWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlotStarts(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	clock := func(starts []time.Time) []string {
+		out := []string{}
+		for _, s := range starts {
+			out = append(out, s.In(loc).Format("15:04"))
+		}
+		return out
+	}
+	tests := []struct {
+		name               string
+		date               string
+		fromH, fromM, toH  int
+		interval, duration time.Duration
+		want               []string
+	}{
+		{"regular day", "2026-03-02", 9, 0, 12, 45 * time.Minute, 45 * time.Minute, []string{"09:00", "09:45", "10:30", "11:15"}},
+		{"rounds up to the interval", "2026-03-02", 9, 10, 11, 30 * time.Minute, 30 * time.Minute, []string{"09:30", "10:00", "10:30"}},
+		{"DST starts", "2026-03-08", 9, 0, 12, 45 * time.Minute, 45 * time.Minute, []string{"09:00", "09:45", "10:30", "11:15"}},
+		{"DST ends", "2026-11-01", 9, 0, 12, 45 * time.Minute, 45 * time.Minute, []string{"09:00", "09:45", "10:30", "11:15"}},
+		{"skips the missing hour", "2026-03-08", 1, 0, 4, 30 * time.Minute, 30 * time.Minute, []string{"01:00", "01:30", "03:00", "03:30"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := time.ParseInLocation(scheduleDateLayout, tt.date, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := timeWindow{clockOn(d, tt.fromH*60+tt.fromM), clockOn(d, tt.toH*60)}
+			starts := slotStarts(d, w, tt.interval, tt.duration)
+			if got := clock(starts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slotStarts() = %v, want %v", got, tt.want)
+			}
+			for _, s := range starts {
+				if s.Before(w.start) || s.Add(tt.duration).After(w.end) {
+					t.Errorf("slot %v falls outside the window %v", s, w)
+				}
+			}
+		})
+	}
+}
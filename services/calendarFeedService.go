@@ -0,0 +1,353 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrCalendarFeedNotFound is returned for unknown or revoked feed tokens and feeds outside the caller's reach
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	// ErrCalendarFeedLimit is returned when the owner already has the maximum number of active feeds
+	ErrCalendarFeedLimit = errors.New("too many active calendar feeds; revoke one first")
+)
+
+const (
+	maxCalendarFeedsPerOwner = 10
+	calendarFeedLookback     = 24 * time.Hour       // recently started appointments stay visible
+	calendarFeedHorizon      = 180 * 24 * time.Hour // how far ahead feeds list appointments
+	calendarFeedMaxEvents    = 500
+)
+
+// calendarFeedStatuses are the appointment statuses listed in feeds
+var calendarFeedStatuses = []string{models.AppointmentRequested, models.AppointmentConfirmed, models.AppointmentCheckedIn}
+
+// getAPIBaseURL returns the public base URL of this API, used in subscription links
+func getAPIBaseURL() string {
+	base := os.Getenv("API_BASE_URL")
+	if base == "" {
+		base = "http://localhost:8080/api"
+	}
+	return strings.TrimRight(base, "/")
+}
+
+// calendarFeedURL is the subscription URL of a feed token
+func calendarFeedURL(token string) string {
+	return getAPIBaseURL() + "/calendar/" + token + ".ics"
+}
+
+// createCalendarFeed issues a new feed token for an owner; the raw token only appears in the returned URL
+func createCalendarFeed(ownerType string, ownerID, clinicID uint64, name string) (*resdto.CalendarFeedDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	// Staff feeds belong to one clinic; customer feeds (clinicID 0) span clinics
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	name = strings.TrimSpace(name)
+	if len(name) > 100 {
+		return nil, errors.New("name must be at most 100 characters")
+	}
+
+	var active int64
+	if err := db.Model(&models.CalendarFeed{}).
+		Where("owner_type = ? AND owner_id = ? AND revoked_at IS NULL", ownerType, ownerID).
+		Count(&active).Error; err != nil {
+		return nil, err
+	}
+	if active >= maxCalendarFeedsPerOwner {
+		return nil, ErrCalendarFeedLimit
+	}
+
+	token, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	feed := models.CalendarFeed{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		ClinicID:  clinicID,
+		Name:      name,
+		TokenHash: utils.HashSecretToken(token),
+	}
+	if err := db.Create(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.CalendarFeedDTO{CalendarFeed: feed, URL: calendarFeedURL(token)}, nil
+}
+
+// CreatePractitionerCalendarFeed issues a feed of the clinic user's own appointments
+func CreatePractitionerCalendarFeed(clinicID, clinicUserID uint64, req reqdto.CreateCalendarFeedRequest) (*resdto.CalendarFeedDTO, error) {
+	return createCalendarFeed(models.AuditActorClinicUser, clinicUserID, clinicID, req.Name)
+}
+
+// CreateCustomerCalendarFeed issues a feed of the customer's appointments at all clinics
+func CreateCustomerCalendarFeed(userID uint64, req reqdto.CreateCalendarFeedRequest) (*resdto.CalendarFeedDTO, error) {
+	return createCalendarFeed(models.AuditActorCustomer, userID, 0, req.Name)
+}
+
+// ListCalendarFeeds lists feeds, newest first. clinicID limits to the clinic's staff feeds,
+// ownerID to one owner; revoked feeds are only included when asked for.
+func ListCalendarFeeds(ownerType string, ownerID, clinicID uint64, includeRevoked bool) ([]resdto.CalendarFeedDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	query := db.Where("owner_type = ?", ownerType)
+	if ownerID != 0 {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	if !includeRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+	var feeds []models.CalendarFeed
+	if err := query.Order("id DESC").Find(&feeds).Error; err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint64]string)
+	if ownerType == models.AuditActorClinicUser && len(feeds) > 0 {
+		ids := make([]uint64, 0, len(feeds))
+		for _, f := range feeds {
+			ids = append(ids, f.OwnerID)
+		}
+		var users []models.ClinicUser
+		if err := db.Unscoped().Select("id", "name").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			names[u.ID] = u.Name
+		}
+	}
+
+	out := make([]resdto.CalendarFeedDTO, 0, len(feeds))
+	for _, f := range feeds {
+		out = append(out, resdto.CalendarFeedDTO{CalendarFeed: f, OwnerName: names[f.OwnerID]})
+	}
+	return out, nil
+}
+
+// RevokeCalendarFeed disables a feed so its URL stops working. ownerID restricts to the owner's
+// own feeds and clinicID to the clinic's staff feeds; revoking twice is a no-op.
+func RevokeCalendarFeed(ownerType string, ownerID, clinicID, feedID uint64, actorType string, actorID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	query := db.Where("id = ? AND owner_type = ?", feedID, ownerType)
+	if ownerID != 0 {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	var feed models.CalendarFeed
+	if err := query.First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCalendarFeedNotFound
+		}
+		return err
+	}
+	if feed.RevokedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	return db.Model(&feed).Updates(map[string]interface{}{
+		"revoked_at":      now,
+		"revoked_by_type": actorType,
+		"revoked_by_id":   actorID,
+	}).Error
+}
+
+// calendarCustomerName applies the clinic's calendar name policy to a customer name
+func calendarCustomerName(name, policy string) string {
+	switch policy {
+	case models.CalendarNamesFull:
+		return name
+	case models.CalendarNamesHidden:
+		return ""
+	}
+	var initials []string
+	for _, word := range strings.Fields(name) {
+		r := []rune(word)[0]
+		initials = append(initials, string(unicode.ToUpper(r))+".")
+	}
+	return strings.Join(initials, " ")
+}
+
+// appointmentCalendarEvent builds the calendar event of an appointment. customerName is shown in the
+// summary when set; otherwise the event is worded for the customer.
+func appointmentCalendarEvent(dto *resdto.AppointmentDTO, address, customerName string) utils.CalendarEvent {
+	summary := fmt.Sprintf("%s at %s", dto.TreatmentName, dto.ClinicName)
+	if customerName != "" {
+		summary = fmt.Sprintf("%s - %s", dto.TreatmentName, customerName)
+	}
+
+	var areas []string
+	for _, item := range dto.Items {
+		area := item.SideAreaName
+		if item.AreaName != "" && item.AreaName != item.SideAreaName {
+			area = item.AreaName + " (" + item.SideAreaName + ")"
+		}
+		if item.SyringeCount > 1 {
+			area += fmt.Sprintf(" x%d", item.SyringeCount)
+		}
+		areas = append(areas, area)
+	}
+	lines := []string{"Clinic: " + dto.ClinicName, "Treatment: " + dto.TreatmentName}
+	if len(areas) > 0 {
+		lines = append(lines, "Areas: "+strings.Join(areas, ", "))
+	}
+	lines = append(lines, "Practitioner: "+dto.PractitionerName, "Status: "+dto.Status)
+
+	status := "CONFIRMED"
+	switch dto.Status {
+	case models.AppointmentRequested:
+		status = "TENTATIVE"
+	case models.AppointmentCancelled, models.AppointmentNoShow:
+		status = "CANCELLED"
+	}
+	return utils.CalendarEvent{
+		UID:         fmt.Sprintf("appointment-%d@skinsync", dto.ID),
+		Start:       dto.StartAt,
+		End:         dto.EndAt,
+		Summary:     summary,
+		Description: strings.Join(lines, "\n"),
+		Location:    address,
+		Status:      status,
+		Sequence:    int(dto.UpdatedAt.Unix() - dto.CreatedAt.Unix()),
+	}
+}
+
+// clinicCalendarSettings loads the address and calendar name policy of clinics by id
+func clinicCalendarSettings(db *gorm.DB, clinicIDs []uint64) (map[uint64]models.Clinic, error) {
+	var clinics []models.Clinic
+	if err := db.Unscoped().Select("id", "address", "calendar_customer_names").Where("id IN ?", clinicIDs).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[uint64]models.Clinic, len(clinics))
+	for _, c := range clinics {
+		out[c.ID] = c
+	}
+	return out, nil
+}
+
+// appointmentICS renders one appointment as an iCalendar attachment for the customer
+func appointmentICS(db *gorm.DB, dto *resdto.AppointmentDTO) (string, error) {
+	clinics, err := clinicCalendarSettings(db, []uint64{dto.ClinicID})
+	if err != nil {
+		return "", err
+	}
+	event := appointmentCalendarEvent(dto, clinics[dto.ClinicID].Address, "")
+	return utils.BuildICS("", "PUBLISH", []utils.CalendarEvent{event}), nil
+}
+
+// GetCalendarFeedICS renders the upcoming appointments behind a feed token ("<token>.ics").
+// The token is the credential and names the clinic, so the lookup runs on the unscoped DB.
+func GetCalendarFeedICS(token string) (string, error) {
+	db := config.DB
+	if db == nil {
+		return "", errors.New("database not initialized")
+	}
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	var feed models.CalendarFeed
+	if err := db.Where("token_hash = ? AND revoked_at IS NULL", utils.HashSecretToken(token)).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrCalendarFeedNotFound
+		}
+		return "", err
+	}
+
+	now := time.Now()
+	query := db.Preload("Items").
+		Where("status IN ? AND start_at >= ? AND start_at < ?", calendarFeedStatuses, now.Add(-calendarFeedLookback), now.Add(calendarFeedHorizon))
+	calendarName := "SkinSync appointments"
+	switch feed.OwnerType {
+	case models.AuditActorClinicUser:
+		// Feeds of staff who left the clinic stop working
+		var user models.ClinicUser
+		err := db.Select("id", "name", "status").
+			Where("id = ? AND clinic_id = ? AND status = ? AND deleted_at IS NULL", feed.OwnerID, feed.ClinicID, models.ClinicUserStatusActive).
+			First(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrCalendarFeedNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		query = query.Where("clinic_id = ? AND practitioner_id = ?", feed.ClinicID, feed.OwnerID)
+		calendarName = "SkinSync - " + user.Name
+	case models.AuditActorCustomer:
+		var user models.User
+		err := db.Select("id").Where("id = ? AND status = ?", feed.OwnerID, models.UserStatusActive).First(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrCalendarFeedNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		query = query.Where("user_id = ?", feed.OwnerID)
+	default:
+		return "", ErrCalendarFeedNotFound
+	}
+
+	var appts []models.Appointment
+	if err := query.Order("start_at ASC, id ASC").Limit(calendarFeedMaxEvents).Find(&appts).Error; err != nil {
+		return "", err
+	}
+	viewer := feed.OwnerType
+	dtos, err := appointmentDTOs(db, appts, viewer)
+	if err != nil {
+		return "", err
+	}
+	clinicIDs := make([]uint64, 0, len(dtos))
+	for _, d := range dtos {
+		clinicIDs = append(clinicIDs, d.ClinicID)
+	}
+	clinics := map[uint64]models.Clinic{}
+	if len(clinicIDs) > 0 {
+		if clinics, err = clinicCalendarSettings(db, clinicIDs); err != nil {
+			return "", err
+		}
+	}
+
+	events := make([]utils.CalendarEvent, 0, len(dtos))
+	for i := range dtos {
+		clinic := clinics[dtos[i].ClinicID]
+		customerName := ""
+		if viewer == models.AuditActorClinicUser {
+			customerName = calendarCustomerName(dtos[i].CustomerName, clinic.CalendarCustomerNames)
+			if customerName == "" {
+				customerName = "Client"
+			}
+		}
+		events = append(events, appointmentCalendarEvent(&dtos[i], clinic.Address, customerName))
+	}
+
+	if err := db.Model(&feed).UpdateColumn("last_accessed_at", now).Error; err != nil {
+		return "", err
+	}
+	return utils.BuildICS(calendarName, "", events), nil
+}
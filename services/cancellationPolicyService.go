@@ -0,0 +1,338 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== CANCELLATION POLICIES ====================
+
+const (
+	maxFreeCancelHours = 14 * 24
+	maxPolicyAmount    = 100000
+)
+
+// validatePolicyFee checks one fee or deposit and returns its type, defaulting to fixed
+func validatePolicyFee(field string, amount float64, feeType string) (string, error) {
+	if feeType == "" {
+		feeType = models.PolicyFeeFixed
+	}
+	switch feeType {
+	case models.PolicyFeeFixed:
+		if amount < 0 || amount > maxPolicyAmount {
+			return "", fmt.Errorf("%s must be between 0 and %d", field, maxPolicyAmount)
+		}
+	case models.PolicyFeePercent:
+		if amount < 0 || amount > 100 {
+			return "", fmt.Errorf("%s must be a percent between 0 and 100", field)
+		}
+	default:
+		return "", fmt.Errorf("%s_type must be fixed or percent", field)
+	}
+	return feeType, nil
+}
+
+// validateCancellationPolicy checks a policy request and applies it to the policy row
+func validateCancellationPolicy(req reqdto.CancellationPolicyRequest, policy *models.ClinicCancellationPolicy) error {
+	if req.FreeCancelHours < 0 || req.FreeCancelHours > maxFreeCancelHours {
+		return fmt.Errorf("free_cancel_hours must be between 0 and %d", maxFreeCancelHours)
+	}
+	lateType, err := validatePolicyFee("late_cancel_fee", req.LateCancelFee, req.LateCancelFeeType)
+	if err != nil {
+		return err
+	}
+	noShowType, err := validatePolicyFee("no_show_fee", req.NoShowFee, req.NoShowFeeType)
+	if err != nil {
+		return err
+	}
+	depositType, err := validatePolicyFee("deposit", req.Deposit, req.DepositType)
+	if err != nil {
+		return err
+	}
+	policy.FreeCancelHours = req.FreeCancelHours
+	policy.LateCancelFee, policy.LateCancelFeeType = req.LateCancelFee, lateType
+	policy.NoShowFee, policy.NoShowFeeType = req.NoShowFee, noShowType
+	policy.Deposit, policy.DepositType = req.Deposit, depositType
+	return nil
+}
+
+// effectiveCancellationPolicy returns the clinic's policy for a treatment, falling back to the
+// clinic default; nil when the clinic has neither
+func effectiveCancellationPolicy(db *gorm.DB, clinicID uint64, treatmentID uint) (*models.ClinicCancellationPolicy, error) {
+	var policy models.ClinicCancellationPolicy
+	err := db.Where("clinic_id = ? AND treatment_id IN ?", clinicID, []uint{treatmentID, 0}).
+		Order("treatment_id DESC").First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// policyAmount resolves a fee to an amount; percent fees of an unpriced appointment are 0
+func policyAmount(value float64, feeType string, price *float64) float64 {
+	if feeType == models.PolicyFeePercent {
+		if price == nil {
+			return 0
+		}
+		value = *price * value / 100
+	}
+	return math.Round(value*100) / 100
+}
+
+// formatPolicyFee describes a fee for the booking terms
+func formatPolicyFee(value float64, feeType, currency string) string {
+	if feeType == models.PolicyFeePercent {
+		return fmt.Sprintf("%g%% of the treatment price", value)
+	}
+	return fmt.Sprintf("%.2f %s", value, currency)
+}
+
+// cancellationTerms describes a policy in plain sentences for customers
+func cancellationTerms(policy *models.ClinicCancellationPolicy, currency string) []string {
+	if policy == nil || (policy.LateCancelFee == 0 && policy.NoShowFee == 0 && policy.Deposit == 0) {
+		return []string{"Appointments can be cancelled free of charge until they start."}
+	}
+	terms := []string{}
+	if policy.FreeCancelHours > 0 {
+		terms = append(terms, fmt.Sprintf("Free cancellation until %d hours before the appointment.", policy.FreeCancelHours))
+	} else {
+		terms = append(terms, "Free cancellation until the appointment starts.")
+	}
+	if policy.LateCancelFee > 0 && policy.FreeCancelHours > 0 {
+		terms = append(terms, fmt.Sprintf("Cancelling a confirmed appointment later costs %s.", formatPolicyFee(policy.LateCancelFee, policy.LateCancelFeeType, currency)))
+	}
+	if policy.NoShowFee > 0 {
+		terms = append(terms, fmt.Sprintf("Missing the appointment costs %s.", formatPolicyFee(policy.NoShowFee, policy.NoShowFeeType, currency)))
+	}
+	if policy.Deposit > 0 {
+		terms = append(terms, fmt.Sprintf("A deposit of %s is charged when booking; it is returned on free cancellation and counts towards any fee.",
+			formatPolicyFee(policy.Deposit, policy.DepositType, currency)))
+	}
+	return terms
+}
+
+// applyCancellationPolicy records the policy a new appointment is booked under and its deposit charge
+func applyCancellationPolicy(tx *gorm.DB, appt *models.Appointment, currency string) error {
+	policy, err := effectiveCancellationPolicy(tx, appt.ClinicID, appt.TreatmentID)
+	if err != nil || policy == nil {
+		return err
+	}
+	snapshot := models.AppointmentPolicy{
+		AppointmentID:   appt.ID,
+		PolicyID:        policy.ID,
+		FreeCancelHours: policy.FreeCancelHours,
+		LateCancelFee:   policyAmount(policy.LateCancelFee, policy.LateCancelFeeType, appt.Price),
+		NoShowFee:       policyAmount(policy.NoShowFee, policy.NoShowFeeType, appt.Price),
+		Deposit:         policyAmount(policy.Deposit, policy.DepositType, appt.Price),
+		Currency:        currency,
+	}
+	if err := tx.Create(&snapshot).Error; err != nil {
+		return err
+	}
+	if snapshot.Deposit <= 0 {
+		return nil
+	}
+	return tx.Create(&models.CustomerCharge{
+		ClinicID:      appt.ClinicID,
+		UserID:        appt.UserID,
+		AppointmentID: appt.ID,
+		Kind:          models.ChargeDeposit,
+		Amount:        snapshot.Deposit,
+		Currency:      currency,
+		Status:        models.ChargePending,
+	}).Error
+}
+
+// lateCancelFeeApplies reports whether a customer cancelling now is charged the late-cancel fee.
+// Only confirmed appointments are charged; requests the clinic has not accepted cancel free.
+func lateCancelFeeApplies(appt *models.Appointment, policy *models.AppointmentPolicy, now time.Time) bool {
+	if policy == nil || policy.LateCancelFee <= 0 || policy.FreeCancelHours == 0 || appt.Status != models.AppointmentConfirmed {
+		return false
+	}
+	return !now.Before(appt.StartAt.Add(-time.Duration(policy.FreeCancelHours) * time.Hour))
+}
+
+// assessAppointmentFees applies the booked policy when an appointment is cancelled or marked no-show.
+// Late customer cancellations and no-shows are charged their fee less the deposit, which the clinic
+// keeps; any other cancellation releases the deposit.
+func assessAppointmentFees(tx *gorm.DB, appt *models.Appointment, status, actorType string, now time.Time) error {
+	if status != models.AppointmentCancelled && status != models.AppointmentNoShow {
+		return nil
+	}
+	var policy models.AppointmentPolicy
+	err := tx.Where("appointment_id = ?", appt.ID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	kind, fee := models.ChargeNoShow, policy.NoShowFee
+	if status == models.AppointmentCancelled {
+		if actorType != models.AuditActorCustomer || !lateCancelFeeApplies(appt, &policy, now) {
+			return releaseDeposit(tx, appt.ID)
+		}
+		kind, fee = models.ChargeLateCancel, policy.LateCancelFee
+	}
+
+	var deposit models.CustomerCharge
+	err = tx.Where("appointment_id = ? AND kind = ? AND status IN ?", appt.ID, models.ChargeDeposit,
+		[]string{models.ChargePending, models.ChargeFailed, models.ChargeCollected}).First(&deposit).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	amount := math.Round((fee-deposit.Amount)*100) / 100
+	if amount <= 0 {
+		return nil
+	}
+	return tx.Create(&models.CustomerCharge{
+		ClinicID:      appt.ClinicID,
+		UserID:        appt.UserID,
+		AppointmentID: appt.ID,
+		Kind:          kind,
+		Amount:        amount,
+		Currency:      policy.Currency,
+		Status:        models.ChargePending,
+	}).Error
+}
+
+// releaseDeposit voids an uncollected deposit and queues a collected one for refund
+func releaseDeposit(tx *gorm.DB, appointmentID uint64) error {
+	deposits := tx.Model(&models.CustomerCharge{}).Where("appointment_id = ? AND kind = ?", appointmentID, models.ChargeDeposit).Session(&gorm.Session{})
+	if err := deposits.Where("status IN ?", []string{models.ChargePending, models.ChargeFailed}).
+		Update("status", models.ChargeVoid).Error; err != nil {
+		return err
+	}
+	return deposits.Where("status = ?", models.ChargeCollected).
+		Update("status", models.ChargeRefunding).Error
+}
+
+// GetClinicCancellationPolicies returns the clinic's default and treatment-specific policies
+func GetClinicCancellationPolicies(clinicID uint64) (*resdto.ClinicCancellationPoliciesDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var clinic models.Clinic
+	if err := db.Select("id", "currency").First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	var policies []models.ClinicCancellationPolicy
+	if err := config.ClinicDB(clinicID).Order("treatment_id").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	var treatmentIDs []uint
+	for _, p := range policies {
+		if p.TreatmentID != 0 {
+			treatmentIDs = append(treatmentIDs, p.TreatmentID)
+		}
+	}
+	nameByID := map[uint]string{}
+	if len(treatmentIDs) > 0 {
+		var treatments []models.Treatment
+		if err := db.Select("id", "name").Where("id IN ?", treatmentIDs).Find(&treatments).Error; err != nil {
+			return nil, err
+		}
+		for _, t := range treatments {
+			nameByID[t.ID] = t.Name
+		}
+	}
+
+	out := &resdto.ClinicCancellationPoliciesDTO{Currency: clinic.Currency, Treatments: []resdto.TreatmentCancellationPolicyDTO{}}
+	for i, p := range policies {
+		if p.TreatmentID == 0 {
+			out.Default = &policies[i]
+			continue
+		}
+		out.Treatments = append(out.Treatments, resdto.TreatmentCancellationPolicyDTO{ClinicCancellationPolicy: p, TreatmentName: nameByID[p.TreatmentID]})
+	}
+	return out, nil
+}
+
+// SetClinicCancellationPolicy creates or replaces the clinic default (treatmentID 0) or a treatment's policy.
+// Appointments already booked keep the terms they were booked under.
+func SetClinicCancellationPolicy(clinicID uint64, treatmentID uint, req reqdto.CancellationPolicyRequest) (*resdto.ClinicCancellationPoliciesDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if treatmentID != 0 {
+		var offered models.ClinicTreatment
+		err := db.Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).First(&offered).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTreatmentNotOffered
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tdb := config.ClinicDB(clinicID)
+	var policy models.ClinicCancellationPolicy
+	err := tdb.Where("treatment_id = ?", treatmentID).First(&policy).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := validateCancellationPolicy(req, &policy); err != nil {
+		return nil, err
+	}
+	policy.ClinicID = clinicID
+	policy.TreatmentID = treatmentID
+	if err := tdb.Save(&policy).Error; err != nil {
+		return nil, err
+	}
+	return GetClinicCancellationPolicies(clinicID)
+}
+
+// DeleteClinicCancellationPolicy removes the clinic default (treatmentID 0) or a treatment's policy
+func DeleteClinicCancellationPolicy(clinicID uint64, treatmentID uint) (*resdto.ClinicCancellationPoliciesDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	res := db.Where("treatment_id = ?", treatmentID).Delete(&models.ClinicCancellationPolicy{})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return GetClinicCancellationPolicies(clinicID)
+}
+
+// GetBookingPolicy returns the cancellation policy a customer books a treatment at the clinic under
+func GetBookingPolicy(clinicID uint64, treatmentID uint) (*resdto.BookingPolicyDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var clinic models.Clinic
+	if err := db.Select("id", "currency").First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	policy, err := effectiveCancellationPolicy(db, clinicID, treatmentID)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.BookingPolicyDTO{
+		ClinicID:    clinicID,
+		TreatmentID: treatmentID,
+		Currency:    clinic.Currency,
+		Policy:      policy,
+		Terms:       cancellationTerms(policy, clinic.Currency),
+	}, nil
+}
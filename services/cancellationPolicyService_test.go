@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"skinSync/models"
+)
+
+func TestPolicyAmount(t *testing.T) {
+	price := 250.0
+	odd := 99.99
+	tests := []struct {
+		name    string
+		value   float64
+		feeType string
+		price   *float64
+		want    float64
+	}{
+		{"fixed", 40, models.PolicyFeeFixed, &price, 40},
+		{"fixed without price", 40, models.PolicyFeeFixed, nil, 40},
+		{"fixed rounds to cents", 12.345, models.PolicyFeeFixed, nil, 12.35},
+		{"percent", 20, models.PolicyFeePercent, &price, 50},
+		{"percent rounds to cents", 15, models.PolicyFeePercent, &odd, 15},
+		{"percent without price", 20, models.PolicyFeePercent, nil, 0},
+		{"zero", 0, models.PolicyFeePercent, &price, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policyAmount(tt.value, tt.feeType, tt.price); got != tt.want {
+				t.Errorf("policyAmount(%v, %q) = %v, want %v", tt.value, tt.feeType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLateCancelFeeApplies(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	policy := &models.AppointmentPolicy{FreeCancelHours: 24, LateCancelFee: 30}
+	tests := []struct {
+		name   string
+		status string
+		policy *models.AppointmentPolicy
+		now    time.Time
+		want   bool
+	}{
+		{"inside free window", models.AppointmentConfirmed, policy, start.Add(-48 * time.Hour), false},
+		{"just before the cut-off", models.AppointmentConfirmed, policy, start.Add(-24*time.Hour - time.Second), false},
+		{"at the cut-off", models.AppointmentConfirmed, policy, start.Add(-24 * time.Hour), true},
+		{"late", models.AppointmentConfirmed, policy, start.Add(-time.Hour), true},
+		{"requested is never charged", models.AppointmentRequested, policy, start.Add(-time.Hour), false},
+		{"no policy", models.AppointmentConfirmed, nil, start.Add(-time.Hour), false},
+		{"no fee", models.AppointmentConfirmed, &models.AppointmentPolicy{FreeCancelHours: 24}, start.Add(-time.Hour), false},
+		{"no free window", models.AppointmentConfirmed, &models.AppointmentPolicy{LateCancelFee: 30}, start.Add(-time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appt := &models.Appointment{Status: tt.status, StartAt: start}
+			if got := lateCancelFeeApplies(appt, tt.policy, tt.now); got != tt.want {
+				t.Errorf("lateCancelFeeApplies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,787 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"skinSync/config"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== CATALOG IMPORT / EXPORT ====================
+//
+// Imports upsert into the draft tables (publish afterwards to make them live).
+// Nodes are matched by their stable code; rows without a code, or existing rows
+// that have never been given one, are matched by name within their parent.
+// Nothing is deleted by an import - archive nodes explicitly instead.
+
+// CatalogFileSideArea is a side area in the import/export format
+type CatalogFileSideArea struct {
+	Code        string `json:"code,omitempty"`
+	Name        string `json:"name"`
+	Icon        string `json:"icon,omitempty"`
+	Description string `json:"description,omitempty"`
+	MinSyringe  int    `json:"min_syringe"`
+	MaxSyringe  int    `json:"max_syringe"`
+	Status      string `json:"status,omitempty"`
+}
+
+// CatalogFileArea is an area in the import/export format
+type CatalogFileArea struct {
+	Code        string                `json:"code,omitempty"`
+	Name        string                `json:"name"`
+	Icon        string                `json:"icon,omitempty"`
+	Description string                `json:"description,omitempty"`
+	MinSyringe  int                   `json:"min_syringe"`
+	MaxSyringe  int                   `json:"max_syringe"`
+	Status      string                `json:"status,omitempty"`
+	SideAreas   []CatalogFileSideArea `json:"side_areas,omitempty"`
+}
+
+// CatalogFileTreatment is a treatment in the import/export format
+type CatalogFileTreatment struct {
+	Code        string            `json:"code,omitempty"`
+	Name        string            `json:"name"`
+	Icon        string            `json:"icon,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	Areas       []CatalogFileArea `json:"areas,omitempty"`
+}
+
+// Catalog import actions
+const (
+	CatalogImportCreate    = "create"
+	CatalogImportUpdate    = "update"
+	CatalogImportUnchanged = "unchanged"
+	CatalogImportConflict  = "conflict"
+)
+
+// CatalogImportItem is the outcome for a single imported node
+type CatalogImportItem struct {
+	Level         string   `json:"level"` // treatment, area, side_area
+	Code          string   `json:"code,omitempty"`
+	Name          string   `json:"name"`
+	Action        string   `json:"action"`
+	ID            uint     `json:"id,omitempty"`
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+}
+
+// CatalogImportReport summarises an import run
+type CatalogImportReport struct {
+	DryRun    bool                `json:"dry_run"`
+	Applied   bool                `json:"applied"`
+	Creates   int                 `json:"creates"`
+	Updates   int                 `json:"updates"`
+	Unchanged int                 `json:"unchanged"`
+	Conflicts int                 `json:"conflicts"`
+	Items     []CatalogImportItem `json:"items"`
+}
+
+// ErrCatalogImportConflicts is returned when an import was not applied because of conflicts
+var ErrCatalogImportConflicts = errors.New("catalog import has conflicts - nothing was applied")
+
+// errCatalogImportRollback rolls back a dry run after the report has been built
+var errCatalogImportRollback = errors.New("catalog import dry run")
+
+// catalogCSVHeader is the column order used by the CSV import/export format.
+// Each row describes one leaf: a treatment, an area or a side area together with its parents.
+var catalogCSVHeader = []string{
+	"treatment_code", "treatment_name", "treatment_icon", "treatment_description", "treatment_status",
+	"area_code", "area_name", "area_icon", "area_description", "area_min_syringe", "area_max_syringe", "area_status",
+	"side_area_code", "side_area_name", "side_area_icon", "side_area_description", "side_area_min_syringe", "side_area_max_syringe", "side_area_status",
+}
+
+// ParseCatalogJSON decodes the JSON import format
+func ParseCatalogJSON(r io.Reader) ([]CatalogFileTreatment, error) {
+	var tree []CatalogFileTreatment
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("invalid catalog JSON: %w", err)
+	}
+	return tree, nil
+}
+
+// ParseCatalogCSV decodes the CSV import format into a treatment tree.
+// Parent columns are repeated on every row; the first row for a parent defines its fields.
+func ParseCatalogCSV(r io.Reader) ([]CatalogFileTreatment, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog CSV: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := cols["treatment_name"]; !ok {
+		return nil, errors.New("invalid catalog CSV: treatment_name column is required")
+	}
+
+	var tree []CatalogFileTreatment
+	treatmentIdx := map[string]int{}
+	areaIdx := map[string]int{}
+
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog CSV line %d: %w", line, err)
+		}
+
+		get := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		getInt := func(name string) (int, error) {
+			v := get(name)
+			if v == "" {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid catalog CSV line %d: %s must be a number", line, name)
+			}
+			return n, nil
+		}
+
+		t := CatalogFileTreatment{
+			Code:        get("treatment_code"),
+			Name:        get("treatment_name"),
+			Icon:        get("treatment_icon"),
+			Description: get("treatment_description"),
+			Status:      get("treatment_status"),
+		}
+		if t.Name == "" && t.Code == "" {
+			continue // blank line
+		}
+		tKey := catalogFileKey(t.Code, t.Name)
+		ti, ok := treatmentIdx[tKey]
+		if !ok {
+			tree = append(tree, t)
+			ti = len(tree) - 1
+			treatmentIdx[tKey] = ti
+		}
+
+		areaCode, areaName := get("area_code"), get("area_name")
+		if areaCode == "" && areaName == "" {
+			continue
+		}
+		areaMin, err := getInt("area_min_syringe")
+		if err != nil {
+			return nil, err
+		}
+		areaMax, err := getInt("area_max_syringe")
+		if err != nil {
+			return nil, err
+		}
+		aKey := tKey + "\x00" + catalogFileKey(areaCode, areaName)
+		ai, ok := areaIdx[aKey]
+		if !ok {
+			tree[ti].Areas = append(tree[ti].Areas, CatalogFileArea{
+				Code:        areaCode,
+				Name:        areaName,
+				Icon:        get("area_icon"),
+				Description: get("area_description"),
+				MinSyringe:  areaMin,
+				MaxSyringe:  areaMax,
+				Status:      get("area_status"),
+			})
+			ai = len(tree[ti].Areas) - 1
+			areaIdx[aKey] = ai
+		}
+
+		sideCode, sideName := get("side_area_code"), get("side_area_name")
+		if sideCode == "" && sideName == "" {
+			continue
+		}
+		sideMin, err := getInt("side_area_min_syringe")
+		if err != nil {
+			return nil, err
+		}
+		sideMax, err := getInt("side_area_max_syringe")
+		if err != nil {
+			return nil, err
+		}
+		tree[ti].Areas[ai].SideAreas = append(tree[ti].Areas[ai].SideAreas, CatalogFileSideArea{
+			Code:        sideCode,
+			Name:        sideName,
+			Icon:        get("side_area_icon"),
+			Description: get("side_area_description"),
+			MinSyringe:  sideMin,
+			MaxSyringe:  sideMax,
+			Status:      get("side_area_status"),
+		})
+	}
+
+	return tree, nil
+}
+
+// catalogFileKey identifies a node within an import file
+func catalogFileKey(code, name string) string {
+	if code != "" {
+		return "code:" + code
+	}
+	return "name:" + strings.ToLower(name)
+}
+
+// catalogImporter carries state for a single import run
+type catalogImporter struct {
+	tx     *gorm.DB
+	report *CatalogImportReport
+	seen   map[string]bool // level + file key, to catch duplicates within the file
+}
+
+// record adds an item to the report and updates the counters
+func (imp *catalogImporter) record(item CatalogImportItem) {
+	switch item.Action {
+	case CatalogImportCreate:
+		imp.report.Creates++
+	case CatalogImportUpdate:
+		imp.report.Updates++
+	case CatalogImportUnchanged:
+		imp.report.Unchanged++
+	case CatalogImportConflict:
+		imp.report.Conflicts++
+	}
+	imp.report.Items = append(imp.report.Items, item)
+}
+
+// conflict records a node that cannot be imported
+func (imp *catalogImporter) conflict(level, code, name, reason string) {
+	imp.record(CatalogImportItem{Level: level, Code: code, Name: name, Action: CatalogImportConflict, Reason: reason})
+}
+
+// duplicate reports whether a node key has already been seen in this file
+func (imp *catalogImporter) duplicate(level, parent, code, name string) bool {
+	keys := []string{level + "|" + parent + "|name:" + strings.ToLower(name)}
+	if code != "" {
+		keys = append(keys, level+"|code:"+code)
+	}
+	dup := false
+	for _, k := range keys {
+		if imp.seen[k] {
+			dup = true
+		}
+		imp.seen[k] = true
+	}
+	return dup
+}
+
+// normalizeImportStatus validates an optional status, keeping current when blank
+func normalizeImportStatus(status, current string) (string, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		if current == "" {
+			return models.CatalogStatusActive, nil
+		}
+		return current, nil
+	}
+	if err := validateCatalogStatus(status); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// normalizeImportSyringes applies the same defaults as the admin CRUD and validates the range
+func normalizeImportSyringes(minSyringe, maxSyringe int) (int, int, error) {
+	if minSyringe == 0 {
+		minSyringe = 1
+	}
+	if maxSyringe == 0 {
+		maxSyringe = minSyringe
+	}
+	return minSyringe, maxSyringe, validateSyringeRange(minSyringe, maxSyringe)
+}
+
+// importTreatment upserts a treatment and its children
+func (imp *catalogImporter) importTreatment(in CatalogFileTreatment) error {
+	code, name := strings.TrimSpace(in.Code), strings.TrimSpace(in.Name)
+
+	fail := func(reason string) {
+		imp.conflict("treatment", code, name, reason)
+		imp.skipAreas(in.Areas, "parent treatment has a conflict")
+	}
+
+	if name == "" {
+		fail("name is required")
+		return nil
+	}
+	if imp.duplicate("treatment", "", code, name) {
+		fail("duplicate treatment in file")
+		return nil
+	}
+
+	var existing models.Treatment
+	found, err := imp.findNode(&existing, code, "name = ?", name)
+	if err != nil {
+		return err
+	}
+	if found && code != "" && existing.Code != nil && *existing.Code != code {
+		fail(fmt.Sprintf("name already used by treatment with code %q", *existing.Code))
+		return nil
+	}
+	if found && existing.Name != name {
+		var other models.Treatment
+		if err := imp.tx.Where("name = ? AND id <> ?", name, existing.ID).First(&other).Error; err == nil {
+			fail("name already used by another treatment")
+			return nil
+		}
+	}
+
+	status, err := normalizeImportStatus(in.Status, existing.Status)
+	if err != nil {
+		fail(err.Error())
+		return nil
+	}
+
+	desired := existing
+	desired.Code = catalogCode(code)
+	if code == "" {
+		desired.Code = existing.Code
+	}
+	desired.Name = name
+	desired.Icon = in.Icon
+	desired.Description = in.Description
+	desired.Status = status
+	desired.IsArea = existing.IsArea || len(in.Areas) > 0
+
+	var changed []string
+	if found {
+		if codeValue(desired.Code) != codeValue(existing.Code) {
+			changed = append(changed, "code")
+		}
+		if desired.Name != existing.Name {
+			changed = append(changed, "name")
+		}
+		if desired.Icon != existing.Icon {
+			changed = append(changed, "icon")
+		}
+		if desired.Description != existing.Description {
+			changed = append(changed, "description")
+		}
+		if desired.Status != existing.Status {
+			changed = append(changed, "status")
+		}
+		if desired.IsArea != existing.IsArea {
+			changed = append(changed, "is_area")
+		}
+	}
+
+	desired.Areas = nil
+	item := CatalogImportItem{Level: "treatment", Code: code, Name: name}
+	if err := imp.save(&desired, found, changed, &item); err != nil {
+		return err
+	}
+
+	for _, a := range in.Areas {
+		if err := imp.importArea(desired.ID, a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importArea upserts an area and its side areas under treatmentID
+func (imp *catalogImporter) importArea(treatmentID uint, in CatalogFileArea) error {
+	code, name := strings.TrimSpace(in.Code), strings.TrimSpace(in.Name)
+
+	fail := func(reason string) {
+		imp.conflict("area", code, name, reason)
+		imp.skipSideAreas(in.SideAreas, "parent area has a conflict")
+	}
+
+	if name == "" {
+		fail("name is required")
+		return nil
+	}
+	if imp.duplicate("area", strconv.FormatUint(uint64(treatmentID), 10), code, name) {
+		fail("duplicate area in file")
+		return nil
+	}
+
+	minSyringe, maxSyringe, err := normalizeImportSyringes(in.MinSyringe, in.MaxSyringe)
+	if err != nil {
+		fail(err.Error())
+		return nil
+	}
+
+	var existing models.Area
+	found, err := imp.findNode(&existing, code, "treatment_id = ? AND name = ?", treatmentID, name)
+	if err != nil {
+		return err
+	}
+	if found && existing.TreatmentID != treatmentID {
+		fail("code belongs to an area of another treatment")
+		return nil
+	}
+	if found && code != "" && existing.Code != nil && *existing.Code != code {
+		fail(fmt.Sprintf("name already used by area with code %q", *existing.Code))
+		return nil
+	}
+
+	status, err := normalizeImportStatus(in.Status, existing.Status)
+	if err != nil {
+		fail(err.Error())
+		return nil
+	}
+
+	desired := existing
+	desired.TreatmentID = treatmentID
+	desired.Code = catalogCode(code)
+	if code == "" {
+		desired.Code = existing.Code
+	}
+	desired.Name = name
+	desired.Icon = in.Icon
+	desired.Description = in.Description
+	desired.MinSyringe = minSyringe
+	desired.MaxSyringe = maxSyringe
+	desired.Status = status
+	desired.IsSideArea = existing.IsSideArea || len(in.SideAreas) > 0
+
+	var changed []string
+	if found {
+		if codeValue(desired.Code) != codeValue(existing.Code) {
+			changed = append(changed, "code")
+		}
+		if desired.Name != existing.Name {
+			changed = append(changed, "name")
+		}
+		if desired.Icon != existing.Icon {
+			changed = append(changed, "icon")
+		}
+		if desired.Description != existing.Description {
+			changed = append(changed, "description")
+		}
+		if desired.IsSideArea != existing.IsSideArea {
+			changed = append(changed, "is_sidearea")
+		}
+		if desired.MinSyringe != existing.MinSyringe {
+			changed = append(changed, "min_syringe")
+		}
+		if desired.MaxSyringe != existing.MaxSyringe {
+			changed = append(changed, "max_syringe")
+		}
+		if desired.Status != existing.Status {
+			changed = append(changed, "status")
+		}
+	}
+
+	desired.SideAreas = nil
+	item := CatalogImportItem{Level: "area", Code: code, Name: name}
+	if err := imp.save(&desired, found, changed, &item); err != nil {
+		return err
+	}
+
+	for _, sa := range in.SideAreas {
+		if err := imp.importSideArea(desired, sa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importSideArea upserts a side area under area
+func (imp *catalogImporter) importSideArea(area models.Area, in CatalogFileSideArea) error {
+	code, name := strings.TrimSpace(in.Code), strings.TrimSpace(in.Name)
+
+	if name == "" {
+		imp.conflict("side_area", code, name, "name is required")
+		return nil
+	}
+	if imp.duplicate("side_area", strconv.FormatUint(uint64(area.ID), 10), code, name) {
+		imp.conflict("side_area", code, name, "duplicate side area in file")
+		return nil
+	}
+
+	minSyringe, maxSyringe, err := normalizeImportSyringes(in.MinSyringe, in.MaxSyringe)
+	if err != nil {
+		imp.conflict("side_area", code, name, err.Error())
+		return nil
+	}
+
+	var existing models.SideArea
+	found, err := imp.findNode(&existing, code, "area_id = ? AND name = ?", area.ID, name)
+	if err != nil {
+		return err
+	}
+	if found && existing.AreaID != area.ID {
+		imp.conflict("side_area", code, name, "code belongs to a side area of another area")
+		return nil
+	}
+	if found && code != "" && existing.Code != nil && *existing.Code != code {
+		imp.conflict("side_area", code, name, fmt.Sprintf("name already used by side area with code %q", *existing.Code))
+		return nil
+	}
+
+	status, err := normalizeImportStatus(in.Status, existing.Status)
+	if err != nil {
+		imp.conflict("side_area", code, name, err.Error())
+		return nil
+	}
+
+	desired := existing
+	desired.TreatmentID = area.TreatmentID
+	desired.AreaID = area.ID
+	desired.Code = catalogCode(code)
+	if code == "" {
+		desired.Code = existing.Code
+	}
+	desired.Name = name
+	desired.Icon = in.Icon
+	desired.Description = in.Description
+	desired.MinSyringe = minSyringe
+	desired.MaxSyringe = maxSyringe
+	desired.Status = status
+
+	var changed []string
+	if found {
+		if codeValue(desired.Code) != codeValue(existing.Code) {
+			changed = append(changed, "code")
+		}
+		if desired.Name != existing.Name {
+			changed = append(changed, "name")
+		}
+		if desired.Icon != existing.Icon {
+			changed = append(changed, "icon")
+		}
+		if desired.Description != existing.Description {
+			changed = append(changed, "description")
+		}
+		if desired.MinSyringe != existing.MinSyringe {
+			changed = append(changed, "min_syringe")
+		}
+		if desired.MaxSyringe != existing.MaxSyringe {
+			changed = append(changed, "max_syringe")
+		}
+		if desired.Status != existing.Status {
+			changed = append(changed, "status")
+		}
+	}
+
+	item := CatalogImportItem{Level: "side_area", Code: code, Name: name}
+	return imp.save(&desired, found, changed, &item)
+}
+
+// findNode looks a node up by code, falling back to its name within the parent.
+// A name match only counts when the existing row has no code or the import has none.
+func (imp *catalogImporter) findNode(dest interface{}, code string, nameQuery string, args ...interface{}) (bool, error) {
+	if code != "" {
+		err := imp.tx.Where("code = ?", code).First(dest).Error
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+	}
+	err := imp.tx.Where(nameQuery, args...).First(dest).Error
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// save creates or updates a node and records the outcome
+func (imp *catalogImporter) save(node interface{}, found bool, changed []string, item *CatalogImportItem) error {
+	switch {
+	case !found:
+		if err := imp.tx.Create(node).Error; err != nil {
+			return err
+		}
+		item.Action = CatalogImportCreate
+	case len(changed) > 0:
+		if err := imp.tx.Save(node).Error; err != nil {
+			return err
+		}
+		item.Action = CatalogImportUpdate
+		item.ChangedFields = changed
+	default:
+		item.Action = CatalogImportUnchanged
+	}
+
+	switch n := node.(type) {
+	case *models.Treatment:
+		item.ID = n.ID
+	case *models.Area:
+		item.ID = n.ID
+	case *models.SideArea:
+		item.ID = n.ID
+	}
+	imp.record(*item)
+	return nil
+}
+
+// skipAreas marks areas under a conflicting treatment as conflicts
+func (imp *catalogImporter) skipAreas(areas []CatalogFileArea, reason string) {
+	for _, a := range areas {
+		imp.conflict("area", a.Code, a.Name, reason)
+		imp.skipSideAreas(a.SideAreas, reason)
+	}
+}
+
+// skipSideAreas marks side areas under a conflicting area as conflicts
+func (imp *catalogImporter) skipSideAreas(sideAreas []CatalogFileSideArea, reason string) {
+	for _, sa := range sideAreas {
+		imp.conflict("side_area", sa.Code, sa.Name, reason)
+	}
+}
+
+// ImportCatalog upserts a parsed catalog file into the draft tables in one transaction.
+// With dryRun the changes are computed and rolled back. Any conflict rolls back the whole
+// import and returns ErrCatalogImportConflicts together with the report.
+func ImportCatalog(tree []CatalogFileTreatment, dryRun bool) (*CatalogImportReport, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	report := &CatalogImportReport{DryRun: dryRun, Items: []CatalogImportItem{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		imp := &catalogImporter{tx: tx, report: report, seen: map[string]bool{}}
+		for _, t := range tree {
+			if err := imp.importTreatment(t); err != nil {
+				return err
+			}
+		}
+		if report.Conflicts > 0 || dryRun {
+			return errCatalogImportRollback
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		report.Applied = true
+		return report, nil
+	case errors.Is(err, errCatalogImportRollback):
+		if report.Conflicts > 0 && !dryRun {
+			return report, ErrCatalogImportConflicts
+		}
+		return report, nil
+	default:
+		return nil, err
+	}
+}
+
+// ExportCatalog returns the draft catalog in the import/export format
+func ExportCatalog() ([]CatalogFileTreatment, error) {
+	tree, err := GetDraftCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]CatalogFileTreatment, 0, len(tree))
+	for _, t := range tree {
+		ft := CatalogFileTreatment{
+			Code:        codeValue(t.Code),
+			Name:        t.Name,
+			Icon:        t.Icon,
+			Description: t.Description,
+			Status:      t.Status,
+		}
+		for _, a := range t.Areas {
+			fa := CatalogFileArea{
+				Code:        codeValue(a.Code),
+				Name:        a.Name,
+				Icon:        a.Icon,
+				Description: a.Description,
+				MinSyringe:  a.MinSyringe,
+				MaxSyringe:  a.MaxSyringe,
+				Status:      a.Status,
+			}
+			for _, sa := range a.SideAreas {
+				fa.SideAreas = append(fa.SideAreas, CatalogFileSideArea{
+					Code:        codeValue(sa.Code),
+					Name:        sa.Name,
+					Icon:        sa.Icon,
+					Description: sa.Description,
+					MinSyringe:  sa.MinSyringe,
+					MaxSyringe:  sa.MaxSyringe,
+					Status:      sa.Status,
+				})
+			}
+			ft.Areas = append(ft.Areas, fa)
+		}
+		out = append(out, ft)
+	}
+	return out, nil
+}
+
+// ExportCatalogCSV renders the draft catalog as CSV, one row per leaf node
+func ExportCatalogCSV() ([]byte, error) {
+	tree, err := ExportCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(catalogCSVHeader); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tree {
+		tCols := []string{t.Code, t.Name, t.Icon, t.Description, t.Status}
+		emptyArea := []string{"", "", "", "", "", "", ""}
+		emptySide := []string{"", "", "", "", "", "", ""}
+		if len(t.Areas) == 0 {
+			if err := w.Write(concatRow(tCols, emptyArea, emptySide)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for _, a := range t.Areas {
+			aCols := []string{a.Code, a.Name, a.Icon, a.Description, strconv.Itoa(a.MinSyringe), strconv.Itoa(a.MaxSyringe), a.Status}
+			if len(a.SideAreas) == 0 {
+				if err := w.Write(concatRow(tCols, aCols, emptySide)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			for _, sa := range a.SideAreas {
+				sCols := []string{sa.Code, sa.Name, sa.Icon, sa.Description, strconv.Itoa(sa.MinSyringe), strconv.Itoa(sa.MaxSyringe), sa.Status}
+				if err := w.Write(concatRow(tCols, aCols, sCols)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// concatRow joins column groups into one CSV row
+func concatRow(parts ...[]string) []string {
+	var row []string
+	for _, p := range parts {
+		row = append(row, p...)
+	}
+	return row
+}
@@ -0,0 +1,761 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"skinSync/config"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== CATALOG VERSIONING ====================
+//
+// The treatments/areas/side_areas tables are the editable draft. Publishing
+// snapshots the draft into catalog_versions; public endpoints always read the
+// latest published snapshot so half-finished edits are never visible.
+// Translations are not part of the snapshot: they are looked up by node id when
+// serving, so translation edits go live immediately for published nodes.
+
+// publishedCatalogCache holds the decoded latest published snapshot
+var (
+	publishedCatalogMu      sync.RWMutex
+	publishedCatalogVersion uint64
+	publishedCatalogTree    []models.Treatment
+)
+
+// CatalogVersionDTO describes a published catalog version
+type CatalogVersionDTO struct {
+	ID             uint64 `json:"id"`
+	Version        int    `json:"version"`
+	Notes          string `json:"notes,omitempty"`
+	PublishedBy    uint64 `json:"published_by"`
+	RolledBackFrom *int   `json:"rolled_back_from,omitempty"`
+	PublishedAt    string `json:"published_at"`
+	Treatments     int    `json:"treatments"`
+	Areas          int    `json:"areas"`
+	SideAreas      int    `json:"side_areas"`
+}
+
+// CatalogDiffItem is a single node that differs between draft and published
+type CatalogDiffItem struct {
+	Level         string   `json:"level"` // treatment, area, side_area
+	ID            uint     `json:"id"`
+	Name          string   `json:"name"`
+	ParentID      uint     `json:"parent_id,omitempty"`
+	ChangedFields []string `json:"changed_fields,omitempty"`
+}
+
+// CatalogDiff lists draft changes relative to the latest published version
+type CatalogDiff struct {
+	BaseVersion int               `json:"base_version"` // 0 when nothing has been published yet
+	Added       []CatalogDiffItem `json:"added"`
+	Removed     []CatalogDiffItem `json:"removed"`
+	Changed     []CatalogDiffItem `json:"changed"`
+	HasChanges  bool              `json:"has_changes"`
+}
+
+// loadDraftCatalog reads the full treatment tree (including archived nodes) from the draft tables
+func loadDraftCatalog(db *gorm.DB) ([]models.Treatment, error) {
+	var treatments []models.Treatment
+	if err := db.Preload("Areas", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id")
+	}).Preload("Areas.SideAreas", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("id")
+	}).Order("id").Find(&treatments).Error; err != nil {
+		return nil, err
+	}
+	return treatments, nil
+}
+
+// latestCatalogVersion returns the most recent published version, or nil when none exists
+func latestCatalogVersion(db *gorm.DB) (*models.CatalogVersion, error) {
+	var v models.CatalogVersion
+	err := db.Order("version DESC").First(&v).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// decodeCatalogSnapshot decodes a stored snapshot into a treatment tree
+func decodeCatalogSnapshot(v *models.CatalogVersion) ([]models.Treatment, error) {
+	var tree []models.Treatment
+	if err := json.Unmarshal([]byte(v.Snapshot), &tree); err != nil {
+		return nil, fmt.Errorf("corrupt catalog snapshot v%d: %w", v.Version, err)
+	}
+	return tree, nil
+}
+
+// copyCatalogTree copies a treatment tree down to its side areas so callers can modify the result
+// without touching the cached snapshot
+func copyCatalogTree(tree []models.Treatment) []models.Treatment {
+	out := make([]models.Treatment, len(tree))
+	for i, t := range tree {
+		if t.Areas != nil {
+			areas := make([]models.Area, len(t.Areas))
+			for j, a := range t.Areas {
+				if a.SideAreas != nil {
+					a.SideAreas = append([]models.SideArea(nil), a.SideAreas...)
+				}
+				areas[j] = a
+			}
+			t.Areas = areas
+		}
+		out[i] = t
+	}
+	return out
+}
+
+// GetPublishedCatalog returns a copy of the treatment tree that public endpoints should serve.
+// The catalog is empty until the first version is published.
+func GetPublishedCatalog() ([]models.Treatment, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	latest, err := latestCatalogVersion(db.Select("id", "version"))
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return []models.Treatment{}, nil
+	}
+
+	publishedCatalogMu.RLock()
+	if publishedCatalogVersion == latest.ID {
+		tree := copyCatalogTree(publishedCatalogTree)
+		publishedCatalogMu.RUnlock()
+		return tree, nil
+	}
+	publishedCatalogMu.RUnlock()
+
+	var full models.CatalogVersion
+	if err := db.First(&full, latest.ID).Error; err != nil {
+		return nil, err
+	}
+	tree, err := decodeCatalogSnapshot(&full)
+	if err != nil {
+		return nil, err
+	}
+
+	publishedCatalogMu.Lock()
+	publishedCatalogVersion = full.ID
+	publishedCatalogTree = tree
+	publishedCatalogMu.Unlock()
+
+	return copyCatalogTree(tree), nil
+}
+
+// GetDraftCatalog returns the editable treatment tree including archived nodes
+func GetDraftCatalog() ([]models.Treatment, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return loadDraftCatalog(db)
+}
+
+// flattenedCatalog indexes a tree by level and id for diffing
+type flattenedCatalog struct {
+	treatments map[uint]models.Treatment
+	areas      map[uint]models.Area
+	sideAreas  map[uint]models.SideArea
+}
+
+func flattenCatalog(tree []models.Treatment) flattenedCatalog {
+	f := flattenedCatalog{
+		treatments: make(map[uint]models.Treatment),
+		areas:      make(map[uint]models.Area),
+		sideAreas:  make(map[uint]models.SideArea),
+	}
+	for _, t := range tree {
+		for _, a := range t.Areas {
+			for _, sa := range a.SideAreas {
+				f.sideAreas[sa.ID] = sa
+			}
+			f.areas[a.ID] = a
+		}
+		f.treatments[t.ID] = t
+	}
+	return f
+}
+
+// codeValue dereferences an optional catalog code
+func codeValue(code *string) string {
+	if code == nil {
+		return ""
+	}
+	return *code
+}
+
+// diffCatalog compares a draft tree against a published tree
+func diffCatalog(draft, published []models.Treatment) CatalogDiff {
+	d := flattenCatalog(draft)
+	p := flattenCatalog(published)
+	diff := CatalogDiff{Added: []CatalogDiffItem{}, Removed: []CatalogDiffItem{}, Changed: []CatalogDiffItem{}}
+
+	for id, t := range d.treatments {
+		old, ok := p.treatments[id]
+		if !ok {
+			diff.Added = append(diff.Added, CatalogDiffItem{Level: "treatment", ID: id, Name: t.Name})
+			continue
+		}
+		var changed []string
+		if codeValue(t.Code) != codeValue(old.Code) {
+			changed = append(changed, "code")
+		}
+		if t.Name != old.Name {
+			changed = append(changed, "name")
+		}
+		if t.Icon != old.Icon {
+			changed = append(changed, "icon")
+		}
+		if t.Description != old.Description {
+			changed = append(changed, "description")
+		}
+		if t.IsArea != old.IsArea {
+			changed = append(changed, "is_area")
+		}
+		if t.DurationMinutes != old.DurationMinutes {
+			changed = append(changed, "duration_minutes")
+		}
+		if t.Status != old.Status {
+			changed = append(changed, "status")
+		}
+		if len(changed) > 0 {
+			diff.Changed = append(diff.Changed, CatalogDiffItem{Level: "treatment", ID: id, Name: t.Name, ChangedFields: changed})
+		}
+	}
+	for id, t := range p.treatments {
+		if _, ok := d.treatments[id]; !ok {
+			diff.Removed = append(diff.Removed, CatalogDiffItem{Level: "treatment", ID: id, Name: t.Name})
+		}
+	}
+
+	for id, a := range d.areas {
+		old, ok := p.areas[id]
+		if !ok {
+			diff.Added = append(diff.Added, CatalogDiffItem{Level: "area", ID: id, Name: a.Name, ParentID: a.TreatmentID})
+			continue
+		}
+		var changed []string
+		if a.TreatmentID != old.TreatmentID {
+			changed = append(changed, "treatment_id")
+		}
+		if codeValue(a.Code) != codeValue(old.Code) {
+			changed = append(changed, "code")
+		}
+		if a.Name != old.Name {
+			changed = append(changed, "name")
+		}
+		if a.Icon != old.Icon {
+			changed = append(changed, "icon")
+		}
+		if a.Description != old.Description {
+			changed = append(changed, "description")
+		}
+		if a.IsSideArea != old.IsSideArea {
+			changed = append(changed, "is_sidearea")
+		}
+		if a.MinSyringe != old.MinSyringe {
+			changed = append(changed, "min_syringe")
+		}
+		if a.MaxSyringe != old.MaxSyringe {
+			changed = append(changed, "max_syringe")
+		}
+		if a.DurationMinutes != old.DurationMinutes {
+			changed = append(changed, "duration_minutes")
+		}
+		if a.ExtraSyringeMinutes != old.ExtraSyringeMinutes {
+			changed = append(changed, "extra_syringe_minutes")
+		}
+		if a.Status != old.Status {
+			changed = append(changed, "status")
+		}
+		if len(changed) > 0 {
+			diff.Changed = append(diff.Changed, CatalogDiffItem{Level: "area", ID: id, Name: a.Name, ParentID: a.TreatmentID, ChangedFields: changed})
+		}
+	}
+	for id, a := range p.areas {
+		if _, ok := d.areas[id]; !ok {
+			diff.Removed = append(diff.Removed, CatalogDiffItem{Level: "area", ID: id, Name: a.Name, ParentID: a.TreatmentID})
+		}
+	}
+
+	for id, sa := range d.sideAreas {
+		old, ok := p.sideAreas[id]
+		if !ok {
+			diff.Added = append(diff.Added, CatalogDiffItem{Level: "side_area", ID: id, Name: sa.Name, ParentID: sa.AreaID})
+			continue
+		}
+		var changed []string
+		if sa.AreaID != old.AreaID {
+			changed = append(changed, "area_id")
+		}
+		if codeValue(sa.Code) != codeValue(old.Code) {
+			changed = append(changed, "code")
+		}
+		if sa.Name != old.Name {
+			changed = append(changed, "name")
+		}
+		if sa.Icon != old.Icon {
+			changed = append(changed, "icon")
+		}
+		if sa.Description != old.Description {
+			changed = append(changed, "description")
+		}
+		if sa.MinSyringe != old.MinSyringe {
+			changed = append(changed, "min_syringe")
+		}
+		if sa.MaxSyringe != old.MaxSyringe {
+			changed = append(changed, "max_syringe")
+		}
+		if sa.DurationMinutes != old.DurationMinutes {
+			changed = append(changed, "duration_minutes")
+		}
+		if sa.ExtraSyringeMinutes != old.ExtraSyringeMinutes {
+			changed = append(changed, "extra_syringe_minutes")
+		}
+		if sa.Status != old.Status {
+			changed = append(changed, "status")
+		}
+		if len(changed) > 0 {
+			diff.Changed = append(diff.Changed, CatalogDiffItem{Level: "side_area", ID: id, Name: sa.Name, ParentID: sa.AreaID, ChangedFields: changed})
+		}
+	}
+	for id, sa := range p.sideAreas {
+		if _, ok := d.sideAreas[id]; !ok {
+			diff.Removed = append(diff.Removed, CatalogDiffItem{Level: "side_area", ID: id, Name: sa.Name, ParentID: sa.AreaID})
+		}
+	}
+
+	levelOrder := map[string]int{"treatment": 0, "area": 1, "side_area": 2}
+	for _, items := range [][]CatalogDiffItem{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Level != items[j].Level {
+				return levelOrder[items[i].Level] < levelOrder[items[j].Level]
+			}
+			return items[i].ID < items[j].ID
+		})
+	}
+
+	diff.HasChanges = len(diff.Added)+len(diff.Removed)+len(diff.Changed) > 0
+	return diff
+}
+
+// GetCatalogDraftDiff previews draft changes against the latest published version
+func GetCatalogDraftDiff() (*CatalogDiff, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	draft, err := loadDraftCatalog(db)
+	if err != nil {
+		return nil, err
+	}
+	draft = withoutDeletedNodes(draft)
+
+	latest, err := latestCatalogVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var published []models.Treatment
+	baseVersion := 0
+	if latest != nil {
+		baseVersion = latest.Version
+		if published, err = decodeCatalogSnapshot(latest); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := diffCatalog(draft, published)
+	diff.BaseVersion = baseVersion
+	return &diff, nil
+}
+
+// withoutDeletedNodes drops nodes staged for deletion from a draft tree
+func withoutDeletedNodes(tree []models.Treatment) []models.Treatment {
+	out := make([]models.Treatment, 0, len(tree))
+	for _, t := range tree {
+		if t.Status == models.CatalogStatusDeleted {
+			continue
+		}
+		areas := make([]models.Area, 0, len(t.Areas))
+		for _, a := range t.Areas {
+			if a.Status == models.CatalogStatusDeleted {
+				continue
+			}
+			sideAreas := make([]models.SideArea, 0, len(a.SideAreas))
+			for _, sa := range a.SideAreas {
+				if sa.Status != models.CatalogStatusDeleted {
+					sideAreas = append(sideAreas, sa)
+				}
+			}
+			a.SideAreas = sideAreas
+			areas = append(areas, a)
+		}
+		t.Areas = areas
+		out = append(out, t)
+	}
+	return out
+}
+
+// applyStagedCatalogDeletes removes the nodes staged for deletion in the draft, with everything
+// below them and their translations. A node that gained clinic prices or doctor assignments since
+// it was staged blocks the publish.
+func applyStagedCatalogDeletes(tx *gorm.DB) error {
+	var treatmentIDs, areaIDs, sideAreaIDs []uint
+	for _, level := range []struct {
+		model  interface{}
+		column string
+		ids    *[]uint
+	}{
+		{&models.Treatment{}, "treatment_id", &treatmentIDs},
+		{&models.Area{}, "area_id", &areaIDs},
+		{&models.SideArea{}, "side_area_id", &sideAreaIDs},
+	} {
+		if err := tx.Model(level.model).Where("status = ?", models.CatalogStatusDeleted).Pluck("id", level.ids).Error; err != nil {
+			return err
+		}
+		for _, id := range *level.ids {
+			inUse, err := catalogUsageCount(tx, level.column, id)
+			if err != nil {
+				return err
+			}
+			if inUse > 0 {
+				return fmt.Errorf("%w (%s %d is staged for deletion)", ErrCatalogInUse, strings.TrimSuffix(level.column, "_id"), id)
+			}
+		}
+	}
+	if len(treatmentIDs)+len(areaIDs)+len(sideAreaIDs) == 0 {
+		return nil
+	}
+
+	sideAreas := func() *gorm.DB {
+		return tx.Model(&models.SideArea{}).
+			Where("id IN ? OR area_id IN ? OR treatment_id IN ?", sideAreaIDs, areaIDs, treatmentIDs)
+	}
+	areas := func() *gorm.DB {
+		return tx.Model(&models.Area{}).Where("id IN ? OR treatment_id IN ?", areaIDs, treatmentIDs)
+	}
+	if err := deleteEntityTranslations(tx, TranslationEntitySideArea, sideAreas().Select("id")); err != nil {
+		return err
+	}
+	if err := deleteEntityTranslations(tx, TranslationEntityArea, areas().Select("id")); err != nil {
+		return err
+	}
+	if err := deleteEntityTranslations(tx, TranslationEntityTreatment, treatmentIDs); err != nil {
+		return err
+	}
+	if err := sideAreas().Delete(&models.SideArea{}).Error; err != nil {
+		return err
+	}
+	if err := areas().Delete(&models.Area{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN ?", treatmentIDs).Delete(&models.Treatment{}).Error
+}
+
+// createCatalogVersion applies staged deletes and snapshots the draft tables into a new version inside tx
+func createCatalogVersion(tx *gorm.DB, adminID uint64, notes string, rolledBackFrom *int) (*models.CatalogVersion, error) {
+	// Lock the latest version row so concurrent publishes serialize on the version number
+	var last models.CatalogVersion
+	nextVersion := 1
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Order("version DESC").First(&last).Error
+	if err == nil {
+		nextVersion = last.Version + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := applyStagedCatalogDeletes(tx); err != nil {
+		return nil, err
+	}
+	tree, err := loadDraftCatalog(tx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	v := models.CatalogVersion{
+		Version:     nextVersion,
+		Snapshot:    string(snapshot),
+		Notes:       notes,
+		PublishedBy: adminID,
+		RolledBack:  rolledBackFrom,
+	}
+	if err := tx.Create(&v).Error; err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// PublishCatalog atomically publishes the current draft as a new catalog version
+func PublishCatalog(adminID uint64, notes string) (*CatalogVersionDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var v *models.CatalogVersion
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		v, err = createCatalogVersion(tx, adminID, notes, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto, err := catalogVersionToDTO(v)
+	if err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
+// ListCatalogVersions returns published versions, newest first
+func ListCatalogVersions() ([]CatalogVersionDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var versions []models.CatalogVersion
+	if err := db.Order("version DESC").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]CatalogVersionDTO, 0, len(versions))
+	for i := range versions {
+		dto, err := catalogVersionToDTO(&versions[i])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *dto)
+	}
+	return list, nil
+}
+
+// RollbackCatalog restores the draft tables to a previous version and publishes it as a new version.
+// Nodes created after that version are archived rather than deleted so clinic prices keep their references.
+func RollbackCatalog(version int, adminID uint64) (*CatalogVersionDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var target models.CatalogVersion
+	if err := db.Where("version = ?", version).First(&target).Error; err != nil {
+		return nil, err
+	}
+	tree, err := decodeCatalogSnapshot(&target)
+	if err != nil {
+		return nil, err
+	}
+
+	var v *models.CatalogVersion
+	err = db.Transaction(func(tx *gorm.DB) error {
+		treatmentIDs := []uint{}
+		areaIDs := []uint{}
+		sideAreaIDs := []uint{}
+
+		for _, t := range tree {
+			areas := t.Areas
+			t.Areas = nil
+			if err := tx.Omit(clause.Associations).Save(&t).Error; err != nil {
+				return fmt.Errorf("restore treatment %d: %w", t.ID, err)
+			}
+			treatmentIDs = append(treatmentIDs, t.ID)
+
+			for _, a := range areas {
+				sideAreas := a.SideAreas
+				a.SideAreas = nil
+				if err := tx.Omit(clause.Associations).Save(&a).Error; err != nil {
+					return fmt.Errorf("restore area %d: %w", a.ID, err)
+				}
+				areaIDs = append(areaIDs, a.ID)
+
+				for _, sa := range sideAreas {
+					if err := tx.Omit(clause.Associations).Save(&sa).Error; err != nil {
+						return fmt.Errorf("restore side area %d: %w", sa.ID, err)
+					}
+					sideAreaIDs = append(sideAreaIDs, sa.ID)
+				}
+			}
+		}
+
+		if err := archiveCatalogNodesNotIn(tx, &models.Treatment{}, treatmentIDs); err != nil {
+			return err
+		}
+		if err := archiveCatalogNodesNotIn(tx, &models.Area{}, areaIDs); err != nil {
+			return err
+		}
+		if err := archiveCatalogNodesNotIn(tx, &models.SideArea{}, sideAreaIDs); err != nil {
+			return err
+		}
+
+		v, err = createCatalogVersion(tx, adminID, fmt.Sprintf("rollback to version %d", version), &target.Version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return catalogVersionToDTO(v)
+}
+
+// archiveCatalogNodesNotIn archives every row of model whose id is not in ids.
+// Nodes staged for deletion stay staged.
+func archiveCatalogNodesNotIn(tx *gorm.DB, model interface{}, ids []uint) error {
+	q := tx.Model(model).Where("status <> ?", models.CatalogStatusDeleted)
+	if len(ids) > 0 {
+		q = q.Where("id NOT IN ?", ids)
+	} else {
+		q = q.Where("1 = 1")
+	}
+	return q.Update("status", models.CatalogStatusArchived).Error
+}
+
+// catalogVersionToDTO summarises a version with node counts
+func catalogVersionToDTO(v *models.CatalogVersion) (*CatalogVersionDTO, error) {
+	tree, err := decodeCatalogSnapshot(v)
+	if err != nil {
+		return nil, err
+	}
+	f := flattenCatalog(tree)
+	return &CatalogVersionDTO{
+		ID:             v.ID,
+		Version:        v.Version,
+		Notes:          v.Notes,
+		PublishedBy:    v.PublishedBy,
+		RolledBackFrom: v.RolledBack,
+		PublishedAt:    v.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Treatments:     len(f.treatments),
+		Areas:          len(f.areas),
+		SideAreas:      len(f.sideAreas),
+	}, nil
+}
+
+// publishedActiveTreatments returns active treatments from the published catalog
+func publishedActiveTreatments() ([]models.Treatment, error) {
+	tree, err := GetPublishedCatalog()
+	if err != nil {
+		return nil, err
+	}
+	var list []models.Treatment
+	for _, t := range tree {
+		if t.Status == models.CatalogStatusActive {
+			list = append(list, t)
+		}
+	}
+	return list, nil
+}
+
+// publishedActiveAreas returns active areas of an active treatment from the published catalog
+func publishedActiveAreas(treatmentID uint) ([]models.Area, error) {
+	treatments, err := publishedActiveTreatments()
+	if err != nil {
+		return nil, err
+	}
+	var list []models.Area
+	for _, t := range treatments {
+		if t.ID != treatmentID {
+			continue
+		}
+		for _, a := range t.Areas {
+			if a.Status == models.CatalogStatusActive {
+				list = append(list, a)
+			}
+		}
+	}
+	return list, nil
+}
+
+// publishedActiveSideAreas returns active side areas of a treatment from the published catalog.
+// areaID 0 returns side areas across all areas of the treatment.
+func publishedActiveSideAreas(treatmentID, areaID uint) ([]models.SideArea, error) {
+	areas, err := publishedActiveAreas(treatmentID)
+	if err != nil {
+		return nil, err
+	}
+	var list []models.SideArea
+	for _, a := range areas {
+		if areaID != 0 && a.ID != areaID {
+			continue
+		}
+		for _, sa := range a.SideAreas {
+			if sa.Status == models.CatalogStatusActive {
+				list = append(list, sa)
+			}
+		}
+	}
+	return list, nil
+}
+
+// publishedCatalogIndex indexes the published catalog by id for booking and discovery.
+// Side areas carry their parent area so area-level defaults resolve.
+type publishedCatalogIndex struct {
+	treatments map[uint]models.Treatment
+	areas      map[uint]models.Area
+	sideAreas  map[uint]models.SideArea
+}
+
+// loadPublishedCatalogIndex indexes the latest published catalog, archived nodes included
+func loadPublishedCatalogIndex() (*publishedCatalogIndex, error) {
+	tree, err := GetPublishedCatalog()
+	if err != nil {
+		return nil, err
+	}
+	idx := &publishedCatalogIndex{
+		treatments: make(map[uint]models.Treatment),
+		areas:      make(map[uint]models.Area),
+		sideAreas:  make(map[uint]models.SideArea),
+	}
+	for _, t := range tree {
+		for _, a := range t.Areas {
+			area := a
+			area.SideAreas = nil
+			for _, sa := range a.SideAreas {
+				sa.Area = area
+				idx.sideAreas[sa.ID] = sa
+			}
+			idx.areas[a.ID] = area
+		}
+		idx.treatments[t.ID] = t
+	}
+	return idx, nil
+}
+
+// activeTreatment returns a treatment customers can see and book
+func (idx *publishedCatalogIndex) activeTreatment(id uint) (models.Treatment, bool) {
+	t, ok := idx.treatments[id]
+	return t, ok && t.Status == models.CatalogStatusActive
+}
+
+// activeSideArea returns a bookable side area of a treatment; its area and treatment must be active too
+func (idx *publishedCatalogIndex) activeSideArea(treatmentID, id uint) (models.SideArea, bool) {
+	sa, ok := idx.sideAreas[id]
+	if !ok || sa.TreatmentID != treatmentID || sa.Status != models.CatalogStatusActive ||
+		sa.Area.Status != models.CatalogStatusActive {
+		return sa, false
+	}
+	_, ok = idx.activeTreatment(treatmentID)
+	return sa, ok
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== ADMIN CLINIC LIFECYCLE ====================
+
+// ErrClinicNotDeleted is returned when restoring a clinic that is not deleted
+var ErrClinicNotDeleted = errors.New("clinic is not deleted")
+
+// ListClinics returns clinics filtered by name/email search and status.
+// deleted follows the customer search convention: "", "include" or "only".
+func ListClinics(term, status, deleted string, page, pageSize int) (*resdto.AdminClinicListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.Clinic{})
+	switch deleted {
+	case CustomerDeletedInclude:
+		query = query.Unscoped()
+	case CustomerDeletedOnly:
+		query = query.Unscoped().Where("clinics.deleted_at IS NOT NULL")
+	}
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		query = query.Where("clinics.name LIKE ? OR clinics.email LIKE ? OR clinics.phone LIKE ?", like, like, like)
+	}
+	if status != "" {
+		query = query.Where("clinics.status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var clinics []models.Clinic
+	if err := query.Order("clinics.id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+
+	items, err := adminClinicRows(db, clinics)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.AdminClinicListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// adminClinicRows builds listing rows with staff and treatment counts
+func adminClinicRows(db *gorm.DB, clinics []models.Clinic) ([]resdto.AdminClinicDTO, error) {
+	items := make([]resdto.AdminClinicDTO, 0, len(clinics))
+	if len(clinics) == 0 {
+		return items, nil
+	}
+
+	ids := make([]uint64, 0, len(clinics))
+	for _, c := range clinics {
+		ids = append(ids, c.ID)
+	}
+
+	type countRow struct {
+		ClinicID uint64
+		Total    int64
+	}
+	var staff, treatments []countRow
+	if err := db.Model(&models.ClinicUser{}).Select("clinic_id, COUNT(*) AS total").
+		Where("clinic_id IN ?", ids).Group("clinic_id").Scan(&staff).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.ClinicTreatment{}).Select("clinic_id, COUNT(*) AS total").
+		Where("clinic_id IN ?", ids).Group("clinic_id").Scan(&treatments).Error; err != nil {
+		return nil, err
+	}
+	staffCount := make(map[uint64]int64, len(staff))
+	for _, r := range staff {
+		staffCount[r.ClinicID] = r.Total
+	}
+	treatmentCount := make(map[uint64]int64, len(treatments))
+	for _, r := range treatments {
+		treatmentCount[r.ClinicID] = r.Total
+	}
+
+	for _, c := range clinics {
+		row := resdto.AdminClinicDTO{
+			ClinicDTO: resdto.ClinicDTO{
+				ID:      c.ID,
+				Name:    c.Name,
+				Email:   c.Email,
+				Phone:   c.Phone,
+				Address: c.Address,
+				Logo:    c.Logo,
+				Status:  c.Status,
+			},
+			StaffCount:     staffCount[c.ID],
+			TreatmentCount: treatmentCount[c.ID],
+			CreatedAt:      c.CreatedAt,
+			UpdatedAt:      c.UpdatedAt,
+		}
+		if c.DeletedAt.Valid {
+			deletedAt := c.DeletedAt.Time
+			row.DeletedAt = &deletedAt
+		}
+		items = append(items, row)
+	}
+	return items, nil
+}
+
+// GetClinicAdminDetail returns a clinic (including deleted ones) with its owners
+func GetClinicAdminDetail(clinicID uint64) (*resdto.AdminClinicDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var clinic models.Clinic
+	if err := db.Unscoped().First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+
+	rows, err := adminClinicRows(db, []models.Clinic{clinic})
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []models.ClinicUser
+	if err := db.Preload("Role").
+		Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
+		Where("clinic_users.clinic_id = ? AND clinic_roles.name = ?", clinicID, models.ClinicRoleOwner).
+		Find(&owners).Error; err != nil {
+		return nil, err
+	}
+
+	detail := &resdto.AdminClinicDetailDTO{AdminClinicDTO: rows[0], Owners: []resdto.ClinicUserDTO{}}
+	for _, o := range owners {
+		detail.Owners = append(detail.Owners, resdto.ClinicUserDTO{
+			ID:       o.ID,
+			ClinicID: o.ClinicID,
+			Email:    o.Email,
+			Name:     o.Name,
+			Role:     o.Role.Name,
+			Status:   o.Status,
+		})
+	}
+	return detail, nil
+}
+
+// AdminUpdateClinic edits clinic details
+func AdminUpdateClinic(clinicID uint64, req reqdto.AdminUpdateClinicRequest) (*resdto.AdminClinicDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var name, email string
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, errors.New("name must be at least 2 characters")
+		}
+	}
+	if req.Email != nil {
+		email = strings.ToLower(strings.TrimSpace(*req.Email))
+		if !strings.Contains(email, "@") {
+			return nil, errors.New("invalid email")
+		}
+		var existing models.Clinic
+		if err := db.Unscoped().Where("email = ? AND id <> ?", email, clinicID).First(&existing).Error; err == nil {
+			return nil, errors.New("clinic email already exists")
+		}
+	}
+
+	// Admin edits skip the public profile review
+	update := ClinicSettingsUpdate{
+		Public: models.ClinicPublicProfile{
+			Phone:        req.Phone,
+			Logo:         req.Logo,
+			Description:  req.Description,
+			Website:      req.Website,
+			AddressLine1: req.AddressLine1,
+			AddressLine2: req.AddressLine2,
+			City:         req.City,
+			State:        req.State,
+			PostalCode:   req.PostalCode,
+			Country:      req.Country,
+			SocialLinks:  req.SocialLinks,
+		},
+		Timezone: req.Timezone,
+		Currency: req.Currency,
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Lock the row and write only edited columns so a concurrent status change or delete is kept
+		var clinic models.Clinic
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&clinic, clinicID).Error; err != nil {
+			return err
+		}
+		columns := append(append([]string{}, clinicProfileColumns...), clinicSettingsColumns...)
+		if req.Name != nil {
+			clinic.Name = name
+		}
+		if req.Email != nil {
+			clinic.Email = email
+			columns = append(columns, "email")
+		}
+		if req.Address != nil {
+			clinic.Address = *req.Address
+		}
+		applyPublicProfile(&clinic, update.Public)
+		update.applySettings(&clinic)
+		return tx.Model(&clinic).Select(columns).Updates(&clinic).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetClinicAdminDetail(clinicID)
+}
+
+// SetClinicStatus moves a clinic between active, inactive and suspended.
+// Leaving active hides the clinic from discovery and signs all its staff out.
+func SetClinicStatus(clinicID uint64, status string) (*resdto.AdminClinicDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	switch status {
+	case models.ClinicStatusActive, models.ClinicStatusInactive, models.ClinicStatusSuspended:
+	default:
+		return nil, errors.New("status must be 'active', 'inactive' or 'suspended'")
+	}
+
+	var clinic models.Clinic
+	if err := db.First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&clinic).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+	if status != models.ClinicStatusActive {
+		if err := RevokeClinicSessions(clinicID); err != nil {
+			return nil, err
+		}
+	}
+	return GetClinicAdminDetail(clinicID)
+}
+
+// DeleteClinic soft-deletes a clinic and signs all its staff out.
+// Staff, prices and assignments are kept so the clinic can be restored.
+func DeleteClinic(clinicID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var clinic models.Clinic
+	if err := db.First(&clinic, clinicID).Error; err != nil {
+		return err
+	}
+	if err := db.Delete(&clinic).Error; err != nil {
+		return err
+	}
+	return RevokeClinicSessions(clinicID)
+}
+
+// RestoreClinic undoes a soft delete. The clinic keeps its previous status.
+func RestoreClinic(clinicID uint64) (*resdto.AdminClinicDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var clinic models.Clinic
+	if err := db.Unscoped().First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	if !clinic.DeletedAt.Valid {
+		return nil, ErrClinicNotDeleted
+	}
+	if err := db.Unscoped().Model(&clinic).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
+	}
+	return GetClinicAdminDetail(clinicID)
+}
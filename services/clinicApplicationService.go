@@ -0,0 +1,548 @@
+package services
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"gorm.io/gorm"
+)
+
+// ==================== CLINIC APPLICATIONS ====================
+
+var (
+	// ErrClinicApplicationExists is returned when an open application already exists for the clinic email
+	ErrClinicApplicationExists = errors.New("an application for this clinic email is already under review")
+	// ErrClinicApplicationOwnerLimit is returned when the owner email already has the maximum number of open applications
+	ErrClinicApplicationOwnerLimit = errors.New("too many applications are already under review for this owner email")
+	// ErrClinicApplicationEmailTaken is returned when the clinic or owner email already belongs to a registered clinic
+	ErrClinicApplicationEmailTaken = errors.New("clinic or owner email is already registered")
+	// ErrClinicApplicationClosed is returned when acting on an approved or rejected application
+	ErrClinicApplicationClosed = errors.New("application has already been approved or rejected")
+	// ErrClinicApplicationNotAwaitingInfo is returned when the applicant replies without an open information request
+	ErrClinicApplicationNotAwaitingInfo = errors.New("application is not waiting for more information")
+)
+
+const (
+	maxClinicApplicationDocuments    = 10
+	maxClinicApplicationDocumentSize = 10 << 20
+	// maxOpenClinicApplicationsPerOwner caps pending/info-requested applications per owner email
+	maxOpenClinicApplicationsPerOwner = 3
+	// clinicApplicationEmailCooldown is the minimum gap between "submitted" emails to one owner email
+	clinicApplicationEmailCooldown = 24 * time.Hour
+)
+
+// clinicApplicationDocumentTypes are the accepted document extensions
+var clinicApplicationDocumentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+// clinicApplicationDir returns where uploaded documents are stored (CLINIC_APPLICATION_DIR, default "uploads/clinic-applications").
+// Must not be under STATIC_DIR: documents are only served through the admin API.
+func clinicApplicationDir() string {
+	if dir := strings.TrimSpace(os.Getenv("CLINIC_APPLICATION_DIR")); dir != "" {
+		return dir
+	}
+	return filepath.Join("uploads", "clinic-applications")
+}
+
+// validateClinicApplication trims the submitted form and checks required fields
+func validateClinicApplication(req *reqdto.SubmitClinicApplicationRequest) error {
+	for _, f := range []*string{&req.ClinicName, &req.ClinicEmail, &req.ClinicPhone, &req.ClinicAddress, &req.ClinicLogo,
+		&req.LicenseNumber, &req.OwnerName, &req.OwnerEmail, &req.OwnerPhone, &req.Message} {
+		*f = strings.TrimSpace(*f)
+	}
+	req.ClinicEmail = strings.ToLower(req.ClinicEmail)
+	req.OwnerEmail = strings.ToLower(req.OwnerEmail)
+
+	if len(req.ClinicName) < 2 {
+		return errors.New("clinic_name must be at least 2 characters")
+	}
+	if len(req.OwnerName) < 2 {
+		return errors.New("owner_name must be at least 2 characters")
+	}
+	if req.LicenseNumber == "" {
+		return errors.New("license_number is required")
+	}
+	if _, err := mail.ParseAddress(req.ClinicEmail); err != nil {
+		return errors.New("clinic_email is invalid")
+	}
+	if _, err := mail.ParseAddress(req.OwnerEmail); err != nil {
+		return errors.New("owner_email is invalid")
+	}
+	return nil
+}
+
+// validateApplicationDocuments checks the count, size and type of uploaded documents
+func validateApplicationDocuments(files []*multipart.FileHeader) error {
+	if len(files) > maxClinicApplicationDocuments {
+		return fmt.Errorf("at most %d documents can be uploaded at once", maxClinicApplicationDocuments)
+	}
+	for _, fh := range files {
+		if fh.Size > maxClinicApplicationDocumentSize {
+			return fmt.Errorf("%s exceeds the %d MB limit", fh.Filename, maxClinicApplicationDocumentSize>>20)
+		}
+		if _, ok := clinicApplicationDocumentTypes[strings.ToLower(filepath.Ext(fh.Filename))]; !ok {
+			return fmt.Errorf("%s must be a PDF, JPG or PNG file", fh.Filename)
+		}
+	}
+	return nil
+}
+
+// saveApplicationDocuments writes uploads to disk and records them in tx.
+// The returned paths let the caller remove the files if the transaction rolls back.
+func saveApplicationDocuments(tx *gorm.DB, applicationID uint64, files []*multipart.FileHeader) ([]string, error) {
+	dir := filepath.Join(clinicApplicationDir(), fmt.Sprintf("application-%d", applicationID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, fh := range files {
+		ext := strings.ToLower(filepath.Ext(fh.Filename))
+		suffix, err := utils.NewSecretToken()
+		if err != nil {
+			return written, err
+		}
+		path := filepath.Join(dir, suffix[:16]+ext)
+
+		src, err := fh.Open()
+		if err != nil {
+			return written, err
+		}
+		dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+		if err != nil {
+			src.Close()
+			return written, err
+		}
+		written = append(written, path)
+		size, err := io.Copy(dst, io.LimitReader(src, maxClinicApplicationDocumentSize+1))
+		src.Close()
+		if cerr := dst.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return written, err
+		}
+		if size > maxClinicApplicationDocumentSize {
+			return written, fmt.Errorf("%s exceeds the %d MB limit", fh.Filename, maxClinicApplicationDocumentSize>>20)
+		}
+
+		doc := models.ClinicApplicationDocument{
+			ApplicationID: applicationID,
+			FileName:      filepath.Base(fh.Filename),
+			ContentType:   clinicApplicationDocumentTypes[ext],
+			Size:          size,
+			FilePath:      path,
+		}
+		if err := tx.Create(&doc).Error; err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// removeFiles deletes files written before a failed transaction
+func removeFiles(paths []string) {
+	for _, p := range paths {
+		_ = os.Remove(p)
+	}
+}
+
+// notifyClinicApplicant emails the applicant; failures are logged, not returned
+func notifyClinicApplicant(app *models.ClinicApplication, status string) {
+	if err := utils.SendClinicApplicationStatusEmail(app.OwnerEmail, app.OwnerName, app.ClinicName, app.ID, status, app.ReviewNote); err != nil {
+		log.Printf("clinic application %d: failed to send %s email: %v", app.ID, status, err)
+	}
+}
+
+// SubmitClinicApplication stores a public clinic application with its license documents.
+// Returns the application and the plain access token the applicant uses to follow it up.
+func SubmitClinicApplication(req reqdto.SubmitClinicApplicationRequest, files []*multipart.FileHeader) (*resdto.SubmitClinicApplicationResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateClinicApplication(&req); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("at least one license document is required")
+	}
+	if err := validateApplicationDocuments(files); err != nil {
+		return nil, err
+	}
+
+	var taken int64
+	if err := db.Unscoped().Model(&models.Clinic{}).Where("email = ?", req.ClinicEmail).Count(&taken).Error; err != nil {
+		return nil, err
+	}
+	if taken == 0 {
+		if err := db.Model(&models.ClinicUser{}).Where("email = ?", req.OwnerEmail).Count(&taken).Error; err != nil {
+			return nil, err
+		}
+	}
+	if taken > 0 {
+		return nil, ErrClinicApplicationEmailTaken
+	}
+
+	openStatuses := []string{models.ClinicApplicationPending, models.ClinicApplicationInfoRequested}
+	var open int64
+	if err := db.Model(&models.ClinicApplication{}).
+		Where("clinic_email = ? AND status IN ?", req.ClinicEmail, openStatuses).
+		Count(&open).Error; err != nil {
+		return nil, err
+	}
+	if open > 0 {
+		return nil, ErrClinicApplicationExists
+	}
+	if err := db.Model(&models.ClinicApplication{}).
+		Where("owner_email = ? AND status IN ?", req.OwnerEmail, openStatuses).
+		Count(&open).Error; err != nil {
+		return nil, err
+	}
+	if open >= maxOpenClinicApplicationsPerOwner {
+		return nil, ErrClinicApplicationOwnerLimit
+	}
+
+	// The owner email is not verified yet, so only one "submitted" email goes to it per cooldown window
+	var recent int64
+	if err := db.Model(&models.ClinicApplication{}).
+		Where("owner_email = ? AND created_at > ?", req.OwnerEmail, time.Now().Add(-clinicApplicationEmailCooldown)).
+		Count(&recent).Error; err != nil {
+		return nil, err
+	}
+
+	token, err := utils.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	app := models.ClinicApplication{
+		ClinicName:      req.ClinicName,
+		ClinicEmail:     req.ClinicEmail,
+		ClinicPhone:     req.ClinicPhone,
+		ClinicAddress:   req.ClinicAddress,
+		ClinicLogo:      req.ClinicLogo,
+		LicenseNumber:   req.LicenseNumber,
+		OwnerName:       req.OwnerName,
+		OwnerEmail:      req.OwnerEmail,
+		OwnerPhone:      req.OwnerPhone,
+		Message:         req.Message,
+		Status:          models.ClinicApplicationPending,
+		AccessTokenHash: utils.HashSecretToken(token),
+	}
+
+	var written []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&app).Error; err != nil {
+			return err
+		}
+		var err error
+		written, err = saveApplicationDocuments(tx, app.ID, files)
+		return err
+	})
+	if err != nil {
+		removeFiles(written)
+		return nil, err
+	}
+
+	if err := db.Preload("Documents").First(&app, app.ID).Error; err != nil {
+		return nil, err
+	}
+	applicantAuditSource(&app, "/clinic/applications").record("create", "clinic_application", app.ID, nil, nil, app)
+	if recent == 0 {
+		notifyClinicApplicant(&app, "submitted")
+	} else {
+		log.Printf("clinic application %d: submitted email skipped, owner email notified within %s", app.ID, clinicApplicationEmailCooldown)
+	}
+	return &resdto.SubmitClinicApplicationResponse{Application: &app, AccessToken: token}, nil
+}
+
+// applicantAuditSource is the applicant of an application, identified by the application's id and owner email
+func applicantAuditSource(app *models.ClinicApplication, route string) auditSource {
+	return auditSource{
+		Actor:  AuditActor{Type: models.AuditActorApplicant, ID: app.ID, Email: app.OwnerEmail},
+		Method: http.MethodPost,
+		Route:  route,
+	}
+}
+
+// loadApplicantApplication loads an application after checking the applicant's access token.
+// A wrong token is reported as not found so application ids cannot be probed.
+func loadApplicantApplication(db *gorm.DB, id uint64, token string) (*models.ClinicApplication, error) {
+	var app models.ClinicApplication
+	if err := db.First(&app, id).Error; err != nil {
+		return nil, err
+	}
+	if token == "" || subtle.ConstantTimeCompare([]byte(utils.HashSecretToken(token)), []byte(app.AccessTokenHash)) != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &app, nil
+}
+
+// GetApplicantClinicApplication returns an application to the applicant holding its access token
+func GetApplicantClinicApplication(id uint64, token string) (*models.ClinicApplication, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	app, err := loadApplicantApplication(db, id, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Model(app).Association("Documents").Find(&app.Documents); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// RespondToClinicApplication lets the applicant answer an information request with a message and more documents.
+// The application goes back to the review queue.
+func RespondToClinicApplication(id uint64, token, message string, files []*multipart.FileHeader) (*models.ClinicApplication, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	message = strings.TrimSpace(message)
+	if message == "" && len(files) == 0 {
+		return nil, errors.New("message or documents are required")
+	}
+	if err := validateApplicationDocuments(files); err != nil {
+		return nil, err
+	}
+
+	app, err := loadApplicantApplication(db, id, token)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": app.Status, "message": app.Message}
+	if app.Status != models.ClinicApplicationInfoRequested {
+		return nil, ErrClinicApplicationNotAwaitingInfo
+	}
+
+	var written []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"status": models.ClinicApplicationPending}
+		if message != "" {
+			updates["message"] = message
+		}
+		res := tx.Model(&models.ClinicApplication{}).
+			Where("id = ? AND status = ?", app.ID, models.ClinicApplicationInfoRequested).
+			Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrClinicApplicationNotAwaitingInfo
+		}
+		var err error
+		written, err = saveApplicationDocuments(tx, app.ID, files)
+		return err
+	})
+	if err != nil {
+		removeFiles(written)
+		return nil, err
+	}
+
+	if err := db.Preload("Documents").First(app, app.ID).Error; err != nil {
+		return nil, err
+	}
+	after := map[string]interface{}{"status": app.Status, "message": app.Message, "documents_added": len(files)}
+	applicantAuditSource(app, "/clinic/applications/:id/respond").record("update", "clinic_application", app.ID, nil, before, after)
+	notifyClinicApplicant(app, "resubmitted")
+	return app, nil
+}
+
+// ListClinicApplications returns the review queue filtered by status and a name/email search, oldest first
+func ListClinicApplications(term, status string, page, pageSize int) (*resdto.ClinicApplicationListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.ClinicApplication{})
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		query = query.Where("clinic_name LIKE ? OR clinic_email LIKE ? OR owner_name LIKE ? OR owner_email LIKE ? OR license_number LIKE ?",
+			like, like, like, like, like)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	items := []models.ClinicApplication{}
+	if err := query.Order("created_at ASC, id ASC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.ClinicApplicationListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// GetClinicApplication returns an application with its documents
+func GetClinicApplication(id uint64) (*models.ClinicApplication, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var app models.ClinicApplication
+	if err := db.Preload("Documents").First(&app, id).Error; err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// GetClinicApplicationDocument returns an uploaded document of an application
+func GetClinicApplicationDocument(applicationID, documentID uint64) (*models.ClinicApplicationDocument, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var doc models.ClinicApplicationDocument
+	if err := db.Where("id = ? AND application_id = ?", documentID, applicationID).First(&doc).Error; err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// reviewClinicApplication moves an open application to a new status and records the reviewer
+func reviewClinicApplication(db *gorm.DB, adminID, id uint64, status, note string, clinicID *uint64) (*models.ClinicApplication, error) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      status,
+		"review_note": note,
+		"reviewed_by": adminID,
+		"reviewed_at": now,
+	}
+	if clinicID != nil {
+		updates["clinic_id"] = *clinicID
+	}
+	res := db.Model(&models.ClinicApplication{}).
+		Where("id = ? AND status IN ?", id, []string{models.ClinicApplicationPending, models.ClinicApplicationInfoRequested}).
+		Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	var app models.ClinicApplication
+	if err := db.Preload("Documents").First(&app, id).Error; err != nil {
+		return nil, err
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrClinicApplicationClosed
+	}
+	return &app, nil
+}
+
+// loadOpenClinicApplication loads an application that can still be reviewed
+func loadOpenClinicApplication(db *gorm.DB, id uint64) (*models.ClinicApplication, error) {
+	var app models.ClinicApplication
+	if err := db.First(&app, id).Error; err != nil {
+		return nil, err
+	}
+	if app.Status != models.ClinicApplicationPending && app.Status != models.ClinicApplicationInfoRequested {
+		return nil, ErrClinicApplicationClosed
+	}
+	return &app, nil
+}
+
+// ApproveClinicApplication registers the clinic and its owner through RegisterClinic and closes the application.
+// The owner receives the usual credentials email in addition to the approval email.
+func ApproveClinicApplication(adminID, id uint64, note string) (*resdto.ApproveClinicApplicationResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	app, err := loadOpenClinicApplication(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := RegisterClinic(reqdto.RegisterClinicRequest{
+		ClinicName:    app.ClinicName,
+		ClinicEmail:   app.ClinicEmail,
+		ClinicPhone:   app.ClinicPhone,
+		ClinicAddress: app.ClinicAddress,
+		ClinicLogo:    app.ClinicLogo,
+		OwnerName:     app.OwnerName,
+		OwnerEmail:    app.OwnerEmail,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	app, err = reviewClinicApplication(db, adminID, id, models.ClinicApplicationApproved, strings.TrimSpace(note), &registered.Data.ClinicID)
+	if err != nil {
+		return nil, err
+	}
+	notifyClinicApplicant(app, "approved")
+	return &resdto.ApproveClinicApplicationResponse{Application: app, Clinic: registered.Data}, nil
+}
+
+// RejectClinicApplication closes an application; the note is sent to the applicant
+func RejectClinicApplication(adminID, id uint64, note string) (*models.ClinicApplication, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if note = strings.TrimSpace(note); note == "" {
+		return nil, errors.New("note is required when rejecting an application")
+	}
+	if _, err := loadOpenClinicApplication(db, id); err != nil {
+		return nil, err
+	}
+
+	app, err := reviewClinicApplication(db, adminID, id, models.ClinicApplicationRejected, note, nil)
+	if err != nil {
+		return nil, err
+	}
+	notifyClinicApplicant(app, "rejected")
+	return app, nil
+}
+
+// RequestClinicApplicationInfo asks the applicant for more information; the note says what is missing
+func RequestClinicApplicationInfo(adminID, id uint64, note string) (*models.ClinicApplication, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if note = strings.TrimSpace(note); note == "" {
+		return nil, errors.New("note is required when requesting more information")
+	}
+	if _, err := loadOpenClinicApplication(db, id); err != nil {
+		return nil, err
+	}
+
+	app, err := reviewClinicApplication(db, adminID, id, models.ClinicApplicationInfoRequested, note, nil)
+	if err != nil {
+		return nil, err
+	}
+	notifyClinicApplicant(app, "info_requested")
+	return app, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"skinSync/config"
@@ -26,7 +27,7 @@ func RegisterClinic(req reqdto.RegisterClinicRequest) (resdto.RegisterClinicResp
 
 	// Check if clinic email already exists
 	var existingClinic models.Clinic
-	if err := db.Where("email = ?", req.ClinicEmail).First(&existingClinic).Error; err == nil {
+	if err := db.Unscoped().Where("email = ?", req.ClinicEmail).First(&existingClinic).Error; err == nil {
 		return resdto.RegisterClinicResponse{
 			BaseResponse: resdto.BaseResponse{IsSuccess: false, Message: "clinic email already exists"},
 		}, errors.New("clinic email already exists")
@@ -277,7 +278,8 @@ func RegisterClinicUser(req reqdto.RegisterClinicUserRequest, clinicID uint64) (
 
 	// Check if email already exists at THIS clinic
 	var existingUser models.ClinicUser
-	if err := db.Where("email = ? AND clinic_id = ?", req.Email, clinicID).First(&existingUser).Error; err == nil {
+	clinicDB := config.ClinicDB(clinicID)
+	if err := clinicDB.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
 		return nil, errors.New("user with this email already exists at this clinic")
 	}
 
@@ -314,9 +316,18 @@ func RegisterClinicUser(req reqdto.RegisterClinicUserRequest, clinicID uint64) (
 		Status:       "active",
 	}
 
-	if err := db.Create(&clinicUser).Error; err != nil {
+	restored, err := restoreRemovedClinicStaff(clinicDB, &clinicUser)
+	if err != nil {
 		return nil, errors.New("failed to create clinic user")
 	}
+	if !restored {
+		if err := clinicDB.Create(&clinicUser).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return nil, errors.New("user with this email already exists at this clinic")
+			}
+			return nil, errors.New("failed to create clinic user")
+		}
+	}
 
 	// Get clinic name for email
 	var clinic models.Clinic
@@ -381,7 +392,8 @@ func RegisterDoctorWithTreatments(req reqdto.RegisterDoctorRequest, clinicID uin
 
 	// Check if email already exists at THIS clinic
 	var existingUser models.ClinicUser
-	if err := db.Where("email = ? AND clinic_id = ?", req.ContactInfo.Email, clinicID).First(&existingUser).Error; err == nil {
+	clinicDB := config.ClinicDB(clinicID)
+	if err := clinicDB.Where("email = ?", req.ContactInfo.Email).First(&existingUser).Error; err == nil {
 		return nil, errors.New("user with this email already exists at this clinic")
 	}
 
@@ -409,7 +421,7 @@ func RegisterDoctorWithTreatments(req reqdto.RegisterDoctorRequest, clinicID uin
 	}
 
 	// Start transaction
-	tx := db.Begin()
+	tx := clinicDB.Begin()
 
 	// Create clinic user
 	clinicUser := models.ClinicUser{
@@ -421,10 +433,20 @@ func RegisterDoctorWithTreatments(req reqdto.RegisterDoctorRequest, clinicID uin
 		Status:       "active",
 	}
 
-	if err := tx.Create(&clinicUser).Error; err != nil {
+	restored, err := restoreRemovedClinicStaff(tx, &clinicUser)
+	if err != nil {
 		tx.Rollback()
 		return nil, errors.New("failed to create clinic user")
 	}
+	if !restored {
+		if err := tx.Create(&clinicUser).Error; err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return nil, errors.New("user with this email already exists at this clinic")
+			}
+			return nil, errors.New("failed to create clinic user")
+		}
+	}
 
 	// Save profile details (image, specialization, phone)
 	profile := models.ClinicUserProfile{
@@ -516,11 +538,11 @@ func RegisterDoctorWithTreatments(req reqdto.RegisterDoctorRequest, clinicID uin
 
 // AssignDoctorTreatments assigns treatments and side areas to an existing doctor/injector
 func AssignDoctorTreatments(req reqdto.AssignDoctorTreatmentsRequest, clinicID uint64) error {
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Verify the clinic user exists at this clinic
 	var clinicUser models.ClinicUser
-	if err := db.Where("id = ? AND clinic_id = ?", req.ClinicUserID, clinicID).First(&clinicUser).Error; err != nil {
+	if err := db.Where("id = ?", req.ClinicUserID).First(&clinicUser).Error; err != nil {
 		return errors.New("doctor not found at this clinic")
 	}
 
@@ -539,8 +561,8 @@ func AssignDoctorTreatments(req reqdto.AssignDoctorTreatmentsRequest, clinicID u
 
 			// Check if already assigned
 			var existing models.ClinicUserSideArea
-			if err := db.Where("clinic_user_id = ? AND clinic_id = ? AND side_area_id = ?",
-				req.ClinicUserID, clinicID, sideArea.ID).First(&existing).Error; err == nil {
+			if err := db.Where("clinic_user_id = ? AND side_area_id = ?",
+				req.ClinicUserID, sideArea.ID).First(&existing).Error; err == nil {
 				continue // already assigned, skip
 			}
 
@@ -563,7 +585,8 @@ func AssignDoctorTreatments(req reqdto.AssignDoctorTreatmentsRequest, clinicID u
 	return nil
 }
 
-// SideAreaPayload is the payload shape when frontend sends side_area_id
+// SideAreaPayload is the payload shape when frontend sends side_area_id.
+// ClinicID is optional; a value other than the caller's clinic is rejected.
 type SideAreaPayload struct {
 	ClinicID    uint64   `json:"clinic_id"`
 	SideAreaID  uint     `json:"side_area_id"`
@@ -574,12 +597,13 @@ type SideAreaPayload struct {
 
 // UpsertClinicSideAreasFromSideArea accepts payloads where frontend sends side_area_id.
 // It looks up the SideArea to get AreaID and TreatmentID and upserts into clinic_side_areas.
-func UpsertClinicSideAreasFromSideArea(payload []SideAreaPayload) error {
+// Rows are always written for clinicID; payload rows naming another clinic are rejected.
+func UpsertClinicSideAreasFromSideArea(payload []SideAreaPayload, clinicID uint64) error {
 	if len(payload) == 0 {
 		return nil
 	}
 
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	var rows []models.ClinicSideArea
 	for _, p := range payload {
@@ -648,11 +672,11 @@ func CreateClinicSideAreasBulk(req BulkSideAreaRequest, clinicID uint64) (*BulkS
 	if len(req.SideAreas) == 0 {
 		return nil, fmt.Errorf("side_area list is empty")
 	}
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Check if treatment already exists for this clinic
 	var existingCT models.ClinicTreatment
-	if err := db.Where("clinic_id = ? AND treatment_id = ?", clinicID, req.TreatmentID).
+	if err := db.Where("treatment_id = ?", req.TreatmentID).
 		First(&existingCT).Error; err == nil {
 		return nil, fmt.Errorf("treatment already exists for this clinic")
 	}
@@ -666,8 +690,8 @@ func CreateClinicSideAreasBulk(req BulkSideAreaRequest, clinicID uint64) (*BulkS
 	// Check if any side areas already exist for this clinic+treatment
 	for _, it := range req.SideAreas {
 		var existing models.ClinicSideArea
-		if err := db.Where("clinic_id = ? AND treatment_id = ? AND side_area_id = ?",
-			clinicID, req.TreatmentID, it.SideAreaID).First(&existing).Error; err == nil {
+		if err := db.Where("treatment_id = ? AND side_area_id = ?",
+			req.TreatmentID, it.SideAreaID).First(&existing).Error; err == nil {
 			return nil, fmt.Errorf("side_area %d already exists for this clinic and treatment", it.SideAreaID)
 		}
 	}
@@ -745,7 +769,7 @@ func UpdateClinicSideAreasBulk(req BulkSideAreaRequest, clinicID uint64) (*BulkS
 	if req.TreatmentID == 0 {
 		return nil, fmt.Errorf("treatment_id is required")
 	}
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Upsert treatment in clinic_treatments (always, so no duplicate rows)
 	ct := models.ClinicTreatment{
@@ -765,7 +789,7 @@ func UpdateClinicSideAreasBulk(req BulkSideAreaRequest, clinicID uint64) (*BulkS
 
 	// Fetch the saved treatment price from DB to return in response
 	var savedCT models.ClinicTreatment
-	db.Where("clinic_id = ? AND treatment_id = ?", clinicID, req.TreatmentID).First(&savedCT)
+	db.Where("treatment_id = ?", req.TreatmentID).First(&savedCT)
 	var treatmentPrice float64
 	if savedCT.Price != nil {
 		treatmentPrice = *savedCT.Price
@@ -783,11 +807,11 @@ func UpdateClinicSideAreasBulk(req BulkSideAreaRequest, clinicID uint64) (*BulkS
 		for _, it := range req.SideAreas {
 			ids = append(ids, it.SideAreaID)
 		}
-		db.Where("clinic_id = ? AND treatment_id = ? AND side_area_id NOT IN ?", clinicID, req.TreatmentID, ids).
+		db.Where("treatment_id = ? AND side_area_id NOT IN ?", req.TreatmentID, ids).
 			Delete(&models.ClinicSideArea{})
 	} else {
 		// If empty list, remove all side areas for this treatment
-		db.Where("clinic_id = ? AND treatment_id = ?", clinicID, req.TreatmentID).
+		db.Where("treatment_id = ?", req.TreatmentID).
 			Delete(&models.ClinicSideArea{})
 
 		return &BulkSideAreaResponse{
@@ -862,11 +886,11 @@ func UpdateClinicSideAreasIndividual(payload []UpdateSideAreaPayload, clinicID u
 	if len(payload) == 0 {
 		return fmt.Errorf("payload is empty")
 	}
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	for _, p := range payload {
 		query := db.Model(&models.ClinicSideArea{}).
-			Where("clinic_id = ? AND side_area_id = ?", clinicID, p.SideAreaID)
+			Where("side_area_id = ?", p.SideAreaID)
 
 		updates := map[string]interface{}{
 			"updated_at": time.Now(),
@@ -891,13 +915,9 @@ func UpdateClinicSideAreasIndividual(payload []UpdateSideAreaPayload, clinicID u
 	return nil
 }
 
-// GetSideAreasByTreatment returns all side areas for a given treatment ID
+// GetSideAreasByTreatment returns all published side areas for a given treatment ID
 func GetSideAreasByTreatment(treatmentID uint) (resdto.SideAreasResponse, error) {
-	db := config.DB
-
-	var sideAreas []models.SideArea
-
-	err := db.Where("treatment_id = ?", treatmentID).Find(&sideAreas).Error
+	sideAreas, err := publishedActiveSideAreas(treatmentID, 0)
 	if err != nil {
 		return resdto.SideAreasResponse{
 			IsSuccess: false,
@@ -935,11 +955,11 @@ func GetSideAreasByTreatment(treatmentID uint) (resdto.SideAreasResponse, error)
 
 // // GetTreatmentsByClinic returns all treatments offered by a clinic with their side area prices
 func GetTreatmentByClinic(clinicID uint64) (map[string]interface{}, error) {
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Get all clinic treatments assigned to this clinic
 	var clinicTreatments []models.ClinicTreatment
-	if err := db.Where("clinic_id = ? AND status = ?", clinicID, "active").
+	if err := db.Where("status = ?", "active").
 		Preload("Treatment").
 		Find(&clinicTreatments).Error; err != nil {
 		return nil, err
@@ -958,7 +978,7 @@ func GetTreatmentByClinic(clinicID uint64) (map[string]interface{}, error) {
 	for _, ct := range clinicTreatments {
 		// Get all side areas for this treatment and clinic
 		var clinicSideAreas []models.ClinicSideArea
-		if err := db.Where("clinic_id = ? AND treatment_id = ? AND status = ?", clinicID, ct.TreatmentID, "active").
+		if err := db.Where("treatment_id = ? AND status = ?", ct.TreatmentID, "active").
 			Find(&clinicSideAreas).Error; err != nil {
 			continue
 		}
@@ -1114,12 +1134,26 @@ func ClinicResetPassword(email, otp, newPassword string) error {
 	}
 
 	// Update password for ALL clinic user records with this email
+	var users []models.ClinicUser
+	if err := db.Select("id", "clinic_id").Where("email = ?", email).Find(&users).Error; err != nil {
+		return errors.New("failed to update password")
+	}
 	if err := db.Model(&models.ClinicUser{}).
 		Where("email = ?", email).
 		Update("password_hash", hashedPassword).Error; err != nil {
 		return errors.New("failed to update password")
 	}
 
+	// The OTP proves the caller owns the email, so each record is its own actor
+	for _, u := range users {
+		source := auditSource{
+			Actor:  AuditActor{Type: models.AuditActorClinicUser, ID: u.ID, Email: email, ClinicID: &u.ClinicID},
+			Method: http.MethodPost,
+			Route:  "/clinic/reset-password",
+		}
+		source.record("update", "clinic_user", u.ID, nil, nil, map[string]interface{}{"password": "[redacted]"})
+	}
+
 	return nil
 }
 
@@ -1163,13 +1197,15 @@ type DoctorFullDetail struct {
 	Image          string                 `json:"image,omitempty"`
 	Specialization string                 `json:"specialization,omitempty"`
 	Phone          string                 `json:"phone,omitempty"`
+	Bio            string                 `json:"bio,omitempty"`
+	Qualifications []string               `json:"qualifications,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 	Treatments     []DoctorTreatmentDetail `json:"treatments"`
 }
 
 // GetDoctorsByClinic returns all doctors/injectors for a clinic with profile info
 func GetDoctorsByClinic(clinicID uint64) ([]DoctorListItem, error) {
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Get doctor and injector role IDs
 	var roles []models.ClinicRole
@@ -1184,7 +1220,7 @@ func GetDoctorsByClinic(clinicID uint64) ([]DoctorListItem, error) {
 	// Get clinic users with doctor/injector roles
 	var clinicUsers []models.ClinicUser
 	if err := db.Preload("Role").
-		Where("clinic_id = ? AND role_id IN ?", clinicID, roleIDs).
+		Where("role_id IN ?", roleIDs).
 		Find(&clinicUsers).Error; err != nil {
 		return nil, err
 	}
@@ -1201,7 +1237,7 @@ func GetDoctorsByClinic(clinicID uint64) ([]DoctorListItem, error) {
 
 		// Get profile details
 		var profile models.ClinicUserProfile
-		if err := db.Where("clinic_user_id = ? AND clinic_id = ?", cu.ID, clinicID).First(&profile).Error; err == nil {
+		if err := db.Where("clinic_user_id = ?", cu.ID).First(&profile).Error; err == nil {
 			item.Image = profile.Image
 			item.Specialization = profile.Specialization
 			item.Phone = profile.Phone
@@ -1215,12 +1251,12 @@ func GetDoctorsByClinic(clinicID uint64) ([]DoctorListItem, error) {
 
 // GetDoctorDetailByID returns full doctor detail with profile and assigned treatments
 func GetDoctorDetailByID(doctorID uint64, clinicID uint64) (*DoctorFullDetail, error) {
-	db := config.DB
+	db := config.ClinicDB(clinicID)
 
 	// Get clinic user
 	var clinicUser models.ClinicUser
 	if err := db.Preload("Role").
-		Where("id = ? AND clinic_id = ?", doctorID, clinicID).
+		Where("id = ?", doctorID).
 		First(&clinicUser).Error; err != nil {
 		return nil, errors.New("doctor not found at this clinic")
 	}
@@ -1237,15 +1273,17 @@ func GetDoctorDetailByID(doctorID uint64, clinicID uint64) (*DoctorFullDetail, e
 
 	// Get profile
 	var profile models.ClinicUserProfile
-	if err := db.Where("clinic_user_id = ? AND clinic_id = ?", doctorID, clinicID).First(&profile).Error; err == nil {
+	if err := db.Where("clinic_user_id = ?", doctorID).First(&profile).Error; err == nil {
 		detail.Image = profile.Image
 		detail.Specialization = profile.Specialization
 		detail.Phone = profile.Phone
+		detail.Bio = profile.Bio
+		detail.Qualifications = profile.Qualifications
 	}
 
 	// Get assigned side areas grouped by treatment
 	var sideAreaRecords []models.ClinicUserSideArea
-	if err := db.Where("clinic_user_id = ? AND clinic_id = ?", doctorID, clinicID).
+	if err := db.Where("clinic_user_id = ?", doctorID).
 		Find(&sideAreaRecords).Error; err != nil {
 		return detail, nil
 	}
@@ -0,0 +1,544 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // clinic timezones must validate on hosts without a zoneinfo database
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== CLINIC PROFILE & SETTINGS ====================
+
+// ErrProfileChangeNotPending is returned when reviewing a profile change that is no longer pending
+var ErrProfileChangeNotPending = errors.New("profile change is not pending")
+
+// clinicSocialNetworks are the accepted social_links keys
+var clinicSocialNetworks = map[string]bool{
+	"facebook":  true,
+	"instagram": true,
+	"linkedin":  true,
+	"tiktok":    true,
+	"x":         true,
+	"youtube":   true,
+}
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ClinicSettingsUpdate is a validated clinic profile edit. Public fields may need review;
+// timezone, currency and booking settings are internal and always apply immediately.
+type ClinicSettingsUpdate struct {
+	Public   models.ClinicPublicProfile
+	Timezone *string
+	Currency *string
+
+	BookingBufferMinutes *int
+	SlotIntervalMinutes  *int
+
+	CalendarCustomerNames *string
+}
+
+// clinicSlotIntervals are the supported spacings of offered appointment start times
+var clinicSlotIntervals = map[int]bool{5: true, 10: true, 15: true, 20: true, 30: true, 60: true}
+
+// clinicProfileReviewRequired reports whether public profile edits wait for admin approval
+// (CLINIC_PROFILE_REVIEW_REQUIRED, default false)
+func clinicProfileReviewRequired() bool {
+	required, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("CLINIC_PROFILE_REVIEW_REQUIRED")))
+	return err == nil && required
+}
+
+// validateHTTPURL checks that a non-empty value is an absolute http(s) URL
+func validateHTTPURL(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL", field)
+	}
+	return nil
+}
+
+// Validate trims the update and checks field formats and lengths
+func (u *ClinicSettingsUpdate) Validate() error {
+	p := &u.Public
+	limits := []struct {
+		field string
+		value *string
+		max   int
+	}{
+		{"name", p.Name, 255},
+		{"phone", p.Phone, 50},
+		{"logo", p.Logo, 500},
+		{"description", p.Description, 5000},
+		{"website", p.Website, 500},
+		{"address_line1", p.AddressLine1, 255},
+		{"address_line2", p.AddressLine2, 255},
+		{"city", p.City, 100},
+		{"state", p.State, 100},
+		{"postal_code", p.PostalCode, 20},
+		{"country", p.Country, 100},
+	}
+	for _, l := range limits {
+		if l.value == nil {
+			continue
+		}
+		*l.value = strings.TrimSpace(*l.value)
+		if len(*l.value) > l.max {
+			return fmt.Errorf("%s must be at most %d characters", l.field, l.max)
+		}
+	}
+
+	if p.Name != nil && len(*p.Name) < 2 {
+		return errors.New("name must be at least 2 characters")
+	}
+	if p.Website != nil {
+		if err := validateHTTPURL("website", *p.Website); err != nil {
+			return err
+		}
+	}
+	if p.SocialLinks != nil {
+		links := make(map[string]string, len(*p.SocialLinks))
+		for network, link := range *p.SocialLinks {
+			network = strings.ToLower(strings.TrimSpace(network))
+			link = strings.TrimSpace(link)
+			if !clinicSocialNetworks[network] {
+				return fmt.Errorf("unsupported social network %q", network)
+			}
+			if link == "" {
+				continue
+			}
+			if err := validateHTTPURL("social_links."+network, link); err != nil {
+				return err
+			}
+			links[network] = link
+		}
+		p.SocialLinks = &links
+	}
+
+	if u.Timezone != nil {
+		tz := strings.TrimSpace(*u.Timezone)
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" || strings.EqualFold(tz, "local") {
+			return errors.New("timezone must be an IANA time zone such as Europe/London")
+		}
+		u.Timezone = &tz
+	}
+	if u.Currency != nil {
+		currency := strings.ToUpper(strings.TrimSpace(*u.Currency))
+		if !currencyCodePattern.MatchString(currency) {
+			return errors.New("currency must be a 3-letter ISO 4217 code")
+		}
+		u.Currency = &currency
+	}
+	if u.BookingBufferMinutes != nil {
+		if b := *u.BookingBufferMinutes; b < 0 || b > 120 || b%5 != 0 {
+			return errors.New("booking_buffer_minutes must be a multiple of 5 between 0 and 120")
+		}
+	}
+	if u.SlotIntervalMinutes != nil && !clinicSlotIntervals[*u.SlotIntervalMinutes] {
+		return errors.New("slot_interval_minutes must be one of 5, 10, 15, 20, 30 or 60")
+	}
+	if u.CalendarCustomerNames != nil {
+		switch *u.CalendarCustomerNames {
+		case models.CalendarNamesFull, models.CalendarNamesInitials, models.CalendarNamesHidden:
+		default:
+			return errors.New("calendar_customer_names must be full, initials or hidden")
+		}
+	}
+	return nil
+}
+
+// Clinic columns written by profile and settings edits. Status, email and the session cut-off are
+// left out so an edit never overwrites a concurrent suspension or delete.
+var (
+	clinicProfileColumns = []string{
+		"name", "phone", "logo", "description", "website", "address",
+		"address_line1", "address_line2", "city", "state", "postal_code", "country", "social_links",
+	}
+	clinicSettingsColumns = []string{
+		"timezone", "currency", "booking_buffer_minutes", "slot_interval_minutes", "calendar_customer_names",
+	}
+)
+
+// clinicSettingsFromRequest converts the clinic settings request
+func clinicSettingsFromRequest(req reqdto.UpdateClinicSettingsRequest) ClinicSettingsUpdate {
+	return ClinicSettingsUpdate{
+		Public: models.ClinicPublicProfile{
+			Name:         req.Name,
+			Phone:        req.Phone,
+			Logo:         req.Logo,
+			Description:  req.Description,
+			Website:      req.Website,
+			AddressLine1: req.AddressLine1,
+			AddressLine2: req.AddressLine2,
+			City:         req.City,
+			State:        req.State,
+			PostalCode:   req.PostalCode,
+			Country:      req.Country,
+			SocialLinks:  req.SocialLinks,
+		},
+		Timezone: req.Timezone,
+		Currency: req.Currency,
+
+		BookingBufferMinutes: req.BookingBufferMinutes,
+		SlotIntervalMinutes:  req.SlotIntervalMinutes,
+
+		CalendarCustomerNames: req.CalendarCustomerNames,
+	}
+}
+
+// clinicPublicFields pairs each public profile field with the clinic column it changes
+func clinicPublicFields(clinic *models.Clinic, p *models.ClinicPublicProfile) []struct {
+	proposed **string
+	current  *string
+} {
+	return []struct {
+		proposed **string
+		current  *string
+	}{
+		{&p.Name, &clinic.Name},
+		{&p.Phone, &clinic.Phone},
+		{&p.Logo, &clinic.Logo},
+		{&p.Description, &clinic.Description},
+		{&p.Website, &clinic.Website},
+		{&p.AddressLine1, &clinic.AddressLine1},
+		{&p.AddressLine2, &clinic.AddressLine2},
+		{&p.City, &clinic.City},
+		{&p.State, &clinic.State},
+		{&p.PostalCode, &clinic.PostalCode},
+		{&p.Country, &clinic.Country},
+	}
+}
+
+// sameSocialLinks compares two social link maps
+func sameSocialLinks(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// changedPublicProfile drops proposed values equal to the clinic's current ones.
+// Reports whether anything is left to change.
+func changedPublicProfile(clinic *models.Clinic, p models.ClinicPublicProfile) (models.ClinicPublicProfile, bool) {
+	changed := false
+	for _, f := range clinicPublicFields(clinic, &p) {
+		if *f.proposed != nil && **f.proposed == *f.current {
+			*f.proposed = nil
+		}
+		changed = changed || *f.proposed != nil
+	}
+	if p.SocialLinks != nil && sameSocialLinks(*p.SocialLinks, clinic.SocialLinks) {
+		p.SocialLinks = nil
+	}
+	return p, changed || p.SocialLinks != nil
+}
+
+// mergePublicProfile overlays the set fields of src onto dst
+func mergePublicProfile(dst *models.ClinicPublicProfile, src models.ClinicPublicProfile) {
+	var scratch models.Clinic
+	dstFields := clinicPublicFields(&scratch, dst)
+	for i, f := range clinicPublicFields(&scratch, &src) {
+		if *f.proposed != nil {
+			*dstFields[i].proposed = *f.proposed
+		}
+	}
+	if src.SocialLinks != nil {
+		dst.SocialLinks = src.SocialLinks
+	}
+}
+
+// applyPublicProfile writes the set public fields onto the clinic.
+// The single-line Address is recomposed whenever a structured address field changes.
+func applyPublicProfile(clinic *models.Clinic, p models.ClinicPublicProfile) {
+	for _, f := range clinicPublicFields(clinic, &p) {
+		if *f.proposed != nil {
+			*f.current = **f.proposed
+		}
+	}
+	if p.SocialLinks != nil {
+		clinic.SocialLinks = *p.SocialLinks
+	}
+	if p.AddressLine1 != nil || p.AddressLine2 != nil || p.City != nil || p.State != nil || p.PostalCode != nil || p.Country != nil {
+		parts := make([]string, 0, 6)
+		for _, part := range []string{clinic.AddressLine1, clinic.AddressLine2, clinic.City, clinic.State, clinic.PostalCode, clinic.Country} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		clinic.Address = strings.Join(parts, ", ")
+	}
+}
+
+// applySettings writes timezone, currency and booking settings onto the clinic
+func (u ClinicSettingsUpdate) applySettings(clinic *models.Clinic) {
+	if u.Timezone != nil {
+		clinic.Timezone = *u.Timezone
+	}
+	if u.Currency != nil {
+		clinic.Currency = *u.Currency
+	}
+	if u.BookingBufferMinutes != nil {
+		clinic.BookingBufferMinutes = *u.BookingBufferMinutes
+	}
+	if u.SlotIntervalMinutes != nil {
+		clinic.SlotIntervalMinutes = *u.SlotIntervalMinutes
+	}
+	if u.CalendarCustomerNames != nil {
+		clinic.CalendarCustomerNames = *u.CalendarCustomerNames
+	}
+}
+
+// pendingProfileChange returns the clinic's pending profile change, or nil
+func pendingProfileChange(db *gorm.DB, clinicID uint64) (*models.ClinicProfileChange, error) {
+	var change models.ClinicProfileChange
+	err := db.Where("clinic_id = ? AND status = ?", clinicID, models.ClinicProfileChangePending).First(&change).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// GetClinicSettings returns the clinic's profile, settings and any pending public profile change
+func GetClinicSettings(clinicID uint64) (*resdto.ClinicSettingsDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var clinic models.Clinic
+	if err := db.First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	pending, err := pendingProfileChange(db, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	settings := &resdto.ClinicSettingsDTO{Clinic: &clinic, PendingChange: pending, ReviewRequired: clinicProfileReviewRequired()}
+
+	var reviewed models.ClinicProfileChange
+	err = db.Where("status IN ?", []string{models.ClinicProfileChangeApproved, models.ClinicProfileChangeRejected}).
+		Order("reviewed_at DESC, id DESC").First(&reviewed).Error
+	if err == nil {
+		settings.LastReviewedChange = &reviewed
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateClinicSettings saves a clinic's own profile edit. When review is required, changed public
+// fields are merged into the clinic's pending change instead of being applied; reports whether that happened.
+func UpdateClinicSettings(clinicID, clinicUserID uint64, req reqdto.UpdateClinicSettingsRequest) (*resdto.ClinicSettingsDTO, bool, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, false, errors.New("database not initialized")
+	}
+	update := clinicSettingsFromRequest(req)
+	if err := update.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	queued := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var clinic models.Clinic
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&clinic, clinicID).Error; err != nil {
+			return err
+		}
+		update.applySettings(&clinic)
+
+		public, changed := changedPublicProfile(&clinic, update.Public)
+		if changed && clinicProfileReviewRequired() {
+			pending, err := pendingProfileChange(tx, clinicID)
+			if err != nil {
+				return err
+			}
+			if pending == nil {
+				pending = &models.ClinicProfileChange{ClinicID: clinicID, Status: models.ClinicProfileChangePending}
+			}
+			mergePublicProfile(&pending.Changes, public)
+			pending.RequestedBy = clinicUserID
+			if err := tx.Save(pending).Error; err != nil {
+				return err
+			}
+			queued = true
+		} else if changed {
+			applyPublicProfile(&clinic, public)
+		}
+		columns := append(append([]string{}, clinicProfileColumns...), clinicSettingsColumns...)
+		return tx.Model(&clinic).Select(columns).Updates(&clinic).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	settings, err := GetClinicSettings(clinicID)
+	return settings, queued, err
+}
+
+// WithdrawClinicProfileChange cancels the clinic's pending public profile change
+func WithdrawClinicProfileChange(clinicID uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	res := db.Model(&models.ClinicProfileChange{}).
+		Where("status = ?", models.ClinicProfileChangePending).
+		Update("status", models.ClinicProfileChangeWithdrawn)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListClinicProfileChanges returns profile changes for review, oldest first, with each clinic's current values.
+// The admin review queue spans clinics, so it and the approve/reject paths below use the unscoped DB.
+func ListClinicProfileChanges(status string, clinicID uint64, page, pageSize int) (*resdto.ClinicProfileChangeListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.ClinicProfileChange{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if clinicID != 0 {
+		query = query.Where("clinic_id = ?", clinicID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var changes []models.ClinicProfileChange
+	if err := query.Order("created_at ASC, id ASC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	clinicIDs := make([]uint64, 0, len(changes))
+	for _, ch := range changes {
+		clinicIDs = append(clinicIDs, ch.ClinicID)
+	}
+	clinics := map[uint64]*models.Clinic{}
+	if len(clinicIDs) > 0 {
+		var rows []models.Clinic
+		if err := db.Unscoped().Where("id IN ?", clinicIDs).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			clinics[rows[i].ID] = &rows[i]
+		}
+	}
+
+	items := make([]resdto.ClinicProfileChangeDTO, 0, len(changes))
+	for _, ch := range changes {
+		item := resdto.ClinicProfileChangeDTO{ClinicProfileChange: ch}
+		if clinic := clinics[ch.ClinicID]; clinic != nil {
+			item.ClinicName = clinic.Name
+			item.Current = clinic
+		}
+		items = append(items, item)
+	}
+	return &resdto.ClinicProfileChangeListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// reviewProfileChange loads a pending change for update inside tx
+func reviewProfileChange(tx *gorm.DB, id uint64) (*models.ClinicProfileChange, error) {
+	var change models.ClinicProfileChange
+	if err := tx.First(&change, id).Error; err != nil {
+		return nil, err
+	}
+	if change.Status != models.ClinicProfileChangePending {
+		return nil, ErrProfileChangeNotPending
+	}
+	return &change, nil
+}
+
+// ApproveClinicProfileChange applies a pending change to the clinic, making it visible in discovery
+func ApproveClinicProfileChange(adminID, id uint64) (*models.ClinicProfileChange, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var change *models.ClinicProfileChange
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if change, err = reviewProfileChange(tx, id); err != nil {
+			return err
+		}
+		var clinic models.Clinic
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&clinic, change.ClinicID).Error; err != nil {
+			return err
+		}
+		applyPublicProfile(&clinic, change.Changes)
+		if err := tx.Model(&clinic).Select(clinicProfileColumns).Updates(&clinic).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		change.Status = models.ClinicProfileChangeApproved
+		change.ReviewedBy = &adminID
+		change.ReviewedAt = &now
+		return tx.Save(change).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// RejectClinicProfileChange discards a pending change; the note is shown to the clinic
+func RejectClinicProfileChange(adminID, id uint64, note string) (*models.ClinicProfileChange, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if note = strings.TrimSpace(note); note == "" {
+		return nil, errors.New("note is required when rejecting a profile change")
+	}
+	var change *models.ClinicProfileChange
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if change, err = reviewProfileChange(tx, id); err != nil {
+			return err
+		}
+		now := time.Now()
+		change.Status = models.ClinicProfileChangeRejected
+		change.ReviewedBy = &adminID
+		change.ReviewedAt = &now
+		change.ReviewNote = note
+		return tx.Save(change).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
@@ -0,0 +1,546 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/permissions"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== CLINIC STAFF MANAGEMENT ====================
+
+var (
+	// ErrLastClinicOwner is returned when a change would leave the clinic without an active owner
+	ErrLastClinicOwner = errors.New("cannot remove the clinic's last active owner")
+	// ErrClinicStaffSelfAction is returned when a staff member tries to deactivate or remove themselves
+	ErrClinicStaffSelfAction = errors.New("you cannot deactivate or remove your own account")
+	// ErrClinicOwnerRole is returned when assigning the owner role, which only clinic registration creates
+	ErrClinicOwnerRole = errors.New("cannot assign the owner role")
+	// ErrClinicStaffHasAppointments is returned when removing a practitioner who still has upcoming appointments
+	ErrClinicStaffHasAppointments = errors.New("staff member has upcoming appointments; reassign or cancel them first")
+)
+
+// isPractitionerRole reports whether a role performs treatments and can hold treatment assignments
+func isPractitionerRole(roleName string) bool {
+	return roleName == models.ClinicRoleDoctor || roleName == models.ClinicRoleInjector
+}
+
+// clinicStaffRows builds staff rows with their assignment counts
+func clinicStaffRows(db *gorm.DB, users []models.ClinicUser) ([]resdto.ClinicStaffDTO, error) {
+	items := make([]resdto.ClinicStaffDTO, 0, len(users))
+	if len(users) == 0 {
+		return items, nil
+	}
+
+	ids := make([]uint64, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	type countRow struct {
+		ClinicUserID uint64
+		Total        int64
+	}
+	var treatments, sideAreas []countRow
+	if err := db.Model(&models.ClinicUserTreatment{}).Select("clinic_user_id, COUNT(*) AS total").
+		Where("clinic_user_id IN ?", ids).Group("clinic_user_id").Scan(&treatments).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.ClinicUserSideArea{}).Select("clinic_user_id, COUNT(*) AS total").
+		Where("clinic_user_id IN ?", ids).Group("clinic_user_id").Scan(&sideAreas).Error; err != nil {
+		return nil, err
+	}
+	treatmentCount := make(map[uint64]int64, len(treatments))
+	for _, r := range treatments {
+		treatmentCount[r.ClinicUserID] = r.Total
+	}
+	sideAreaCount := make(map[uint64]int64, len(sideAreas))
+	for _, r := range sideAreas {
+		sideAreaCount[r.ClinicUserID] = r.Total
+	}
+
+	for _, u := range users {
+		items = append(items, resdto.ClinicStaffDTO{
+			ID:             u.ID,
+			Email:          u.Email,
+			Name:           u.Name,
+			RoleID:         u.RoleID,
+			Role:           u.Role.Name,
+			Status:         u.Status,
+			LastLogin:      u.LastLogin,
+			TreatmentCount: treatmentCount[u.ID],
+			SideAreaCount:  sideAreaCount[u.ID],
+			CreatedAt:      u.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// ListClinicStaff returns the clinic's staff filtered by name/email search, role name and status
+func ListClinicStaff(clinicID uint64, term, roleName, status string, page, pageSize int) (*resdto.ClinicStaffListResponse, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.ClinicUser{})
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		query = query.Where("clinic_users.name LIKE ? OR clinic_users.email LIKE ?", like, like)
+	}
+	if roleName != "" {
+		query = query.Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").Where("clinic_roles.name = ?", roleName)
+	}
+	if status != "" {
+		query = query.Where("clinic_users.status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var users []models.ClinicUser
+	if err := query.Preload("Role").Order("clinic_users.name ASC, clinic_users.id ASC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	items, err := clinicStaffRows(config.DB, users)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.ClinicStaffListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// loadClinicStaff loads one staff member of the clinic with their role
+func loadClinicStaff(clinicID, id uint64) (*models.ClinicUser, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var user models.ClinicUser
+	if err := db.Preload("Role").First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetClinicStaff returns one staff member of the clinic
+func GetClinicStaff(clinicID, id uint64) (*resdto.ClinicStaffDTO, error) {
+	user, err := loadClinicStaff(clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := clinicStaffRows(config.DB, []models.ClinicUser{*user})
+	if err != nil {
+		return nil, err
+	}
+	return &rows[0], nil
+}
+
+// ensureOtherClinicOwner locks the clinic's active owner rows and fails with ErrLastClinicOwner when
+// clinicUserID is the only one. Users that are not active owners pass. Callers run it and their
+// change in one transaction, so concurrent demotions, deactivations and removals are checked one at a time.
+func ensureOtherClinicOwner(tx *gorm.DB, clinicID, clinicUserID uint64) error {
+	var ids []uint64
+	if err := tx.Model(&models.ClinicUser{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
+		Where("clinic_roles.name = ? AND clinic_users.clinic_id = ? AND clinic_users.status = ?",
+			models.ClinicRoleOwner, clinicID, models.ClinicUserStatusActive).
+		Order("clinic_users.id").
+		Pluck("clinic_users.id", &ids).Error; err != nil {
+		return err
+	}
+	isOwner := false
+	for _, id := range ids {
+		if id == clinicUserID {
+			isOwner = true
+		}
+	}
+	if isOwner && len(ids) == 1 {
+		return ErrLastClinicOwner
+	}
+	return nil
+}
+
+// clearPractitionerAssignments removes a user's treatment and side-area assignments
+func clearPractitionerAssignments(tx *gorm.DB, clinicUserID uint64) error {
+	if err := tx.Where("clinic_user_id = ?", clinicUserID).Delete(&models.ClinicUserSideArea{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("clinic_user_id = ?", clinicUserID).Delete(&models.ClinicUserTreatment{}).Error
+}
+
+// signOutClinicStaff revokes a staff member's tokens and drops their cached permissions
+func signOutClinicStaff(clinicUserID uint64) error {
+	permissions.InvalidateClinicPermissionCache(clinicUserID)
+	return RevokeClinicUserSessions(clinicUserID)
+}
+
+// restoreRemovedClinicStaff brings back a removed account with the same email at the clinic instead
+// of creating a new one, so a returning employee keeps their history. It reports whether one existed.
+func restoreRemovedClinicStaff(tx *gorm.DB, user *models.ClinicUser) (bool, error) {
+	var removed models.ClinicUser
+	err := tx.Unscoped().Where("clinic_id = ? AND email = ? AND deleted_at IS NOT NULL", user.ClinicID, user.Email).
+		First(&removed).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	err = tx.Unscoped().Model(&removed).Updates(map[string]interface{}{
+		"password_hash": user.PasswordHash,
+		"name":          user.Name,
+		"role_id":       user.RoleID,
+		"status":        user.Status,
+		"deleted_at":    nil,
+	}).Error
+	if err != nil {
+		return false, err
+	}
+	user.ID = removed.ID
+	user.CreatedAt = removed.CreatedAt
+	return true, nil
+}
+
+// UpdateClinicStaff changes a staff member's name and role. Leaving a doctor/injector role
+// clears their treatment assignments; any role change signs them out so the new role applies.
+func UpdateClinicStaff(clinicID, id uint64, req reqdto.UpdateClinicUserRequest) (*resdto.ClinicStaffDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	user, err := loadClinicStaff(clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, errors.New("name must be at least 2 characters")
+		}
+		updates["name"] = name
+	}
+
+	roleChanged := false
+	clearAssignments := false
+	if req.RoleID != nil && *req.RoleID != user.RoleID {
+		var role models.ClinicRole
+		if err := db.First(&role, *req.RoleID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("invalid role_id")
+			}
+			return nil, err
+		}
+		if role.Name == models.ClinicRoleOwner {
+			return nil, ErrClinicOwnerRole
+		}
+		updates["role_id"] = role.ID
+		roleChanged = true
+		clearAssignments = isPractitionerRole(user.Role.Name) && !isPractitionerRole(role.Name)
+	}
+
+	if len(updates) > 0 {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if roleChanged {
+				if err := ensureOtherClinicOwner(tx, clinicID, id); err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(&models.ClinicUser{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+				return err
+			}
+			if clearAssignments {
+				return clearPractitionerAssignments(tx, id)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if roleChanged {
+		if err := signOutClinicStaff(id); err != nil {
+			return nil, err
+		}
+	}
+	return GetClinicStaff(clinicID, id)
+}
+
+// SetClinicStaffStatus activates or deactivates a staff member; deactivation signs them out
+func SetClinicStaffStatus(clinicID, actorID, id uint64, status string) (*resdto.ClinicStaffDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if status != models.ClinicUserStatusActive && status != models.ClinicUserStatusInactive {
+		return nil, errors.New("status must be 'active' or 'inactive'")
+	}
+	user, err := loadClinicStaff(clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == models.ClinicUserStatusInactive && user.Status != models.ClinicUserStatusInactive && id == actorID {
+		return nil, ErrClinicStaffSelfAction
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if status == models.ClinicUserStatusInactive {
+			if err := ensureOtherClinicOwner(tx, clinicID, id); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.ClinicUser{}).Where("id = ?", id).Update("status", status).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == models.ClinicUserStatusInactive {
+		if err := signOutClinicStaff(id); err != nil {
+			return nil, err
+		}
+	}
+	return GetClinicStaff(clinicID, id)
+}
+
+// DeleteClinicStaff removes a staff member from the clinic and signs them out. The account is
+// soft-deleted so past appointments and signed treatment records keep their practitioner; their
+// assignments, practitioner profile, calendar feeds and waitlist entries are dropped. Practitioners
+// with upcoming appointments must have them reassigned or cancelled first.
+func DeleteClinicStaff(clinicID, actorID, id uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	if id == actorID {
+		return ErrClinicStaffSelfAction
+	}
+	if _, err := loadClinicStaff(clinicID, id); err != nil {
+		return err
+	}
+
+	var upcoming int64
+	if err := db.Model(&models.Appointment{}).
+		Where("practitioner_id = ? AND status IN ? AND end_at > ?", id,
+			[]string{models.AppointmentRequested, models.AppointmentConfirmed}, time.Now()).
+		Count(&upcoming).Error; err != nil {
+		return err
+	}
+	if upcoming > 0 {
+		return ErrClinicStaffHasAppointments
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureOtherClinicOwner(tx, clinicID, id); err != nil {
+			return err
+		}
+		if err := clearPractitionerAssignments(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Where("clinic_user_id = ?", id).Delete(&models.ClinicUserProfile{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WaitlistEntry{}).
+			Where("practitioner_id = ? AND status IN ?", id,
+				[]string{models.WaitlistWaiting, models.WaitlistOffered}).
+			Update("status", models.WaitlistCancelled).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.WaitlistOffer{}).
+			Where("practitioner_id = ? AND status = ?", id, models.WaitlistOfferPending).
+			Update("status", models.WaitlistOfferUnavailable).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.CalendarFeed{}).
+			Where("owner_type = ? AND owner_id = ? AND revoked_at IS NULL", models.AuditActorClinicUser, id).
+			Updates(map[string]interface{}{
+				"revoked_at":      time.Now(),
+				"revoked_by_type": models.AuditActorClinicUser,
+				"revoked_by_id":   actorID,
+			}).Error; err != nil {
+			return err
+		}
+		// The cut-off is written before the soft delete, which would hide the row from this update
+		if err := tx.Model(&models.ClinicUser{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":              models.ClinicUserStatusInactive,
+			"sessions_revoked_at": newSessionCutoff(),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ClinicUser{}, id).Error
+	})
+	if err != nil {
+		return err
+	}
+	permissions.InvalidateClinicPermissionCache(id)
+	return nil
+}
+
+// ==================== CLINIC USER SELF-SERVICE ====================
+
+// ErrPractitionerOnlyField is returned when a non-practitioner sets doctor-only profile fields
+var ErrPractitionerOnlyField = errors.New("specialization, bio and qualifications are only available to doctors and injectors")
+
+const maxQualifications = 20
+
+// GetClinicMe returns the signed-in clinic user's profile, role, clinic and effective permissions
+func GetClinicMe(clinicID, clinicUserID uint64) (*resdto.ClinicMeDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var user models.ClinicUser
+	if err := db.Preload("Role").Preload("Clinic").First(&user, clinicUserID).Error; err != nil {
+		return nil, err
+	}
+	perms, err := permissions.GetClinicUserPermissionNames(clinicUserID)
+	if err != nil {
+		return nil, err
+	}
+	if perms == nil {
+		perms = []string{}
+	}
+
+	me := &resdto.ClinicMeDTO{
+		ID:          user.ID,
+		Email:       user.Email,
+		Name:        user.Name,
+		Role:        user.Role.Name,
+		Status:      user.Status,
+		LastLogin:   user.LastLogin,
+		Permissions: perms,
+		Clinic: &resdto.ClinicDTO{
+			ID:      user.Clinic.ID,
+			Name:    user.Clinic.Name,
+			Email:   user.Clinic.Email,
+			Phone:   user.Clinic.Phone,
+			Address: user.Clinic.Address,
+			Logo:    user.Clinic.Logo,
+			Status:  user.Clinic.Status,
+		},
+	}
+
+	var profile models.ClinicUserProfile
+	err = db.Where("clinic_user_id = ?", clinicUserID).First(&profile).Error
+	if err == nil {
+		me.Profile = &profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return me, nil
+}
+
+// UpdateClinicMe updates the signed-in clinic user's name and profile, creating the profile on first edit
+func UpdateClinicMe(clinicID, clinicUserID uint64, req reqdto.UpdateClinicMeRequest) (*resdto.ClinicMeDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	user, err := loadClinicStaff(clinicID, clinicUserID)
+	if err != nil {
+		return nil, err
+	}
+	if (req.Specialization != nil || req.Bio != nil || req.Qualifications != nil) && !isPractitionerRole(user.Role.Name) {
+		return nil, ErrPractitionerOnlyField
+	}
+
+	limits := []struct {
+		field string
+		value *string
+		max   int
+	}{
+		{"name", req.Name, 100},
+		{"image", req.Image, 500},
+		{"phone", req.Phone, 50},
+		{"specialization", req.Specialization, 255},
+		{"bio", req.Bio, 5000},
+	}
+	for _, l := range limits {
+		if l.value == nil {
+			continue
+		}
+		*l.value = strings.TrimSpace(*l.value)
+		if len(*l.value) > l.max {
+			return nil, fmt.Errorf("%s must be at most %d characters", l.field, l.max)
+		}
+	}
+	if req.Name != nil && len(*req.Name) < 2 {
+		return nil, errors.New("name must be at least 2 characters")
+	}
+
+	var qualifications []string
+	if req.Qualifications != nil {
+		qualifications = make([]string, 0, len(*req.Qualifications))
+		for _, q := range *req.Qualifications {
+			if q = strings.TrimSpace(q); q == "" {
+				continue
+			}
+			if len(q) > 255 {
+				return nil, errors.New("each qualification must be at most 255 characters")
+			}
+			qualifications = append(qualifications, q)
+		}
+		if len(qualifications) > maxQualifications {
+			return nil, fmt.Errorf("at most %d qualifications are allowed", maxQualifications)
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if req.Name != nil {
+			if err := tx.Model(&models.ClinicUser{}).Where("id = ?", clinicUserID).
+				Update("name", *req.Name).Error; err != nil {
+				return err
+			}
+		}
+		if req.Image == nil && req.Phone == nil && req.Specialization == nil && req.Bio == nil && req.Qualifications == nil {
+			return nil
+		}
+
+		var profile models.ClinicUserProfile
+		err := tx.Where("clinic_user_id = ?", clinicUserID).First(&profile).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			profile = models.ClinicUserProfile{ClinicUserID: clinicUserID, ClinicID: clinicID}
+		} else if err != nil {
+			return err
+		}
+		if req.Image != nil {
+			profile.Image = *req.Image
+		}
+		if req.Phone != nil {
+			profile.Phone = *req.Phone
+		}
+		if req.Specialization != nil {
+			profile.Specialization = *req.Specialization
+		}
+		if req.Bio != nil {
+			profile.Bio = *req.Bio
+		}
+		if req.Qualifications != nil {
+			profile.Qualifications = qualifications
+		}
+		return tx.Save(&profile).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetClinicMe(clinicID, clinicUserID)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"skinSync/config"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// fakeClinicUser is the single clinic_users row served by fakeStaffDB
+type fakeClinicUser struct {
+	id, clinicID      uint64
+	status            string
+	deletedAt         *time.Time
+	sessionsRevokedAt *time.Time
+}
+
+// fakeStaffDB is a database/sql driver holding one clinic user. It understands just enough of
+// gorm's MySQL statements to honour the soft-delete scope on that row; the user is not an owner,
+// every other table is empty and every other write succeeds without effect.
+type fakeStaffDB struct {
+	mu   sync.Mutex
+	user fakeClinicUser
+}
+
+var fakeTableRe = regexp.MustCompile("(?i)(?:from|update) `(\\w+)`")
+
+func (f *fakeStaffDB) Connect(context.Context) (driver.Conn, error) { return fakeStaffConn{f}, nil }
+func (f *fakeStaffDB) Driver() driver.Driver                        { return nil }
+
+// visible reports whether the row passes the statement's soft-delete scope, if any
+func (f *fakeStaffDB) visible(query string) bool {
+	return f.user.deletedAt == nil || !strings.Contains(query, "`deleted_at` IS NULL")
+}
+
+func (f *fakeStaffDB) exec(query string, args []driver.NamedValue) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m := fakeTableRe.FindStringSubmatch(query)
+	if m == nil || m[1] != "clinic_users" || !strings.HasPrefix(query, "UPDATE") || !f.visible(query) {
+		return 0
+	}
+	set := query[strings.Index(query, " SET ")+5 : strings.Index(query, " WHERE ")]
+	for i, item := range strings.Split(set, ",") {
+		col := strings.Trim(strings.SplitN(item, "=", 2)[0], "` ")
+		switch v := args[i].Value.(type) {
+		case time.Time:
+			switch col {
+			case "deleted_at":
+				f.user.deletedAt = &v
+			case "sessions_revoked_at":
+				f.user.sessionsRevokedAt = &v
+			}
+		case string:
+			if col == "status" {
+				f.user.status = v
+			}
+		}
+	}
+	return 1
+}
+
+func (f *fakeStaffDB) query(query string) *fakeRows {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if strings.HasPrefix(query, "SELECT count(*)") {
+		return &fakeRows{cols: []string{"count(*)"}, rows: [][]driver.Value{{int64(0)}}}
+	}
+	if strings.Contains(query, "JOIN clinic_roles") {
+		// The owner lock: the fake user is a doctor and the clinic has no other owners
+		return &fakeRows{cols: []string{"id"}}
+	}
+	m := fakeTableRe.FindStringSubmatch(query)
+	if m == nil {
+		return &fakeRows{}
+	}
+	switch m[1] {
+	case "clinic_users":
+		if !f.visible(query) {
+			return &fakeRows{}
+		}
+		u := f.user
+		row := []driver.Value{int64(u.id), int64(u.clinicID), "staff@example.com", "Staff", int64(2), u.status, nil, nil}
+		if u.deletedAt != nil {
+			row[6] = *u.deletedAt
+		}
+		if u.sessionsRevokedAt != nil {
+			row[7] = *u.sessionsRevokedAt
+		}
+		return &fakeRows{
+			cols: []string{"id", "clinic_id", "email", "name", "role_id", "status", "deleted_at", "sessions_revoked_at"},
+			rows: [][]driver.Value{row},
+		}
+	case "clinic_roles":
+		return &fakeRows{cols: []string{"id", "name"}, rows: [][]driver.Value{{int64(2), "doctor"}}}
+	}
+	return &fakeRows{}
+}
+
+type fakeStaffConn struct{ db *fakeStaffDB }
+
+func (c fakeStaffConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakeStaffConn) Close() error                        { return nil }
+func (c fakeStaffConn) Begin() (driver.Tx, error)           { return fakeTx{}, nil }
+
+func (c fakeStaffConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(c.db.exec(query, args)), nil
+}
+
+func (c fakeStaffConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	return c.db.query(query), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// useFakeStaffDB installs fake as the package DB for the duration of the test
+func useFakeStaffDB(t *testing.T, fake *fakeStaffDB) {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sql.OpenDB(fake), SkipInitializeWithVersion: true}),
+		&gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := config.DB
+	config.DB = db
+	t.Cleanup(func() { config.DB = prev })
+}
+
+func TestDeleteClinicStaffRevokesTheirSessions(t *testing.T) {
+	fake := &fakeStaffDB{user: fakeClinicUser{id: 5, clinicID: 7, status: "active"}}
+	useFakeStaffDB(t, fake)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	if IsClinicSessionRevoked(7, 5, issuedAt) {
+		t.Fatal("token rejected before the staff member was removed")
+	}
+
+	if err := DeleteClinicStaff(7, 1, 5); err != nil {
+		t.Fatalf("DeleteClinicStaff: %v", err)
+	}
+	if fake.user.deletedAt == nil {
+		t.Fatal("staff member was not soft-deleted")
+	}
+	if fake.user.sessionsRevokedAt == nil {
+		t.Error("no session cut-off was stored for the removed staff member")
+	}
+	if !IsClinicSessionRevoked(7, 5, issuedAt) {
+		t.Error("token of a removed staff member is still accepted")
+	}
+	if !IsClinicSessionRevoked(7, 5, time.Now().Add(time.Minute)) {
+		t.Error("token issued after removal is accepted")
+	}
+}
+
+func TestIsClinicSessionRevokedRejectsRemovedUser(t *testing.T) {
+	removedAt := time.Now().Add(-time.Hour)
+	useFakeStaffDB(t, &fakeStaffDB{user: fakeClinicUser{id: 5, clinicID: 7, status: "inactive", deletedAt: &removedAt}})
+
+	if !IsClinicSessionRevoked(7, 5, time.Now()) {
+		t.Error("token of a soft-deleted clinic user without a cut-off is accepted")
+	}
+}
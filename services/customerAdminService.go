@@ -0,0 +1,326 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== ADMIN CUSTOMER MANAGEMENT ====================
+
+// Customer search filters for soft-deleted accounts
+const (
+	CustomerDeletedExclude = ""
+	CustomerDeletedInclude = "include"
+	CustomerDeletedOnly    = "only"
+)
+
+// ErrCustomerNotDeleted is returned when restoring an account that is not deleted
+var ErrCustomerNotDeleted = errors.New("customer is not deleted")
+
+// normalizePage applies default and maximum page sizes
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return page, pageSize
+}
+
+// SearchCustomers finds customers whose email, phone or name matches term.
+// Matches primary contact details, profile details and linked login providers.
+func SearchCustomers(term, status, deleted string, page, pageSize int) (*resdto.CustomerListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.User{})
+	switch deleted {
+	case CustomerDeletedInclude:
+		query = query.Unscoped()
+	case CustomerDeletedOnly:
+		query = query.Unscoped().Where("users.deleted_at IS NOT NULL")
+	}
+
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		profileMatches := db.Model(&models.UserProfile{}).Select("user_id").
+			Where("name LIKE ? OR email_address LIKE ? OR phone_number LIKE ?", like, like, like)
+		providerMatches := db.Model(&models.AuthProvider{}).Select("user_id").
+			Where("email LIKE ? OR phone LIKE ?", like, like)
+		query = query.Where("users.primary_email LIKE ? OR users.primary_phone LIKE ? OR users.id IN (?) OR users.id IN (?)",
+			like, like, profileMatches, providerMatches)
+	}
+	if status != "" {
+		query = query.Where("users.status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if err := query.Order("users.id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	items, err := customerSummaries(db, users)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.CustomerListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// customerSummaries builds summary rows with profile names and provider types in two queries
+func customerSummaries(db *gorm.DB, users []models.User) ([]resdto.CustomerSummaryDTO, error) {
+	items := make([]resdto.CustomerSummaryDTO, 0, len(users))
+	if len(users) == 0 {
+		return items, nil
+	}
+
+	ids := make([]uint64, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	var profiles []models.UserProfile
+	if err := db.Where("user_id IN ?", ids).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]string, len(profiles))
+	for _, p := range profiles {
+		names[p.UserID] = p.Name
+	}
+
+	var providers []models.AuthProvider
+	if err := db.Where("user_id IN ?", ids).Order("id").Find(&providers).Error; err != nil {
+		return nil, err
+	}
+	providerNames := make(map[uint64][]string)
+	for _, p := range providers {
+		providerNames[p.UserID] = append(providerNames[p.UserID], p.Provider)
+	}
+
+	for _, u := range users {
+		items = append(items, customerSummary(u, names[u.ID], providerNames[u.ID]))
+	}
+	return items, nil
+}
+
+// customerSummary converts a user to its summary row
+func customerSummary(u models.User, name string, providers []string) resdto.CustomerSummaryDTO {
+	if providers == nil {
+		providers = []string{}
+	}
+	dto := resdto.CustomerSummaryDTO{
+		ID:           u.ID,
+		Name:         name,
+		PrimaryEmail: u.PrimaryEmail,
+		PrimaryPhone: u.PrimaryPhone,
+		Status:       u.Status,
+		Providers:    providers,
+		CreatedAt:    u.CreatedAt,
+	}
+	if u.DeletedAt.Valid {
+		deletedAt := u.DeletedAt.Time
+		dto.DeletedAt = &deletedAt
+	}
+	return dto
+}
+
+// GetCustomerDetail returns a customer's profile, login providers, sessions and onboarding answers.
+// Soft-deleted customers are included so admins can review them before restoring.
+func GetCustomerDetail(userID uint64) (*resdto.CustomerDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var user models.User
+	if err := db.Unscoped().First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	detail := &resdto.CustomerDetailDTO{
+		AuthProviders: []resdto.CustomerProviderDTO{},
+		Sessions:      []resdto.CustomerSessionDTO{},
+	}
+
+	var profile models.UserProfile
+	name := ""
+	if err := db.Where("user_id = ?", userID).First(&profile).Error; err == nil {
+		name = profile.Name
+		detail.Profile = &resdto.CustomerProfileDTO{
+			Name:             profile.Name,
+			PhoneNumber:      profile.PhoneNumber,
+			EmailAddress:     profile.EmailAddress,
+			Location:         profile.Location,
+			Bio:              profile.Bio,
+			ProfileImagePath: profile.ProfileImagePath,
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var providers []models.AuthProvider
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&providers).Error; err != nil {
+		return nil, err
+	}
+	providerNames := make([]string, 0, len(providers))
+	for _, p := range providers {
+		providerNames = append(providerNames, p.Provider)
+		detail.AuthProviders = append(detail.AuthProviders, resdto.CustomerProviderDTO{
+			ID:        p.ID,
+			Provider:  p.Provider,
+			Email:     p.Email,
+			Phone:     p.Phone,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+	detail.CustomerSummaryDTO = customerSummary(user, name, providerNames)
+
+	var tokens []models.AuthToken
+	if err := db.Where("user_id = ?", userID).Order("id DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, t := range tokens {
+		detail.Sessions = append(detail.Sessions, resdto.CustomerSessionDTO{
+			ID:               t.ID,
+			DeviceInfo:       t.DeviceInfo,
+			IPAddress:        t.IPAddress,
+			CreatedAt:        t.CreatedAt,
+			LastRefreshedAt:  t.UpdatedAt,
+			AccessExpiresAt:  t.AccessExpiresAt,
+			RefreshExpiresAt: t.RefreshExpiresAt,
+			IsRevoked:        t.IsRevoked,
+			Active:           !t.IsRevoked && t.RefreshExpiresAt.After(now),
+		})
+	}
+
+	onboarding, err := GetUserOnboarding(userID)
+	if err != nil {
+		return nil, err
+	}
+	if answers, ok := onboarding.Data.(resdto.UserOnboardingResponse); ok {
+		detail.Onboarding = answers
+	}
+
+	return detail, nil
+}
+
+// RevokeCustomerSessions revokes every live session of a customer and blacklists their access tokens.
+// The stored cut-off also rejects access tokens on other instances and after a restart.
+// Returns the number of sessions revoked.
+func RevokeCustomerSessions(userID uint64) (int64, error) {
+	db := config.DB
+	if db == nil {
+		return 0, errors.New("database not initialized")
+	}
+	if err := RevokeUserSessions(userID); err != nil {
+		return 0, err
+	}
+
+	var tokens []models.AuthToken
+	if err := db.Where("user_id = ? AND is_revoked = ?", userID, false).Find(&tokens).Error; err != nil {
+		return 0, err
+	}
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	if err := db.Model(&models.AuthToken{}).Where("user_id = ? AND is_revoked = ?", userID, false).
+		Update("is_revoked", true).Error; err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, t := range tokens {
+		if t.AccessExpiresAt.After(now) {
+			BlacklistToken(t.AccessToken, t.AccessExpiresAt)
+		}
+	}
+	return int64(len(tokens)), nil
+}
+
+// SetCustomerStatus suspends or reactivates a customer. Suspending also ends all sessions.
+func SetCustomerStatus(userID uint64, status string) (*resdto.CustomerDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if status != models.UserStatusActive && status != models.UserStatusSuspended {
+		return nil, errors.New("status must be 'active' or 'suspended'")
+	}
+
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&user).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+	if status == models.UserStatusSuspended {
+		if _, err := RevokeCustomerSessions(userID); err != nil {
+			return nil, err
+		}
+	}
+	return GetCustomerDetail(userID)
+}
+
+// DeleteCustomer soft-deletes a customer and ends all sessions
+func DeleteCustomer(userID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return err
+	}
+	if err := db.Delete(&user).Error; err != nil {
+		return err
+	}
+	_, err := RevokeCustomerSessions(userID)
+	return err
+}
+
+// RestoreCustomer undoes a soft delete. The account keeps its previous status.
+func RestoreCustomer(userID uint64) (*resdto.CustomerDetailDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var user models.User
+	if err := db.Unscoped().First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+	if !user.DeletedAt.Valid {
+		return nil, ErrCustomerNotDeleted
+	}
+
+	if err := db.Unscoped().Model(&user).Update("deleted_at", nil).Error; err != nil {
+		return nil, err
+	}
+	return GetCustomerDetail(userID)
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"skinSync/config"
 	"skinSync/models"
+
+	"gorm.io/gorm"
 )
 
 // DoctorDTO represents a doctor in discovery responses
@@ -35,11 +37,60 @@ type TreatmentWithPriceDTO struct {
 	Price       *float64 `json:"price,omitempty"`
 }
 
+// localizeTreatmentDTOs replaces treatment names and descriptions with translations for locale
+func localizeTreatmentDTOs(treatments []TreatmentWithPriceDTO, locale string) error {
+	ids := make([]uint64, 0, len(treatments))
+	for _, t := range treatments {
+		ids = append(ids, uint64(t.ID))
+	}
+	tr, err := loadTranslations(TranslationEntityTreatment, ids, locale)
+	if err != nil {
+		return err
+	}
+	for i := range treatments {
+		t := &treatments[i]
+		t.Name = translated(tr, uint64(t.ID), "name", t.Name)
+		t.Description = translated(tr, uint64(t.ID), "description", t.Description)
+	}
+	return nil
+}
+
+// activeClinicIDs selects ids of clinics visible in discovery (active and not deleted)
+func activeClinicIDs(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Clinic{}).Select("id").Where("status = ?", models.ClinicStatusActive)
+}
+
+// publishedTreatmentDTO builds a discovery entry from the published catalog.
+// ok is false when the treatment is unpublished or archived there.
+func publishedTreatmentDTO(catalog *publishedCatalogIndex, treatmentID uint, price *float64) (TreatmentWithPriceDTO, bool) {
+	t, ok := catalog.activeTreatment(treatmentID)
+	if !ok {
+		return TreatmentWithPriceDTO{}, false
+	}
+	return TreatmentWithPriceDTO{
+		ID:          t.ID,
+		Name:        t.Name,
+		Icon:        t.Icon,
+		Description: t.Description,
+		Price:       price,
+	}, true
+}
+
+// isPublishedTreatment reports whether customers can currently see a treatment
+func isPublishedTreatment(treatmentID uint) (bool, error) {
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return false, err
+	}
+	_, ok := catalog.activeTreatment(treatmentID)
+	return ok, nil
+}
+
 // GetAllClinics returns all active clinics
 func GetAllClinics() ([]models.Clinic, error) {
 	db := config.DB
 	var clinics []models.Clinic
-	if err := db.Where("status = ?", "active").Find(&clinics).Error; err != nil {
+	if err := db.Where("status = ?", models.ClinicStatusActive).Find(&clinics).Error; err != nil {
 		return nil, err
 	}
 	return clinics, nil
@@ -52,8 +103,8 @@ func GetAllDoctors() ([]DoctorDTO, error) {
 	var clinicUsers []models.ClinicUser
 	if err := db.Preload("Role").
 		Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
-		Where("clinic_roles.name = ? AND clinic_users.status = ?",
-			models.ClinicRoleDoctor, "active").
+		Where("clinic_roles.name = ? AND clinic_users.status = ? AND clinic_users.clinic_id IN (?)",
+			models.ClinicRoleDoctor, "active", activeClinicIDs(db)).
 		Find(&clinicUsers).Error; err != nil {
 		return nil, err
 	}
@@ -71,26 +122,28 @@ func GetAllDoctors() ([]DoctorDTO, error) {
 	return doctors, nil
 }
 
-// GetTreatmentsByClinic returns treatments offered by a specific clinic
-func GetTreatmentsByClinic(clinicID uint64) ([]TreatmentWithPriceDTO, error) {
+// GetTreatmentsByClinic returns published treatments offered by a specific clinic
+func GetTreatmentsByClinic(clinicID uint64, locale string) ([]TreatmentWithPriceDTO, error) {
 	db := config.DB
 
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return nil, err
+	}
 	var clinicTreatments []models.ClinicTreatment
-	if err := db.Preload("Treatment").
-		Where("clinic_id = ? AND status = ?", clinicID, "active").
+	if err := db.Where("clinic_id = ? AND status = ? AND clinic_id IN (?)", clinicID, "active", activeClinicIDs(db)).
 		Find(&clinicTreatments).Error; err != nil {
 		return nil, err
 	}
 
 	treatments := make([]TreatmentWithPriceDTO, 0, len(clinicTreatments))
 	for _, ct := range clinicTreatments {
-		treatments = append(treatments, TreatmentWithPriceDTO{
-			ID:          ct.Treatment.ID,
-			Name:        ct.Treatment.Name,
-			Icon:        ct.Treatment.Icon,
-			Description: ct.Treatment.Description,
-			Price:       ct.Price,
-		})
+		if dto, ok := publishedTreatmentDTO(catalog, ct.TreatmentID, ct.Price); ok {
+			treatments = append(treatments, dto)
+		}
+	}
+	if err := localizeTreatmentDTOs(treatments, locale); err != nil {
+		return nil, err
 	}
 	return treatments, nil
 }
@@ -99,6 +152,10 @@ func GetTreatmentsByClinic(clinicID uint64) ([]TreatmentWithPriceDTO, error) {
 func GetClinicsByTreatment(treatmentID uint) ([]ClinicWithPriceDTO, error) {
 	db := config.DB
 
+	if published, err := isPublishedTreatment(treatmentID); err != nil || !published {
+		return []ClinicWithPriceDTO{}, err
+	}
+
 	var clinicTreatments []models.ClinicTreatment
 	if err := db.Preload("Clinic").
 		Where("treatment_id = ? AND status = ?", treatmentID, "active").
@@ -108,7 +165,7 @@ func GetClinicsByTreatment(treatmentID uint) ([]ClinicWithPriceDTO, error) {
 
 	clinics := make([]ClinicWithPriceDTO, 0, len(clinicTreatments))
 	for _, ct := range clinicTreatments {
-		if ct.Clinic.Status == "active" {
+		if ct.Clinic.Status == models.ClinicStatusActive {
 			clinics = append(clinics, ClinicWithPriceDTO{
 				ID:      ct.Clinic.ID,
 				Name:    ct.Clinic.Name,
@@ -125,24 +182,28 @@ func GetClinicsByTreatment(treatmentID uint) ([]ClinicWithPriceDTO, error) {
 }
 
 // GetTreatmentsByDoctor returns treatments a doctor can perform
-func GetTreatmentsByDoctor(doctorID uint64) ([]TreatmentWithPriceDTO, error) {
+func GetTreatmentsByDoctor(doctorID uint64, locale string) ([]TreatmentWithPriceDTO, error) {
 	db := config.DB
 
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return nil, err
+	}
 	var userTreatments []models.ClinicUserTreatment
-	if err := db.Preload("Treatment").
-		Where("clinic_user_id = ?", doctorID).
+	if err := db.Where("clinic_user_id = ? AND clinic_user_id IN (?)", doctorID,
+		db.Model(&models.ClinicUser{}).Select("id").Where("clinic_id IN (?)", activeClinicIDs(db))).
 		Find(&userTreatments).Error; err != nil {
 		return nil, err
 	}
 
 	treatments := make([]TreatmentWithPriceDTO, 0, len(userTreatments))
 	for _, ut := range userTreatments {
-		treatments = append(treatments, TreatmentWithPriceDTO{
-			ID:          ut.Treatment.ID,
-			Name:        ut.Treatment.Name,
-			Icon:        ut.Treatment.Icon,
-			Description: ut.Treatment.Description,
-		})
+		if dto, ok := publishedTreatmentDTO(catalog, ut.TreatmentID, nil); ok {
+			treatments = append(treatments, dto)
+		}
+	}
+	if err := localizeTreatmentDTOs(treatments, locale); err != nil {
+		return nil, err
 	}
 	return treatments, nil
 }
@@ -151,12 +212,16 @@ func GetTreatmentsByDoctor(doctorID uint64) ([]TreatmentWithPriceDTO, error) {
 func GetDoctorsByClinicAndTreatment(clinicID uint64, treatmentID uint) ([]DoctorDTO, error) {
 	db := config.DB
 
+	if published, err := isPublishedTreatment(treatmentID); err != nil || !published {
+		return []DoctorDTO{}, err
+	}
+
 	var clinicUsers []models.ClinicUser
 	if err := db.Preload("Role").
 		Joins("JOIN clinic_user_treatments ON clinic_user_treatments.clinic_user_id = clinic_users.id").
 		Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
-		Where("clinic_users.clinic_id = ? AND clinic_user_treatments.treatment_id = ? AND clinic_users.status = ? AND clinic_roles.name = ?",
-			clinicID, treatmentID, "active", models.ClinicRoleDoctor).
+		Where("clinic_users.clinic_id = ? AND clinic_user_treatments.treatment_id = ? AND clinic_users.status = ? AND clinic_roles.name = ? AND clinic_users.clinic_id IN (?)",
+			clinicID, treatmentID, "active", models.ClinicRoleDoctor, activeClinicIDs(db)).
 		Find(&clinicUsers).Error; err != nil {
 		return nil, err
 	}
@@ -175,20 +240,23 @@ func GetDoctorsByClinicAndTreatment(clinicID uint64, treatmentID uint) ([]Doctor
 }
 
 // GetTreatmentsByDoctorAndClinic returns treatments a doctor can perform at a specific clinic
-func GetTreatmentsByDoctorAndClinic(doctorID uint64, clinicID uint64) ([]TreatmentWithPriceDTO, error) {
+func GetTreatmentsByDoctorAndClinic(doctorID uint64, clinicID uint64, locale string) ([]TreatmentWithPriceDTO, error) {
 	db := config.DB
 
 	// Find the doctor at this clinic
 	var clinicUser models.ClinicUser
-	if err := db.Where("id = ? AND clinic_id = ? AND status = ?", doctorID, clinicID, "active").
+	if err := db.Where("id = ? AND clinic_id = ? AND status = ? AND clinic_id IN (?)", doctorID, clinicID, "active", activeClinicIDs(db)).
 		First(&clinicUser).Error; err != nil {
 		return nil, err
 	}
 
 	// Get treatments this doctor can perform
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return nil, err
+	}
 	var userTreatments []models.ClinicUserTreatment
-	if err := db.Preload("Treatment").
-		Where("clinic_user_id = ?", clinicUser.ID).
+	if err := db.Where("clinic_user_id = ?", clinicUser.ID).
 		Find(&userTreatments).Error; err != nil {
 		return nil, err
 	}
@@ -201,13 +269,12 @@ func GetTreatmentsByDoctorAndClinic(doctorID uint64, clinicID uint64) ([]Treatme
 			clinicID, ut.TreatmentID, "active").First(&ct).Error; err != nil {
 			continue // clinic doesn't offer this treatment
 		}
-		treatments = append(treatments, TreatmentWithPriceDTO{
-			ID:          ut.Treatment.ID,
-			Name:        ut.Treatment.Name,
-			Icon:        ut.Treatment.Icon,
-			Description: ut.Treatment.Description,
-			Price:       ct.Price,
-		})
+		if dto, ok := publishedTreatmentDTO(catalog, ut.TreatmentID, ct.Price); ok {
+			treatments = append(treatments, dto)
+		}
+	}
+	if err := localizeTreatmentDTOs(treatments, locale); err != nil {
+		return nil, err
 	}
 	return treatments, nil
 }
@@ -216,6 +283,10 @@ func GetTreatmentsByDoctorAndClinic(doctorID uint64, clinicID uint64) ([]Treatme
 func GetClinicsByDoctorAndTreatment(doctorID uint64, treatmentID uint) ([]ClinicWithPriceDTO, error) {
 	db := config.DB
 
+	if published, err := isPublishedTreatment(treatmentID); err != nil || !published {
+		return []ClinicWithPriceDTO{}, err
+	}
+
 	// Get all clinic_user records for this email (doctor at multiple clinics)
 	var doctor models.ClinicUser
 	if err := db.First(&doctor, doctorID).Error; err != nil {
@@ -235,7 +306,7 @@ func GetClinicsByDoctorAndTreatment(doctorID uint64, treatmentID uint) ([]Clinic
 	// Also check that the clinic actually offers this treatment
 	clinics := make([]ClinicWithPriceDTO, 0)
 	for _, u := range clinicUsers {
-		if u.Clinic.Status != "active" {
+		if u.Clinic.Status != models.ClinicStatusActive {
 			continue
 		}
 		// Check if clinic offers this treatment
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== TREATMENT DURATIONS ====================
+//
+// An appointment lasts the treatment's base minutes plus, per selected side area,
+// the minutes for the first syringe and the extra-syringe minutes for each further one.
+// Side areas without their own durations use their area's. Clinics may override the
+// base and side-area durations; nil overrides fall back to the catalog.
+
+// maxRequirementQuantity caps how many units of one resource a treatment can require
+const maxRequirementQuantity = 20
+
+var resourceTypePattern = regexp.MustCompile(`^[a-z0-9_]{2,50}$`)
+
+// ErrTreatmentNotOffered is returned when the clinic has no active price for the treatment
+var ErrTreatmentNotOffered = errors.New("clinic does not offer this treatment")
+
+// sideAreaCatalogDurations returns the catalog first-syringe and extra-syringe minutes of a side area
+func sideAreaCatalogDurations(sa models.SideArea) (int, int) {
+	minutes, extra := sa.DurationMinutes, sa.ExtraSyringeMinutes
+	if minutes == 0 {
+		minutes = sa.Area.DurationMinutes
+	}
+	if extra == 0 {
+		extra = sa.Area.ExtraSyringeMinutes
+	}
+	return minutes, extra
+}
+
+// effectiveSideAreaDurations applies a clinic's overrides to the catalog durations of a side area
+func effectiveSideAreaDurations(sa models.SideArea, offer *models.ClinicSideArea) (int, int) {
+	minutes, extra := sideAreaCatalogDurations(sa)
+	if offer != nil && offer.DurationMinutes != nil {
+		minutes = *offer.DurationMinutes
+	}
+	if offer != nil && offer.ExtraSyringeMinutes != nil {
+		extra = *offer.ExtraSyringeMinutes
+	}
+	return minutes, extra
+}
+
+// treatmentBaseMinutes returns the clinic's base minutes for a treatment, falling back to the catalog
+func treatmentBaseMinutes(db *gorm.DB, clinicID uint64, treatment models.Treatment) (int, error) {
+	var offered models.ClinicTreatment
+	err := db.Select("id", "duration_minutes").
+		Where("clinic_id = ? AND treatment_id = ?", clinicID, treatment.ID).
+		First(&offered).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	if err == nil && offered.DurationMinutes != nil {
+		return *offered.DurationMinutes, nil
+	}
+	return treatment.DurationMinutes, nil
+}
+
+// treatmentRequirements lists the resources needed for a treatment and the selected side areas
+func treatmentRequirements(db *gorm.DB, treatmentID uint, sideAreaIDs []uint) ([]models.TreatmentResourceRequirement, error) {
+	query := db.Where("treatment_id = ?", treatmentID)
+	if len(sideAreaIDs) > 0 {
+		query = query.Where("side_area_id = 0 OR side_area_id IN ?", sideAreaIDs)
+	} else {
+		query = query.Where("side_area_id = 0")
+	}
+	requirements := []models.TreatmentResourceRequirement{}
+	if err := query.Order("id").Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+// estimateAppointmentDuration computes the length of resolved appointment items at a clinic from the
+// published catalog durations and the clinic's overrides.
+// The total is rounded up to whole slot blocks; when nothing is configured the default length applies.
+func estimateAppointmentDuration(db *gorm.DB, clinicID uint64, treatmentID uint, items []models.AppointmentItem) (*resdto.AppointmentDurationDTO, error) {
+	catalog, err := loadPublishedCatalogIndex()
+	if err != nil {
+		return nil, err
+	}
+	treatment, ok := catalog.treatments[treatmentID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	base, err := treatmentBaseMinutes(db, clinicID, treatment)
+	if err != nil {
+		return nil, err
+	}
+
+	sideAreaIDs := make([]uint, 0, len(items))
+	for _, it := range items {
+		sideAreaIDs = append(sideAreaIDs, it.SideAreaID)
+	}
+	offers := map[[2]uint]*models.ClinicSideArea{}
+	if len(sideAreaIDs) > 0 {
+		var offerRows []models.ClinicSideArea
+		if err := db.Where("clinic_id = ? AND treatment_id = ? AND side_area_id IN ?", clinicID, treatmentID, sideAreaIDs).
+			Find(&offerRows).Error; err != nil {
+			return nil, err
+		}
+		for i := range offerRows {
+			offers[[2]uint{offerRows[i].SideAreaID, uint(offerRows[i].SyringeSize)}] = &offerRows[i]
+		}
+	}
+
+	result := &resdto.AppointmentDurationDTO{
+		ClinicID:    clinicID,
+		TreatmentID: treatmentID,
+		BaseMinutes: base,
+		Items:       make([]resdto.AppointmentDurationItemDTO, 0, len(items)),
+	}
+	total := base
+	for _, it := range items {
+		sa := catalog.sideAreas[it.SideAreaID]
+		minutes, extra := effectiveSideAreaDurations(sa, offers[[2]uint{it.SideAreaID, uint(it.SyringeSize)}])
+		if it.SyringeCount > 1 {
+			minutes += extra * (it.SyringeCount - 1)
+		}
+		total += minutes
+		result.Items = append(result.Items, resdto.AppointmentDurationItemDTO{
+			SideAreaID:   it.SideAreaID,
+			SideAreaName: sa.Name,
+			SyringeCount: it.SyringeCount,
+			SyringeSize:  it.SyringeSize,
+			Minutes:      minutes,
+		})
+	}
+
+	duration := time.Duration(total) * time.Minute
+	if total == 0 {
+		duration = getDefaultAppointmentDuration()
+		result.DefaultApplied = true
+	}
+	if rem := duration % appointmentBlock; rem != 0 {
+		duration += appointmentBlock - rem
+	}
+	if duration > maxAppointmentMinutes*time.Minute {
+		return nil, fmt.Errorf("the selection takes longer than the maximum appointment length of %d minutes", maxAppointmentMinutes)
+	}
+	result.TotalMinutes = int(duration / time.Minute)
+
+	if result.Requirements, err = effectiveTreatmentRequirements(db, clinicID, treatmentID, sideAreaIDs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EstimateAppointmentDuration computes the appointment length of a treatment selection at a clinic
+func EstimateAppointmentDuration(clinicID uint64, req reqdto.AppointmentDurationRequest) (*resdto.AppointmentDurationDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if req.TreatmentID == 0 {
+		return nil, errors.New("treatment_id is required")
+	}
+	if _, err := checkBookableClinic(db, clinicID); err != nil {
+		return nil, err
+	}
+	items, _, err := resolveAppointmentItems(db, clinicID, req.TreatmentID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	return estimateAppointmentDuration(db, clinicID, req.TreatmentID, items)
+}
+
+// ==================== CLINIC DURATION OVERRIDES ====================
+
+// clinicTreatmentDurations builds the duration settings of a clinic treatment
+func clinicTreatmentDurations(db *gorm.DB, clinicID uint64, treatmentID uint) (*resdto.ClinicTreatmentDurationsDTO, error) {
+	var treatment models.Treatment
+	if err := db.Select("id", "duration_minutes").First(&treatment, treatmentID).Error; err != nil {
+		return nil, err
+	}
+	var offered models.ClinicTreatment
+	err := db.Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).First(&offered).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTreatmentNotOffered
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &resdto.ClinicTreatmentDurationsDTO{
+		TreatmentID:          treatmentID,
+		CatalogBaseMinutes:   treatment.DurationMinutes,
+		BaseMinutes:          offered.DurationMinutes,
+		EffectiveBaseMinutes: treatment.DurationMinutes,
+		SideAreas:            []resdto.ClinicSideAreaDurationDTO{},
+	}
+	if offered.DurationMinutes != nil {
+		result.EffectiveBaseMinutes = *offered.DurationMinutes
+	}
+
+	var offers []models.ClinicSideArea
+	if err := db.Preload("SideArea.Area").
+		Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).
+		Order("side_area_id, syringe_size").Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	for i := range offers {
+		o := &offers[i]
+		catalogMinutes, catalogExtra := sideAreaCatalogDurations(o.SideArea)
+		minutes, extra := effectiveSideAreaDurations(o.SideArea, o)
+		result.SideAreas = append(result.SideAreas, resdto.ClinicSideAreaDurationDTO{
+			SideAreaID:                   o.SideAreaID,
+			SideAreaName:                 o.SideArea.Name,
+			SyringeSize:                  o.SyringeSize,
+			CatalogMinutes:               catalogMinutes,
+			CatalogExtraSyringeMinutes:   catalogExtra,
+			DurationMinutes:              o.DurationMinutes,
+			ExtraSyringeMinutes:          o.ExtraSyringeMinutes,
+			EffectiveMinutes:             minutes,
+			EffectiveExtraSyringeMinutes: extra,
+		})
+	}
+	return result, nil
+}
+
+// GetClinicTreatmentDurations returns a clinic's duration overrides for a treatment next to the catalog values
+func GetClinicTreatmentDurations(clinicID uint64, treatmentID uint) (*resdto.ClinicTreatmentDurationsDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return clinicTreatmentDurations(db, clinicID, treatmentID)
+}
+
+// SetClinicTreatmentDurations replaces a clinic's duration overrides for a treatment
+func SetClinicTreatmentDurations(clinicID uint64, treatmentID uint, req reqdto.SetClinicTreatmentDurationsRequest) (*resdto.ClinicTreatmentDurationsDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var offered models.ClinicTreatment
+	err := db.Where("treatment_id = ?", treatmentID).First(&offered).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTreatmentNotOffered
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.BaseMinutes != nil {
+		if err := validateDurationMinutes("base_minutes", *req.BaseMinutes, maxAppointmentMinutes); err != nil {
+			return nil, err
+		}
+	}
+
+	var offers []models.ClinicSideArea
+	if err := db.Where("treatment_id = ?", treatmentID).Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	type override struct {
+		minutes, extra *int
+	}
+	overrides := make(map[uint]override, len(offers))
+	for i, r := range req.SideAreas {
+		if r.DurationMinutes != nil {
+			if err := validateDurationMinutes(fmt.Sprintf("side_areas[%d].duration_minutes", i), *r.DurationMinutes, maxAppointmentMinutes); err != nil {
+				return nil, err
+			}
+		}
+		if r.ExtraSyringeMinutes != nil {
+			if err := validateDurationMinutes(fmt.Sprintf("side_areas[%d].extra_syringe_minutes", i), *r.ExtraSyringeMinutes, maxExtraSyringeMinutes); err != nil {
+				return nil, err
+			}
+		}
+		matched := false
+		for _, o := range offers {
+			if o.SideAreaID != r.SideAreaID || (r.SyringeSize != nil && o.SyringeSize != *r.SyringeSize) {
+				continue
+			}
+			if _, dup := overrides[o.ID]; dup {
+				return nil, fmt.Errorf("side area %d is listed more than once", r.SideAreaID)
+			}
+			overrides[o.ID] = override{minutes: r.DurationMinutes, extra: r.ExtraSyringeMinutes}
+			matched = true
+		}
+		if !matched {
+			return nil, fmt.Errorf("clinic does not price side area %d for this treatment at the given syringe size", r.SideAreaID)
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&offered).Update("duration_minutes", req.BaseMinutes).Error; err != nil {
+			return err
+		}
+		for _, o := range offers {
+			ov := overrides[o.ID]
+			if err := tx.Model(&models.ClinicSideArea{}).Where("id = ?", o.ID).Updates(map[string]interface{}{
+				"duration_minutes":      ov.minutes,
+				"extra_syringe_minutes": ov.extra,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clinicTreatmentDurations(db, clinicID, treatmentID)
+}
+
+// ==================== TREATMENT RESOURCE REQUIREMENTS ====================
+
+// validateRequirement checks one requirement of a treatment and returns its normalized resource type.
+// seen tracks side area/resource type pairs already listed in the same request.
+func validateRequirement(db *gorm.DB, treatmentID uint, i int, sideAreaID uint, kind, resourceType string, quantity int, seen map[string]bool) (string, error) {
+	if kind != models.ResourceKindRoom && kind != models.ResourceKindEquipment {
+		return "", fmt.Errorf("%w: requirements[%d].kind must be 'room' or 'equipment'", ErrInvalidCatalogItem, i)
+	}
+	resourceType = strings.ToLower(strings.TrimSpace(resourceType))
+	if !resourceTypePattern.MatchString(resourceType) {
+		return "", fmt.Errorf("%w: requirements[%d].resource_type must be 2-50 lowercase letters, digits or underscores", ErrInvalidCatalogItem, i)
+	}
+	if quantity < 0 || quantity > maxRequirementQuantity {
+		return "", fmt.Errorf("%w: requirements[%d].quantity must be between 0 and %d", ErrInvalidCatalogItem, i, maxRequirementQuantity)
+	}
+	if sideAreaID != 0 {
+		var count int64
+		if err := db.Model(&models.SideArea{}).Where("id = ? AND treatment_id = ?", sideAreaID, treatmentID).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return "", fmt.Errorf("%w: side area %d is not part of this treatment", ErrInvalidCatalogItem, sideAreaID)
+		}
+	}
+	key := fmt.Sprintf("%d:%s", sideAreaID, resourceType)
+	if seen[key] {
+		return "", fmt.Errorf("%w: resource_type %s is listed more than once for the same side area", ErrInvalidCatalogItem, resourceType)
+	}
+	seen[key] = true
+	return resourceType, nil
+}
+
+// GetTreatmentRequirements lists the room and equipment requirements of a catalog treatment
+func GetTreatmentRequirements(treatmentID uint) ([]models.TreatmentResourceRequirement, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := db.Select("id").First(&models.Treatment{}, treatmentID).Error; err != nil {
+		return nil, err
+	}
+	requirements := []models.TreatmentResourceRequirement{}
+	if err := db.Where("treatment_id = ?", treatmentID).Order("side_area_id, id").Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+// SetTreatmentRequirements replaces the room and equipment requirements of a catalog treatment
+func SetTreatmentRequirements(treatmentID uint, req reqdto.SetTreatmentRequirementsRequest) ([]models.TreatmentResourceRequirement, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := db.Select("id").First(&models.Treatment{}, treatmentID).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.TreatmentResourceRequirement, 0, len(req.Requirements))
+	seen := make(map[string]bool, len(req.Requirements))
+	for i, r := range req.Requirements {
+		if r.Quantity == 0 {
+			r.Quantity = 1
+		}
+		resourceType, err := validateRequirement(db, treatmentID, i, r.SideAreaID, r.Kind, r.ResourceType, r.Quantity, seen)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, models.TreatmentResourceRequirement{
+			TreatmentID:  treatmentID,
+			SideAreaID:   r.SideAreaID,
+			Kind:         r.Kind,
+			ResourceType: resourceType,
+			Quantity:     r.Quantity,
+		})
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("treatment_id = ?", treatmentID).Delete(&models.TreatmentResourceRequirement{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetTreatmentRequirements(treatmentID)
+}
@@ -0,0 +1,614 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"gorm.io/gorm"
+)
+
+// ==================== LIST EXPORTS (CSV / XLSX) ====================
+
+var (
+	// ErrExportDatasetNotFound is returned for unknown datasets or datasets the caller cannot export
+	ErrExportDatasetNotFound = errors.New("export dataset not found")
+	// ErrExportJobNotReady is returned when downloading a job that has not completed
+	ErrExportJobNotReady = errors.New("export is not ready")
+	// ErrExportJobExpired is returned when downloading a job whose file has been cleaned up
+	ErrExportJobExpired = errors.New("export file has expired, please run the export again")
+)
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportColumn is one selectable column of a dataset
+type ExportColumn struct {
+	Key    string `json:"key"`
+	Header string `json:"header"`
+	expr   string
+}
+
+// ExportDataset is a list that can be exported. base builds the FROM/JOIN/WHERE part;
+// clinicColumn is the column used to restrict rows to one clinic.
+type ExportDataset struct {
+	Name         string         `json:"name"`
+	Title        string         `json:"title"`
+	ForClinics   bool           `json:"-"`
+	Columns      []ExportColumn `json:"columns"`
+	clinicColumn string
+	order        string
+	base         func(db *gorm.DB) *gorm.DB
+}
+
+// exportDatasets lists every exportable list. Clinic users may only export datasets with ForClinics set.
+var exportDatasets = []ExportDataset{
+	{
+		Name:  "clinics",
+		Title: "Clinics",
+		Columns: []ExportColumn{
+			{Key: "id", Header: "ID", expr: "clinics.id"},
+			{Key: "name", Header: "Name", expr: "clinics.name"},
+			{Key: "email", Header: "Email", expr: "clinics.email"},
+			{Key: "phone", Header: "Phone", expr: "clinics.phone"},
+			{Key: "address", Header: "Address", expr: "clinics.address"},
+			{Key: "status", Header: "Status", expr: "clinics.status"},
+			{Key: "created_at", Header: "Created At", expr: "clinics.created_at"},
+		},
+		clinicColumn: "clinics.id",
+		order:        "clinics.id",
+		base: func(db *gorm.DB) *gorm.DB {
+			return db.Table("clinics").Where("clinics.deleted_at IS NULL")
+		},
+	},
+	{
+		Name:       "doctors",
+		Title:      "Doctors",
+		ForClinics: true,
+		Columns: []ExportColumn{
+			{Key: "id", Header: "ID", expr: "clinic_users.id"},
+			{Key: "clinic_id", Header: "Clinic ID", expr: "clinic_users.clinic_id"},
+			{Key: "clinic_name", Header: "Clinic", expr: "clinics.name"},
+			{Key: "name", Header: "Name", expr: "clinic_users.name"},
+			{Key: "email", Header: "Email", expr: "clinic_users.email"},
+			{Key: "role", Header: "Role", expr: "clinic_roles.name"},
+			{Key: "status", Header: "Status", expr: "clinic_users.status"},
+			{Key: "specialization", Header: "Specialization", expr: "clinic_user_profiles.specialization"},
+			{Key: "phone", Header: "Phone", expr: "clinic_user_profiles.phone"},
+			{Key: "created_at", Header: "Created At", expr: "clinic_users.created_at"},
+		},
+		clinicColumn: "clinic_users.clinic_id",
+		order:        "clinic_users.clinic_id, clinic_users.id",
+		base: func(db *gorm.DB) *gorm.DB {
+			return db.Table("clinic_users").
+				Joins("JOIN clinic_roles ON clinic_roles.id = clinic_users.role_id").
+				Joins("JOIN clinics ON clinics.id = clinic_users.clinic_id AND clinics.deleted_at IS NULL").
+				Joins("LEFT JOIN clinic_user_profiles ON clinic_user_profiles.clinic_user_id = clinic_users.id").
+				Where("clinic_users.deleted_at IS NULL AND clinic_roles.name IN ?",
+					[]string{models.ClinicRoleDoctor, models.ClinicRoleInjector})
+		},
+	},
+	{
+		Name:  "customers",
+		Title: "Customers",
+		Columns: []ExportColumn{
+			{Key: "id", Header: "ID", expr: "users.id"},
+			{Key: "name", Header: "Name", expr: "(SELECT user_profiles.name FROM user_profiles WHERE user_profiles.user_id = users.id ORDER BY user_profiles.user_profile_id LIMIT 1)"},
+			{Key: "primary_email", Header: "Email", expr: "users.primary_email"},
+			{Key: "primary_phone", Header: "Phone", expr: "users.primary_phone"},
+			{Key: "providers", Header: "Login Providers", expr: "(SELECT GROUP_CONCAT(DISTINCT auth_providers.provider ORDER BY auth_providers.provider) FROM auth_providers WHERE auth_providers.user_id = users.id)"},
+			{Key: "status", Header: "Status", expr: "users.status"},
+			{Key: "created_at", Header: "Signed Up At", expr: "users.created_at"},
+		},
+		order: "users.id",
+		base: func(db *gorm.DB) *gorm.DB {
+			return db.Table("users").Where("users.deleted_at IS NULL")
+		},
+	},
+	{
+		Name:       "clinic_prices",
+		Title:      "Clinic Prices",
+		ForClinics: true,
+		Columns: []ExportColumn{
+			{Key: "clinic_id", Header: "Clinic ID", expr: "clinic_side_areas.clinic_id"},
+			{Key: "clinic_name", Header: "Clinic", expr: "clinics.name"},
+			{Key: "treatment_id", Header: "Treatment ID", expr: "clinic_side_areas.treatment_id"},
+			{Key: "treatment", Header: "Treatment", expr: "treatments.name"},
+			{Key: "area_id", Header: "Area ID", expr: "clinic_side_areas.area_id"},
+			{Key: "area", Header: "Area", expr: "areas.name"},
+			{Key: "side_area_id", Header: "Side Area ID", expr: "clinic_side_areas.side_area_id"},
+			{Key: "side_area", Header: "Side Area", expr: "side_areas.name"},
+			{Key: "syringe_size", Header: "Syringe Size", expr: "clinic_side_areas.syringe_size"},
+			{Key: "price", Header: "Price", expr: "clinic_side_areas.price"},
+			{Key: "status", Header: "Status", expr: "clinic_side_areas.status"},
+		},
+		clinicColumn: "clinic_side_areas.clinic_id",
+		order:        "clinic_side_areas.clinic_id, clinic_side_areas.treatment_id, clinic_side_areas.area_id, clinic_side_areas.side_area_id, clinic_side_areas.syringe_size",
+		base: func(db *gorm.DB) *gorm.DB {
+			return db.Table("clinic_side_areas").
+				Joins("JOIN clinics ON clinics.id = clinic_side_areas.clinic_id AND clinics.deleted_at IS NULL").
+				Joins("JOIN treatments ON treatments.id = clinic_side_areas.treatment_id").
+				Joins("JOIN areas ON areas.id = clinic_side_areas.area_id").
+				Joins("JOIN side_areas ON side_areas.id = clinic_side_areas.side_area_id")
+		},
+	},
+	{
+		Name:  "onboarding_answers",
+		Title: "Onboarding Answers",
+		Columns: []ExportColumn{
+			{Key: "user_id", Header: "Customer ID", expr: "users.id"},
+			{Key: "user_email", Header: "Customer Email", expr: "users.primary_email"},
+			{Key: "question_id", Header: "Question ID", expr: "skin_condition_questions.id"},
+			{Key: "question", Header: "Question", expr: "skin_condition_questions.question_text"},
+			{Key: "option_id", Header: "Option ID", expr: "skin_condition_question_options.id"},
+			{Key: "option", Header: "Answer", expr: "skin_condition_question_options.option_text"},
+		},
+		order: "users.id, skin_condition_questions.id",
+		base: func(db *gorm.DB) *gorm.DB {
+			return db.Table("skin_condition_question_answers").
+				Joins("JOIN users ON users.id = skin_condition_question_answers.user_id AND users.deleted_at IS NULL").
+				Joins("JOIN skin_condition_questions ON skin_condition_questions.id = skin_condition_question_answers.question_id").
+				Joins("JOIN skin_condition_question_options ON skin_condition_question_options.id = skin_condition_question_answers.option_id")
+		},
+	},
+}
+
+// ExportRequest describes one export. ClinicID restricts rows to one clinic;
+// ForClinic limits the caller to datasets clinics may export.
+type ExportRequest struct {
+	Dataset   string
+	Format    string
+	Columns   []string
+	ClinicID  *uint64
+	ForClinic bool
+}
+
+// ListExportDatasets returns the datasets available to admins or clinic users
+func ListExportDatasets(forClinic bool) []ExportDataset {
+	out := []ExportDataset{}
+	for _, ds := range exportDatasets {
+		if !forClinic || ds.ForClinics {
+			out = append(out, ds)
+		}
+	}
+	return out
+}
+
+// resolveExport validates an export request and returns its dataset and selected columns
+func resolveExport(req ExportRequest) (*ExportDataset, []ExportColumn, error) {
+	var ds *ExportDataset
+	for i := range exportDatasets {
+		if exportDatasets[i].Name == req.Dataset {
+			ds = &exportDatasets[i]
+			break
+		}
+	}
+	if ds == nil || (req.ForClinic && !ds.ForClinics) {
+		return nil, nil, ErrExportDatasetNotFound
+	}
+	if req.Format != ExportFormatCSV && req.Format != ExportFormatXLSX {
+		return nil, nil, errors.New("format must be csv or xlsx")
+	}
+	if req.ClinicID != nil && ds.clinicColumn == "" {
+		return nil, nil, fmt.Errorf("%s cannot be filtered by clinic", ds.Name)
+	}
+	if req.ForClinic && req.ClinicID == nil {
+		return nil, nil, errors.New("clinic not in context")
+	}
+
+	if len(req.Columns) == 0 {
+		return ds, ds.Columns, nil
+	}
+	byKey := make(map[string]ExportColumn, len(ds.Columns))
+	for _, col := range ds.Columns {
+		byKey[col.Key] = col
+	}
+	cols := make([]ExportColumn, 0, len(req.Columns))
+	seen := map[string]bool{}
+	for _, key := range req.Columns {
+		key = strings.TrimSpace(key)
+		col, ok := byKey[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown column %q for %s", key, ds.Name)
+		}
+		if !seen[key] {
+			seen[key] = true
+			cols = append(cols, col)
+		}
+	}
+	return ds, cols, nil
+}
+
+// exportQuery builds the filtered base query for a request
+func exportQuery(db *gorm.DB, ds *ExportDataset, req ExportRequest) *gorm.DB {
+	query := ds.base(db)
+	if req.ClinicID != nil {
+		query = query.Where(ds.clinicColumn+" = ?", *req.ClinicID)
+	}
+	return query
+}
+
+// CountExportRows returns how many rows an export would produce
+func CountExportRows(req ExportRequest) (int64, error) {
+	db := config.DB
+	if db == nil {
+		return 0, errors.New("database not initialized")
+	}
+	ds, _, err := resolveExport(req)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	err = exportQuery(db, ds, req).Count(&n).Error
+	return n, err
+}
+
+// exportRowWriter is implemented by the CSV and XLSX writers
+type exportRowWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+// csvRowWriter adapts encoding/csv to exportRowWriter
+type csvRowWriter struct{ w *csv.Writer }
+
+// WriteRow writes one row, neutralizing cells a spreadsheet would run as a formula
+func (c csvRowWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = csvSafeCell(cell)
+	}
+	return c.w.Write(escaped)
+}
+
+// csvSafeCell prefixes a cell starting with a formula trigger with a quote so spreadsheets show it as text
+func csvSafeCell(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (c csvRowWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ExportContentType returns the MIME type for an export format
+func ExportContentType(format string) string {
+	if format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// ExportFileName builds a download name such as clinic_prices-20250102-150405.xlsx
+func ExportFileName(req ExportRequest) string {
+	return fmt.Sprintf("%s-%s.%s", req.Dataset, time.Now().Format("20060102-150405"), req.Format)
+}
+
+// StreamExport writes the export to w row by row without buffering the result set.
+// Returns the number of data rows written.
+func StreamExport(w io.Writer, req ExportRequest) (int64, error) {
+	db := config.DB
+	if db == nil {
+		return 0, errors.New("database not initialized")
+	}
+	ds, cols, err := resolveExport(req)
+	if err != nil {
+		return 0, err
+	}
+
+	selects := make([]string, 0, len(cols))
+	headers := make([]string, 0, len(cols))
+	for i, col := range cols {
+		selects = append(selects, fmt.Sprintf("%s AS c%d", col.expr, i))
+		headers = append(headers, col.Header)
+	}
+
+	rows, err := exportQuery(db, ds, req).Select(strings.Join(selects, ", ")).Order(ds.order).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var out exportRowWriter
+	if req.Format == ExportFormatXLSX {
+		xw, err := utils.NewXLSXWriter(w, ds.Title)
+		if err != nil {
+			return 0, err
+		}
+		out = xw
+	} else {
+		out = csvRowWriter{w: csv.NewWriter(w)}
+	}
+	if err := out.WriteRow(headers); err != nil {
+		return 0, err
+	}
+
+	values := make([]sql.NullString, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	cells := make([]string, len(cols))
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, err
+		}
+		for i, v := range values {
+			cells[i] = v.String
+		}
+		if err := out.WriteRow(cells); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+	return count, out.Close()
+}
+
+// exportSyncRowLimit returns the largest export streamed in the request (EXPORT_SYNC_ROW_LIMIT, default 5000).
+// Larger exports run as background jobs.
+func exportSyncRowLimit() int64 {
+	limit, err := strconv.ParseInt(os.Getenv("EXPORT_SYNC_ROW_LIMIT"), 10, 64)
+	if err != nil || limit <= 0 {
+		return 5000
+	}
+	return limit
+}
+
+// ShouldRunExportInBackground reports whether an export is too large to stream in the request
+func ShouldRunExportInBackground(req ExportRequest) (bool, error) {
+	n, err := CountExportRows(req)
+	if err != nil {
+		return false, err
+	}
+	return n > exportSyncRowLimit(), nil
+}
+
+// exportDir returns the directory background export files are written to (EXPORT_DIR, default "exports")
+func exportDir() string {
+	if dir := strings.TrimSpace(os.Getenv("EXPORT_DIR")); dir != "" {
+		return dir
+	}
+	return "exports"
+}
+
+// exportRetention returns how long finished export files are kept (EXPORT_RETENTION_HOURS, default 24)
+func exportRetention() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("EXPORT_RETENTION_HOURS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// exportJobPath is where a background job writes its file
+func exportJobPath(jobID uint64, format string) string {
+	return filepath.Join(exportDir(), fmt.Sprintf("export-%d.%s", jobID, format))
+}
+
+// CreateExportJob queues an export and starts it in the background
+func CreateExportJob(req ExportRequest, requestedByType string, requestedByID uint64) (*models.ExportJob, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	_, cols, err := resolveExport(req)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(cols))
+	for _, col := range cols {
+		keys = append(keys, col.Key)
+	}
+	req.Columns = keys
+
+	job := models.ExportJob{
+		Dataset:         req.Dataset,
+		Format:          req.Format,
+		Columns:         strings.Join(req.Columns, ","),
+		RequestedByType: requestedByType,
+		RequestedByID:   requestedByID,
+		ClinicID:        req.ClinicID,
+		Status:          models.ExportJobPending,
+		FileName:        ExportFileName(req),
+	}
+	if err := db.Create(&job).Error; err != nil {
+		return nil, err
+	}
+
+	go runExportJob(job.ID, req)
+	return &job, nil
+}
+
+// runExportJob writes the export file and records the outcome on the job
+func runExportJob(jobID uint64, req ExportRequest) {
+	db := config.DB
+	started := time.Now()
+	db.Model(&models.ExportJob{}).Where("id = ?", jobID).
+		Updates(map[string]interface{}{"status": models.ExportJobRunning, "started_at": started})
+
+	fail := func(err error) {
+		log.Printf("export job %d failed: %v", jobID, err)
+		now := time.Now()
+		db.Model(&models.ExportJob{}).Where("id = ?", jobID).
+			Updates(map[string]interface{}{"status": models.ExportJobFailed, "error": err.Error(), "completed_at": now})
+	}
+
+	if err := os.MkdirAll(exportDir(), 0o755); err != nil {
+		fail(err)
+		return
+	}
+	path := exportJobPath(jobID, req.Format)
+	f, err := os.Create(path)
+	if err != nil {
+		fail(err)
+		return
+	}
+	count, err := StreamExport(f, req)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		fail(err)
+		return
+	}
+
+	now := time.Now()
+	db.Model(&models.ExportJob{}).Where("id = ?", jobID).Updates(map[string]interface{}{
+		"status":       models.ExportJobCompleted,
+		"file_path":    path,
+		"row_count":    count,
+		"completed_at": now,
+	})
+}
+
+// FailStaleExportJobs marks jobs left pending or running by a previous process as failed and removes
+// their partial files. Jobs only run in the process that queued them, so call it once at startup.
+func FailStaleExportJobs() {
+	db := config.DB
+	if db == nil {
+		return
+	}
+	var jobs []models.ExportJob
+	if err := db.Select("id", "format").
+		Where("status IN ?", []string{models.ExportJobPending, models.ExportJobRunning}).
+		Find(&jobs).Error; err != nil {
+		log.Printf("export jobs: failed to load stale jobs: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if err := os.Remove(exportJobPath(job.ID, job.Format)); err != nil && !os.IsNotExist(err) {
+			log.Printf("export job %d: failed to remove partial file: %v", job.ID, err)
+		}
+		now := time.Now()
+		if err := db.Model(&models.ExportJob{}).
+			Where("id = ? AND status IN ?", job.ID, []string{models.ExportJobPending, models.ExportJobRunning}).
+			Updates(map[string]interface{}{"status": models.ExportJobFailed, "error": "interrupted by a server restart", "completed_at": now}).Error; err != nil {
+			log.Printf("export job %d: failed to mark as failed: %v", job.ID, err)
+		}
+	}
+	if len(jobs) > 0 {
+		log.Printf("export jobs: marked %d interrupted jobs as failed", len(jobs))
+	}
+}
+
+// StartExportCleanup runs a background goroutine that deletes export files older than the retention period every hour
+func StartExportCleanup() {
+	go func() {
+		for {
+			if err := expireExportFiles(time.Now()); err != nil {
+				log.Printf("export cleanup: %v", err)
+			}
+			time.Sleep(time.Hour)
+		}
+	}()
+}
+
+// expireExportFiles removes the files of jobs completed before the retention period and marks them expired
+func expireExportFiles(now time.Time) error {
+	db := config.DB
+	if db == nil {
+		return nil
+	}
+	var jobs []models.ExportJob
+	if err := db.Select("id", "file_path").
+		Where("status = ? AND completed_at < ?", models.ExportJobCompleted, now.Add(-exportRetention())).
+		Find(&jobs).Error; err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if job.FilePath != "" {
+			if err := os.Remove(job.FilePath); err != nil && !os.IsNotExist(err) {
+				log.Printf("export job %d: failed to remove file: %v", job.ID, err)
+				continue
+			}
+		}
+		if err := db.Model(&models.ExportJob{}).Where("id = ? AND status = ?", job.ID, models.ExportJobCompleted).
+			Updates(map[string]interface{}{"status": models.ExportJobExpired, "file_path": ""}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportJobScope restricts job queries to what the caller may see:
+// clinic users see their clinic's jobs, admins see jobs requested by admins.
+func exportJobScope(db *gorm.DB, clinicID *uint64) *gorm.DB {
+	if clinicID != nil {
+		return db.Where("requested_by_type = ? AND clinic_id = ?", models.AuditActorClinicUser, *clinicID)
+	}
+	return db.Where("requested_by_type = ?", models.AuditActorAdmin)
+}
+
+// ListExportJobs returns recent export jobs visible to the caller
+func ListExportJobs(clinicID *uint64, page, pageSize int) (*resdto.ExportJobListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := exportJobScope(db.Model(&models.ExportJob{}), clinicID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	jobs := []models.ExportJob{}
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.ExportJobListResponse{
+		Items: jobs,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// GetExportJob returns one export job visible to the caller
+func GetExportJob(jobID uint64, clinicID *uint64) (*models.ExportJob, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var job models.ExportJob
+	if err := exportJobScope(db, clinicID).First(&job, jobID).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetExportJobFile returns the file of a completed export job
+func GetExportJobFile(jobID uint64, clinicID *uint64) (*models.ExportJob, error) {
+	job, err := GetExportJob(jobID, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == models.ExportJobExpired {
+		return nil, ErrExportJobExpired
+	}
+	if job.Status != models.ExportJobCompleted || job.FilePath == "" {
+		return nil, ErrExportJobNotReady
+	}
+	return job, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCSVRowWriterNeutralizesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	w := csvRowWriter{w: csv.NewWriter(&buf)}
+	row := []string{"=HYPERLINK(\"http://x\")", "+1", "-2", "@SUM(A1)", "\tcmd", "Glow Clinic", "", "a=b"}
+	if err := w.WriteRow(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := csv.NewReader(&buf).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"'=HYPERLINK(\"http://x\")", "'+1", "'-2", "'@SUM(A1)", "'\tcmd", "Glow Clinic", "", "a=b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("cell %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
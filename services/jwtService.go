@@ -3,6 +3,10 @@ package services
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"math"
+	"skinSync/config"
+	"skinSync/models"
 	"sync"
 	"time"
 
@@ -33,6 +37,151 @@ func IsTokenBlacklisted(token string) bool {
 	return exists
 }
 
+// sessionCutoffPrecision matches the DATETIME(3) columns the cut-offs are stored in
+const sessionCutoffPrecision = time.Millisecond
+
+// newSessionCutoff returns the revocation time written to a sessions_revoked_at column.
+// Tokens issued strictly before it are rejected, so a login right after a revocation stays valid.
+func newSessionCutoff() time.Time {
+	return time.Now().Truncate(sessionCutoffPrecision)
+}
+
+// tokenIssuedAtClaim returns the iat claim for a new token at millisecond resolution
+func tokenIssuedAtClaim() float64 {
+	return float64(time.Now().UnixMilli()) / 1000
+}
+
+// TokenIssuedAt reads a token's iat claim, accepting whole and fractional seconds
+func TokenIssuedAt(claims jwt.MapClaims) time.Time {
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(math.Round(iat * 1000)))
+}
+
+// sessionCutoffRow receives a sessions_revoked_at column
+type sessionCutoffRow struct {
+	SessionsRevokedAt *time.Time
+}
+
+// revokedBefore reports whether a token issued at issuedAt falls before a stored cut-off
+func revokedBefore(cutoff *time.Time, issuedAt time.Time) bool {
+	return cutoff != nil && issuedAt.Before(*cutoff)
+}
+
+// RevokeClinicSessions invalidates every token issued so far to staff of a clinic.
+// The cut-off is stored on the clinic so it survives restarts and is shared by all instances.
+func RevokeClinicSessions(clinicID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	return db.Unscoped().Model(&models.Clinic{}).Where("id = ?", clinicID).
+		UpdateColumn("sessions_revoked_at", newSessionCutoff()).Error
+}
+
+// RevokeClinicUserSessions invalidates every token issued so far to one clinic user.
+// Removed staff are included so their cut-off is still recorded.
+func RevokeClinicUserSessions(clinicUserID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	return db.Unscoped().Model(&models.ClinicUser{}).Where("id = ?", clinicUserID).
+		UpdateColumn("sessions_revoked_at", newSessionCutoff()).Error
+}
+
+// clinicUserSessionRow receives a clinic user's cut-off and removal time
+type clinicUserSessionRow struct {
+	SessionsRevokedAt *time.Time
+	DeletedAt         *time.Time
+}
+
+// IsClinicSessionRevoked checks a clinic token's issue time against the clinic and user cut-offs.
+// Lookup failures count as revoked so an unreadable cut-off never lets a token through, and so
+// does a user that no longer exists at the clinic or has been removed.
+func IsClinicSessionRevoked(clinicID, clinicUserID uint64, issuedAt time.Time) bool {
+	db := config.DB
+	if db == nil {
+		return true
+	}
+	var clinic sessionCutoffRow
+	if err := db.Unscoped().Model(&models.Clinic{}).Where("id = ?", clinicID).
+		Select("sessions_revoked_at").Scan(&clinic).Error; err != nil {
+		return true
+	}
+	if revokedBefore(clinic.SessionsRevokedAt, issuedAt) {
+		return true
+	}
+	var user clinicUserSessionRow
+	res := db.Unscoped().Model(&models.ClinicUser{}).Where("id = ? AND clinic_id = ?", clinicUserID, clinicID).
+		Select("sessions_revoked_at", "deleted_at").Scan(&user)
+	if res.Error != nil || res.RowsAffected == 0 || user.DeletedAt != nil {
+		return true
+	}
+	return revokedBefore(user.SessionsRevokedAt, issuedAt)
+}
+
+// RevokeUserSessions invalidates every token issued so far to a customer.
+// Deleted customers are included so their cut-off is still recorded.
+func RevokeUserSessions(userID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	return db.Unscoped().Model(&models.User{}).Where("id = ?", userID).
+		UpdateColumn("sessions_revoked_at", newSessionCutoff()).Error
+}
+
+// userSessionRow receives a customer's status, cut-off and removal time
+type userSessionRow struct {
+	Status            string
+	SessionsRevokedAt *time.Time
+	DeletedAt         *time.Time
+}
+
+// IsUserSessionRevoked checks a customer token's issue time against the customer's cut-off.
+// Missing, deleted and suspended customers count as revoked, as do lookup failures.
+func IsUserSessionRevoked(userID uint64, issuedAt time.Time) bool {
+	db := config.DB
+	if db == nil {
+		return true
+	}
+	var user userSessionRow
+	res := db.Unscoped().Model(&models.User{}).Where("id = ?", userID).
+		Select("status", "sessions_revoked_at", "deleted_at").Scan(&user)
+	if res.Error != nil || res.RowsAffected == 0 || user.DeletedAt != nil || user.Status != models.UserStatusActive {
+		return true
+	}
+	return revokedBefore(user.SessionsRevokedAt, issuedAt)
+}
+
+// RevokeAdminSessions invalidates every token issued so far to an admin.
+// Deleted admins are included so their cut-off is still recorded.
+func RevokeAdminSessions(adminID uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	return db.Unscoped().Model(&models.AdminUser{}).Where("id = ?", adminID).
+		UpdateColumn("sessions_revoked_at", newSessionCutoff()).Error
+}
+
+// IsAdminSessionRevoked checks an admin token's issue time against the admin's cut-off
+func IsAdminSessionRevoked(adminID uint64, issuedAt time.Time) bool {
+	db := config.DB
+	if db == nil {
+		return true
+	}
+	var admin sessionCutoffRow
+	if err := db.Unscoped().Model(&models.AdminUser{}).Where("id = ?", adminID).
+		Select("sessions_revoked_at").Scan(&admin).Error; err != nil {
+		return true
+	}
+	return revokedBefore(admin.SessionsRevokedAt, issuedAt)
+}
+
 // StartTokenBlacklistCleanup runs a background goroutine to clean expired tokens
 func StartTokenBlacklistCleanup() {
 	go func() {
@@ -73,6 +222,7 @@ func GenerateJWT(email string, user_id uint, deviceID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"email":   email,
 		"user_id": user_id,
+		"iat":     tokenIssuedAtClaim(),
 		"exp":     time.Now().Add(accessTokenDuration).Unix(),
 	})
 	return token.SignedString(jwtSecret)
@@ -85,6 +235,7 @@ func GenerateClinicJWT(email string, clinicUserID uint64, clinicID uint64, role
 		"clinic_user_id": clinicUserID,
 		"clinic_id":      clinicID,
 		"role":           role,
+		"iat":            tokenIssuedAtClaim(),
 		"exp":            time.Now().Add(accessTokenDuration).Unix(),
 	})
 	return token.SignedString(jwtSecret)
@@ -17,7 +17,8 @@ type OnboardingMasters struct {
 	Questions []models.SkinConditionQuestion `json:"questions"` // preloaded with Options
 }
 
-func GetOnboardingMasters() (resdto.BaseResponse, error) {
+// GetOnboardingMasters returns questions with options, localized to locale with fallback to the default content
+func GetOnboardingMasters(locale string) (resdto.BaseResponse, error) {
 	var base resdto.BaseResponse
 	db := config.DB
 	if db == nil {
@@ -30,10 +31,44 @@ func GetOnboardingMasters() (resdto.BaseResponse, error) {
 		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
 		return base, err
 	}
+	if err := localizeQuestions(masters.Questions, locale); err != nil {
+		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
+		return base, err
+	}
 	base = resdto.BaseResponse{IsSuccess: true, Message: "", Data: masters}
 	return base, nil
 }
 
+// localizeQuestions replaces question and option text with translations for locale
+func localizeQuestions(questions []models.SkinConditionQuestion, locale string) error {
+	var questionIDs, optionIDs []uint64
+	for _, q := range questions {
+		questionIDs = append(questionIDs, q.ID)
+		for _, o := range q.Options {
+			optionIDs = append(optionIDs, o.ID)
+		}
+	}
+
+	qt, err := loadTranslations(TranslationEntityQuestion, questionIDs, locale)
+	if err != nil {
+		return err
+	}
+	ot, err := loadTranslations(TranslationEntityOption, optionIDs, locale)
+	if err != nil {
+		return err
+	}
+
+	for i := range questions {
+		q := &questions[i]
+		q.QuestionText = translated(qt, q.ID, "question_text", q.QuestionText)
+		for j := range q.Options {
+			o := &q.Options[j]
+			o.OptionText = translated(ot, o.ID, "option_text", o.OptionText)
+		}
+	}
+	return nil
+}
+
 // CreateOnboardingQuestion creates a question and its options.
 func CreateOnboardingQuestion(req reqdto.CreateQuestionRequest) (resdto.BaseResponse, error) {
 	var base resdto.BaseResponse
@@ -127,7 +162,11 @@ func UpdateOnboardingQuestion(qidStr string, req reqdto.UpdateQuestionRequest) (
 
 	// handle options
 	if req.ReplaceOptions {
-		// delete existing options
+		// delete existing options (and their translations)
+		if err := deleteEntityTranslations(db, TranslationEntityOption, db.Model(&models.SkinConditionQuestionOption{}).Select("id").Where("question_id = ?", q.ID)); err != nil {
+			base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
+			return base, err
+		}
 		if err := db.Where("question_id = ?", q.ID).Delete(&models.SkinConditionQuestionOption{}).Error; err != nil {
 			base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
 			return base, err
@@ -192,6 +231,16 @@ func DeleteOnboardingQuestion(qidStr string) (resdto.BaseResponse, error) {
 		return base, err
 	}
 
+	// options cascade in the DB; translations are not FK-linked so remove them explicitly
+	if err := deleteEntityTranslations(db, TranslationEntityOption, db.Model(&models.SkinConditionQuestionOption{}).Select("id").Where("question_id = ?", q.ID)); err != nil {
+		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
+		return base, err
+	}
+	if err := deleteEntityTranslations(db, TranslationEntityQuestion, []uint64{q.ID}); err != nil {
+		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
+		return base, err
+	}
+
 	if err := db.Delete(&q).Error; err != nil {
 		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
 		return base, err
@@ -216,6 +265,11 @@ func DeleteOnboardingOption(qidStr string, oidStr string) (resdto.BaseResponse,
 		return base, err
 	}
 
+	if err := deleteEntityTranslations(db, TranslationEntityOption, []uint64{o.ID}); err != nil {
+		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
+		return base, err
+	}
+
 	if err := db.Delete(&o).Error; err != nil {
 		base = resdto.BaseResponse{IsSuccess: false, Message: err.Error()}
 		return base, err
@@ -219,6 +219,10 @@ func VerifyOTP(req reqdto.VerifyOTPRequest) (*resdto.LoginResponse, error) {
 		return nil, err
 	}
 
+	if err := ensureCustomerActive(user); err != nil {
+		return nil, err
+	}
+
 	// Check if user has a profile to determine isFirstLogin
 	var profile models.UserProfile
 	isFirstLogin := true
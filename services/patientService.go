@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxPatientText = 10000
+
+var (
+	// ErrNotClinicPatient is returned when a customer sets sharing for a clinic they have never booked at
+	ErrNotClinicPatient = errors.New("you are not a patient of this clinic")
+	// ErrPatientWithoutAccount is returned when staff book a walk-in patient who has no customer account
+	ErrPatientWithoutAccount = errors.New("patient has no customer account to book for")
+)
+
+// clinicPatientUserID returns the customer account of one of the clinic's patients, so staff can only
+// book for people who are already patients of their clinic
+func clinicPatientUserID(clinicID, patientID uint64) (uint64, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return 0, errors.New("database not initialized")
+	}
+	if patientID == 0 {
+		return 0, errors.New("patient_id is required")
+	}
+	var patient models.ClinicPatient
+	if err := db.Select("id", "clinic_id", "user_id").First(&patient, patientID).Error; err != nil {
+		return 0, err
+	}
+	if patient.UserID == nil {
+		return 0, ErrPatientWithoutAccount
+	}
+	return *patient.UserID, nil
+}
+
+// ensureClinicPatient makes the customer a patient of the clinic when they book there, and a deleted
+// record is restored. The account's email, phone and name are copied only when the customer booked
+// themselves; a walk-in record with that email is then linked to the account instead of creating a
+// second record.
+func ensureClinicPatient(tx *gorm.DB, clinicID, userID uint64, selfBooked bool) error {
+	var patient models.ClinicPatient
+	err := tx.Unscoped().Where("clinic_id = ? AND user_id = ?", clinicID, userID).First(&patient).Error
+	if err == nil {
+		if patient.DeletedAt.Valid {
+			return tx.Unscoped().Model(&patient).Update("deleted_at", nil).Error
+		}
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	patient = models.ClinicPatient{ClinicID: clinicID, UserID: &userID, Source: models.PatientSourceBooking}
+	if !selfBooked {
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&patient).Error
+	}
+
+	var user models.User
+	if err := tx.Select("id", "primary_email", "primary_phone").First(&user, userID).Error; err != nil {
+		return err
+	}
+	if user.PrimaryEmail != nil {
+		patient.Email = *user.PrimaryEmail
+	}
+	if user.PrimaryPhone != nil {
+		patient.Phone = *user.PrimaryPhone
+	}
+	var profile models.UserProfile
+	if err := tx.Select("user_id", "name").Where("user_id = ?", userID).First(&profile).Error; err == nil {
+		patient.Name = profile.Name
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if patient.Email != "" {
+		var walkIn models.ClinicPatient
+		err := tx.Where("clinic_id = ? AND user_id IS NULL AND email = ?", clinicID, patient.Email).Order("id").First(&walkIn).Error
+		if err == nil {
+			return tx.Model(&walkIn).Update("user_id", userID).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+	// A concurrent booking may have created the record first
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&patient).Error
+}
+
+// BackfillClinicPatients creates patient records for customers who booked before patient records
+// existed; deleted records are left deleted. Contact details are copied only for customers who booked
+// at the clinic themselves, as in ensureClinicPatient. Run at startup and safe to repeat.
+func BackfillClinicPatients() {
+	db := config.DB
+	if db == nil {
+		return
+	}
+	err := db.Exec(`INSERT INTO clinic_patients (clinic_id, user_id, name, email, phone, source, created_at, updated_at)
+		SELECT a.clinic_id, a.user_id,
+			COALESCE(MAX(CASE WHEN a.created_by_type = ? THEN p.name END), ''),
+			COALESCE(MAX(CASE WHEN a.created_by_type = ? THEN u.primary_email END), ''),
+			COALESCE(MAX(CASE WHEN a.created_by_type = ? THEN u.primary_phone END), ''),
+			?, NOW(), NOW()
+		FROM appointments a
+		JOIN users u ON u.id = a.user_id
+		LEFT JOIN user_profiles p ON p.user_id = a.user_id
+		WHERE NOT EXISTS (SELECT 1 FROM clinic_patients cp WHERE cp.clinic_id = a.clinic_id AND cp.user_id = a.user_id)
+		GROUP BY a.clinic_id, a.user_id`, models.AuditActorCustomer, models.AuditActorCustomer, models.AuditActorCustomer,
+		models.PatientSourceBooking).Error
+	if err != nil {
+		log.Printf("clinic patients: backfill failed: %v", err)
+	}
+}
+
+// trimmedPatientText trims a free-text patient field and checks its length
+func trimmedPatientText(field string, value *string, max int) error {
+	if value == nil {
+		return nil
+	}
+	*value = strings.TrimSpace(*value)
+	if len(*value) > max {
+		return fmt.Errorf("%s must be at most %d characters", field, max)
+	}
+	return nil
+}
+
+// validatePatientRequest trims the request and checks its fields; creating requires a name
+func validatePatientRequest(req *reqdto.PatientRequest, creating bool) error {
+	for _, f := range []struct {
+		field string
+		value *string
+		max   int
+	}{
+		{"name", req.Name, 100},
+		{"email", req.Email, 255},
+		{"phone", req.Phone, 50},
+		{"date_of_birth", req.DateOfBirth, 10},
+		{"notes", req.Notes, maxPatientText},
+		{"medical_history", req.MedicalHistory, maxPatientText},
+		{"allergies", req.Allergies, maxPatientText},
+		{"contraindications", req.Contraindications, maxPatientText},
+	} {
+		if err := trimmedPatientText(f.field, f.value, f.max); err != nil {
+			return err
+		}
+	}
+	if (creating && req.Name == nil) || (req.Name != nil && *req.Name == "") {
+		return errors.New("name is required")
+	}
+	if req.Email != nil && *req.Email != "" {
+		if _, err := mail.ParseAddress(*req.Email); err != nil {
+			return errors.New("email is invalid")
+		}
+	}
+	if req.DateOfBirth != nil && *req.DateOfBirth != "" {
+		dob, err := time.Parse("2006-01-02", *req.DateOfBirth)
+		if err != nil {
+			return errors.New("date_of_birth must be YYYY-MM-DD")
+		}
+		if dob.After(time.Now()) {
+			return errors.New("date_of_birth cannot be in the future")
+		}
+	}
+	return nil
+}
+
+// patientEditableColumns are the columns applyPatientRequest sets. The account link and sharing
+// consent belong to the customer and are never written by staff edits.
+var patientEditableColumns = []string{
+	"name", "email", "phone", "date_of_birth", "notes", "medical_history", "allergies", "contraindications",
+}
+
+// applyPatientRequest copies the set request fields onto the patient
+func applyPatientRequest(patient *models.ClinicPatient, req reqdto.PatientRequest) {
+	for _, f := range []struct {
+		value *string
+		dst   *string
+	}{
+		{req.Name, &patient.Name},
+		{req.Email, &patient.Email},
+		{req.Phone, &patient.Phone},
+		{req.DateOfBirth, &patient.DateOfBirth},
+		{req.Notes, &patient.Notes},
+		{req.MedicalHistory, &patient.MedicalHistory},
+		{req.Allergies, &patient.Allergies},
+		{req.Contraindications, &patient.Contraindications},
+	} {
+		if f.value != nil {
+			*f.dst = *f.value
+		}
+	}
+}
+
+// patientDTO builds a patient's DTO; withShared loads the customer's profile and onboarding
+// answers when they share them with the clinic
+func patientDTO(db *gorm.DB, patient models.ClinicPatient, withShared bool) (*resdto.PatientDTO, error) {
+	dto := &resdto.PatientDTO{ClinicPatient: patient, ProfileShared: patient.UserID != nil && patient.ProfileSharedAt != nil}
+	if !withShared || !dto.ProfileShared {
+		return dto, nil
+	}
+
+	var profile models.UserProfile
+	if err := db.Where("user_id = ?", *patient.UserID).First(&profile).Error; err == nil {
+		dto.Profile = &resdto.CustomerProfileDTO{
+			Name:             profile.Name,
+			PhoneNumber:      profile.PhoneNumber,
+			EmailAddress:     profile.EmailAddress,
+			Location:         profile.Location,
+			Bio:              profile.Bio,
+			ProfileImagePath: profile.ProfileImagePath,
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	onboarding, err := GetUserOnboarding(*patient.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if answers, ok := onboarding.Data.(resdto.UserOnboardingResponse); ok {
+		dto.Onboarding = &answers
+	}
+	return dto, nil
+}
+
+// ListClinicPatients returns the clinic's patients filtered by name/email/phone search and source
+func ListClinicPatients(clinicID uint64, term, source string, page, pageSize int) (*resdto.PatientListResponse, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.ClinicPatient{})
+	if term = strings.TrimSpace(term); term != "" {
+		like := "%" + term + "%"
+		query = query.Where("name LIKE ? OR email LIKE ? OR phone LIKE ?", like, like, like)
+	}
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var patients []models.ClinicPatient
+	if err := query.Order("name ASC, id ASC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&patients).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]resdto.PatientDTO, 0, len(patients))
+	for _, p := range patients {
+		dto, err := patientDTO(db, p, false)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *dto)
+	}
+	return &resdto.PatientListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// GetClinicPatient returns one patient with the customer's shared profile and onboarding answers
+func GetClinicPatient(clinicID, id uint64) (*resdto.PatientDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var patient models.ClinicPatient
+	if err := db.First(&patient, id).Error; err != nil {
+		return nil, err
+	}
+	return patientDTO(config.DB, patient, true)
+}
+
+// CreateWalkInPatient records a patient without an app account. The record is linked to the
+// customer's account if they later book at the clinic with the same email.
+func CreateWalkInPatient(clinicID uint64, req reqdto.PatientRequest) (*resdto.PatientDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validatePatientRequest(&req, true); err != nil {
+		return nil, err
+	}
+
+	patient := models.ClinicPatient{ClinicID: clinicID, Source: models.PatientSourceWalkIn}
+	applyPatientRequest(&patient, req)
+	if err := db.Create(&patient).Error; err != nil {
+		return nil, err
+	}
+	return patientDTO(config.DB, patient, false)
+}
+
+// UpdateClinicPatient edits the clinic's record of a patient
+func UpdateClinicPatient(clinicID, id uint64, req reqdto.PatientRequest) (*resdto.PatientDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validatePatientRequest(&req, false); err != nil {
+		return nil, err
+	}
+
+	var patient models.ClinicPatient
+	if err := db.First(&patient, id).Error; err != nil {
+		return nil, err
+	}
+	applyPatientRequest(&patient, req)
+	if err := db.Model(&patient).Select(patientEditableColumns).Updates(&patient).Error; err != nil {
+		return nil, err
+	}
+	// Reload so the response reflects the customer's current sharing consent
+	if err := db.First(&patient, id).Error; err != nil {
+		return nil, err
+	}
+	return patientDTO(config.DB, patient, true)
+}
+
+// DeleteClinicPatient removes a patient record; a customer who books again gets it back
+func DeleteClinicPatient(clinicID, id uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	res := db.Delete(&models.ClinicPatient{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListPatientConsents lists the clinics the customer is a patient of with what they share
+func ListPatientConsents(userID uint64) ([]resdto.PatientConsentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var patients []models.ClinicPatient
+	if err := db.Select("id", "clinic_id", "profile_shared_at").Where("user_id = ?", userID).Order("clinic_id").Find(&patients).Error; err != nil {
+		return nil, err
+	}
+	out := make([]resdto.PatientConsentDTO, 0, len(patients))
+	if len(patients) == 0 {
+		return out, nil
+	}
+
+	clinicIDs := make([]uint64, 0, len(patients))
+	for _, p := range patients {
+		clinicIDs = append(clinicIDs, p.ClinicID)
+	}
+	var clinics []models.Clinic
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", clinicIDs).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]string, len(clinics))
+	for _, c := range clinics {
+		names[c.ID] = c.Name
+	}
+
+	for _, p := range patients {
+		out = append(out, resdto.PatientConsentDTO{
+			ClinicID:        p.ClinicID,
+			ClinicName:      names[p.ClinicID],
+			ProfileShared:   p.ProfileSharedAt != nil,
+			ProfileSharedAt: p.ProfileSharedAt,
+		})
+	}
+	return out, nil
+}
+
+// setPatientConsent records whether the customer shares their profile with the clinic
+func setPatientConsent(db *gorm.DB, userID, clinicID uint64, share bool) (*models.ClinicPatient, error) {
+	var patient models.ClinicPatient
+	if err := db.Where("clinic_id = ? AND user_id = ?", clinicID, userID).First(&patient).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotClinicPatient
+		}
+		return nil, err
+	}
+	if share == (patient.ProfileSharedAt != nil) {
+		return &patient, nil
+	}
+	var sharedAt *time.Time
+	if share {
+		now := time.Now()
+		sharedAt = &now
+	}
+	if err := db.Model(&patient).Update("profile_shared_at", sharedAt).Error; err != nil {
+		return nil, err
+	}
+	patient.ProfileSharedAt = sharedAt
+	return &patient, nil
+}
+
+// SetPatientConsent grants or withdraws the clinic's access to the customer's profile and onboarding answers
+func SetPatientConsent(userID, clinicID uint64, req reqdto.PatientConsentRequest) (*resdto.PatientConsentDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	patient, err := setPatientConsent(db, userID, clinicID, req.ShareProfile)
+	if err != nil {
+		return nil, err
+	}
+	var clinic models.Clinic
+	if err := db.Unscoped().Select("id", "name").First(&clinic, clinicID).Error; err != nil {
+		return nil, err
+	}
+	return &resdto.PatientConsentDTO{
+		ClinicID:        clinicID,
+		ClinicName:      clinic.Name,
+		ProfileShared:   patient.ProfileSharedAt != nil,
+		ProfileSharedAt: patient.ProfileSharedAt,
+	}, nil
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== CUSTOMER CHARGES ====================
+
+// ErrChargeNotOpen is returned when collecting or waiving a charge that is already settled
+var ErrChargeNotOpen = errors.New("charge is already settled")
+
+// PaymentResult is the outcome of a collection attempt. Collected false leaves the charge
+// pending, e.g. when the provider needs the customer to pay in person.
+type PaymentResult struct {
+	Collected bool
+	Reference string
+}
+
+// PaymentProvider collects customer charges and returns collected deposits.
+// Card processors register in paymentProviders and are selected with PAYMENT_PROVIDER.
+type PaymentProvider interface {
+	Name() string
+	Collect(charge models.CustomerCharge) (PaymentResult, error)
+	Refund(charge models.CustomerCharge) error
+}
+
+// manualPaymentProvider leaves charges for the clinic to take at the front desk
+type manualPaymentProvider struct{}
+
+func (manualPaymentProvider) Name() string { return "manual" }
+
+func (manualPaymentProvider) Collect(models.CustomerCharge) (PaymentResult, error) {
+	return PaymentResult{}, nil
+}
+
+func (manualPaymentProvider) Refund(models.CustomerCharge) error { return nil }
+
+var paymentProviders = map[string]PaymentProvider{
+	"manual": manualPaymentProvider{},
+}
+
+// activePaymentProvider returns the provider named by PAYMENT_PROVIDER (default manual)
+func activePaymentProvider() PaymentProvider {
+	if p, ok := paymentProviders[os.Getenv("PAYMENT_PROVIDER")]; ok {
+		return p
+	}
+	return paymentProviders["manual"]
+}
+
+// truncateChargeError keeps provider errors within the column size
+func truncateChargeError(err error) string {
+	msg := err.Error()
+	if len(msg) > 255 {
+		msg = msg[:255]
+	}
+	return msg
+}
+
+// processCharge sends a pending charge to the provider for collection, or a refunding deposit
+// back to the customer. The status guard keeps concurrent attempts from applying twice.
+func processCharge(db *gorm.DB, charge *models.CustomerCharge) error {
+	provider := activePaymentProvider()
+	updates := map[string]interface{}{"provider": provider.Name()}
+	switch charge.Status {
+	case models.ChargePending, models.ChargeFailed:
+		result, err := provider.Collect(*charge)
+		if err != nil {
+			updates["status"] = models.ChargeFailed
+			updates["error"] = truncateChargeError(err)
+		} else if result.Collected {
+			updates["status"] = models.ChargeCollected
+			updates["provider_ref"] = result.Reference
+			updates["error"] = ""
+			updates["collected_at"] = time.Now().UTC()
+		} else {
+			updates["status"] = models.ChargePending
+		}
+	case models.ChargeRefunding:
+		if err := provider.Refund(*charge); err != nil {
+			updates["error"] = truncateChargeError(err)
+		} else {
+			updates["status"] = models.ChargeRefunded
+			updates["error"] = ""
+		}
+	default:
+		return ErrChargeNotOpen
+	}
+	res := db.Model(&models.CustomerCharge{}).Where("id = ? AND status = ?", charge.ID, charge.Status).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrChargeNotOpen
+	}
+	return nil
+}
+
+// processAppointmentCharges collects an appointment's open charges and returns released deposits;
+// failures stay on the charge for staff to retry
+func processAppointmentCharges(db *gorm.DB, appointmentID uint64) {
+	var charges []models.CustomerCharge
+	if err := db.Where("appointment_id = ? AND status IN ?", appointmentID,
+		[]string{models.ChargePending, models.ChargeRefunding}).Find(&charges).Error; err != nil {
+		log.Printf("appointment %d: failed to load charges: %v", appointmentID, err)
+		return
+	}
+	for i := range charges {
+		if err := processCharge(db, &charges[i]); err != nil {
+			log.Printf("charge %d: %v", charges[i].ID, err)
+		}
+	}
+}
+
+// customerChargeDTOs adds clinic and customer names and the appointment time to charges
+func customerChargeDTOs(db *gorm.DB, charges []models.CustomerCharge) ([]resdto.CustomerChargeDTO, error) {
+	out := make([]resdto.CustomerChargeDTO, 0, len(charges))
+	if len(charges) == 0 {
+		return out, nil
+	}
+	var clinicIDs, userIDs, apptIDs []uint64
+	for _, c := range charges {
+		clinicIDs = append(clinicIDs, c.ClinicID)
+		userIDs = append(userIDs, c.UserID)
+		apptIDs = append(apptIDs, c.AppointmentID)
+	}
+
+	var clinics []models.Clinic
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", clinicIDs).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	clinicByID := make(map[uint64]string, len(clinics))
+	for _, c := range clinics {
+		clinicByID[c.ID] = c.Name
+	}
+	var profiles []models.UserProfile
+	if err := db.Select("user_id", "name").Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	nameByUser := make(map[uint64]string, len(profiles))
+	for _, p := range profiles {
+		nameByUser[p.UserID] = p.Name
+	}
+	var appts []models.Appointment
+	if err := db.Select("id", "start_at").Where("id IN ?", apptIDs).Find(&appts).Error; err != nil {
+		return nil, err
+	}
+	startByAppt := make(map[uint64]time.Time, len(appts))
+	for _, a := range appts {
+		startByAppt[a.ID] = a.StartAt
+	}
+
+	for _, c := range charges {
+		out = append(out, resdto.CustomerChargeDTO{
+			CustomerCharge:     c,
+			ClinicName:         clinicByID[c.ClinicID],
+			CustomerName:       nameByUser[c.UserID],
+			AppointmentStartAt: startByAppt[c.AppointmentID],
+		})
+	}
+	return out, nil
+}
+
+// ListCustomerCharges returns a page of charges, newest first; non-zero clinicID/userID restrict
+// them to that clinic or customer
+func ListCustomerCharges(clinicID, userID uint64, status string, page, pageSize int) (*resdto.CustomerChargeListResponse, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.CustomerCharge{})
+	if clinicID != 0 {
+		query = query.Where("clinic_id = ?", clinicID)
+	}
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var charges []models.CustomerCharge
+	if err := query.Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&charges).Error; err != nil {
+		return nil, err
+	}
+	items, err := customerChargeDTOs(db, charges)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.CustomerChargeListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// loadClinicCharge fetches one of the clinic's charges
+func loadClinicCharge(db *gorm.DB, clinicID, id uint64) (*models.CustomerCharge, error) {
+	var charge models.CustomerCharge
+	if err := db.Where("clinic_id = ?", clinicID).First(&charge, id).Error; err != nil {
+		return nil, err
+	}
+	return &charge, nil
+}
+
+// clinicChargeDTO reloads a charge and builds its DTO
+func clinicChargeDTO(db *gorm.DB, clinicID, id uint64) (*resdto.CustomerChargeDTO, error) {
+	charge, err := loadClinicCharge(db, clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+	dtos, err := customerChargeDTOs(db, []models.CustomerCharge{*charge})
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// CollectCustomerCharge collects a pending or failed charge. With a reference the payment was taken
+// outside the provider and is recorded as collected; otherwise the provider is charged.
+func CollectCustomerCharge(clinicID, id uint64, req reqdto.CollectChargeRequest) (*resdto.CustomerChargeDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	charge, err := loadClinicCharge(db, clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+	if charge.Status != models.ChargePending && charge.Status != models.ChargeFailed && charge.Status != models.ChargeRefunding {
+		return nil, ErrChargeNotOpen
+	}
+
+	reference := strings.TrimSpace(req.Reference)
+	if len(reference) > 100 {
+		return nil, errors.New("reference must be at most 100 characters")
+	}
+	if reference != "" && charge.Status != models.ChargeRefunding {
+		res := db.Model(&models.CustomerCharge{}).Where("id = ? AND status = ?", charge.ID, charge.Status).Updates(map[string]interface{}{
+			"status":       models.ChargeCollected,
+			"provider":     manualPaymentProvider{}.Name(),
+			"provider_ref": reference,
+			"error":        "",
+			"collected_at": time.Now().UTC(),
+		})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil, ErrChargeNotOpen
+		}
+	} else if err := processCharge(db, charge); err != nil {
+		return nil, err
+	}
+	return clinicChargeDTO(db, clinicID, id)
+}
+
+// WaiveCustomerCharge drops a charge that has not been collected
+func WaiveCustomerCharge(clinicID, id uint64, req reqdto.WaiveChargeRequest) (*resdto.CustomerChargeDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	charge, err := loadClinicCharge(db, clinicID, id)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 255 {
+		return nil, errors.New("reason must be at most 255 characters")
+	}
+
+	res := db.Model(&models.CustomerCharge{}).
+		Where("id = ? AND status IN ?", charge.ID, []string{models.ChargePending, models.ChargeFailed}).
+		Updates(map[string]interface{}{"status": models.ChargeWaived, "note": reason})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, fmt.Errorf("%w: only pending or failed charges can be waived", ErrChargeNotOpen)
+	}
+	return clinicChargeDTO(db, clinicID, id)
+}
@@ -0,0 +1,582 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"github.com/dgrijalva/jwt-go"
+	"gorm.io/gorm"
+)
+
+// ==================== APPOINTMENT REMINDERS ====================
+
+// defaultReminderOffsets are the reminders (minutes before start) of clinics without their own rules
+var defaultReminderOffsets = []int{48 * 60, 2 * 60}
+
+const (
+	minReminderOffset   = 15
+	maxReminderOffset   = 7 * 24 * 60
+	maxReminderRules    = 5
+	maxReminderSubject  = 200
+	maxReminderBody     = 5000
+	reminderScanPeriod  = time.Minute
+	defaultReminderBody = `Hello {{customer_name}},
+
+This is a reminder of your appointment.
+
+Clinic: {{clinic_name}}
+Treatment: {{treatment_name}}
+Practitioner: {{practitioner_name}}
+When: {{when}}
+
+Confirm you are coming: {{confirm_url}}
+Can't make it? Cancel here: {{cancel_url}}
+
+Thanks,
+SkinSync Team
+`
+	defaultReminderSubject = "SkinSync - Appointment Reminder"
+)
+
+// reminderPlaceholders are the variables reminder templates may use
+var reminderPlaceholders = []string{
+	"customer_name", "clinic_name", "treatment_name", "practitioner_name",
+	"when", "appointment_id", "confirm_url", "cancel_url",
+}
+
+var reminderPlaceholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_]+)\s*\}\}`)
+
+// Signed appointment link actions
+const (
+	AppointmentActionConfirm = "confirm"
+	AppointmentActionCancel  = "cancel"
+)
+
+// appointmentActionPurpose marks link tokens so session tokens cannot be used as links and vice versa
+const appointmentActionPurpose = "appointment_action"
+
+// ErrInvalidActionLink is returned for a tampered, expired or unknown appointment link
+var ErrInvalidActionLink = errors.New("link is invalid or has expired")
+
+// ReminderChannel delivers a rendered reminder to a customer.
+// Further channels (SMS, push) register in reminderChannels.
+type ReminderChannel interface {
+	Send(to reminderRecipient, subject, body string) error
+}
+
+// reminderRecipient is the customer a reminder goes to
+type reminderRecipient struct {
+	UserID uint64
+	Name   string
+	Email  string
+}
+
+// emailReminderChannel sends reminders through the SMTP email utilities
+type emailReminderChannel struct{}
+
+func (emailReminderChannel) Send(to reminderRecipient, subject, body string) error {
+	if to.Email == "" {
+		return errors.New("customer has no email address")
+	}
+	return utils.SendAppointmentReminderEmail(to.Email, subject, body)
+}
+
+var reminderChannels = map[string]ReminderChannel{
+	models.ReminderChannelEmail: emailReminderChannel{},
+}
+
+// reminderChannelNames lists the registered channels in a stable order
+func reminderChannelNames() []string {
+	names := make([]string, 0, len(reminderChannels))
+	for name := range reminderChannels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getAppBaseURL returns the customer app URL used in emailed links (APP_BASE_URL)
+func getAppBaseURL() string {
+	base := os.Getenv("APP_BASE_URL")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return strings.TrimRight(base, "/")
+}
+
+// appointmentActionSecret signs appointment links (APPOINTMENT_LINK_SECRET, falling back to the JWT secret)
+func appointmentActionSecret() []byte {
+	if secret := os.Getenv("APPOINTMENT_LINK_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return jwtSecret
+}
+
+// generateAppointmentActionToken signs a link token for one action on an appointment, valid until expires
+func generateAppointmentActionToken(appointmentID uint64, action string, expires time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"purpose":        appointmentActionPurpose,
+		"appointment_id": appointmentID,
+		"action":         action,
+		"exp":            expires.Unix(),
+	})
+	return token.SignedString(appointmentActionSecret())
+}
+
+// parseAppointmentActionToken verifies a link token and returns its appointment and action
+func parseAppointmentActionToken(tokenString string) (uint64, string, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidActionLink
+		}
+		return appointmentActionSecret(), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, "", ErrInvalidActionLink
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != appointmentActionPurpose {
+		return 0, "", ErrInvalidActionLink
+	}
+	id, ok := claims["appointment_id"].(float64)
+	action, _ := claims["action"].(string)
+	if !ok || id <= 0 || (action != AppointmentActionConfirm && action != AppointmentActionCancel) {
+		return 0, "", ErrInvalidActionLink
+	}
+	return uint64(id), action, nil
+}
+
+// appointmentActionURL builds the customer link for an action; the link expires when the appointment starts
+func appointmentActionURL(appointmentID uint64, action string, expires time.Time) (string, error) {
+	token, err := generateAppointmentActionToken(appointmentID, action, expires)
+	if err != nil {
+		return "", err
+	}
+	return getAppBaseURL() + "/appointments/actions?token=" + url.QueryEscape(token), nil
+}
+
+// validateReminderTemplate rejects placeholders the renderer does not know
+func validateReminderTemplate(field, text string) error {
+	known := make(map[string]bool, len(reminderPlaceholders))
+	for _, p := range reminderPlaceholders {
+		known[p] = true
+	}
+	for _, m := range reminderPlaceholderPattern.FindAllStringSubmatch(text, -1) {
+		if !known[m[1]] {
+			return fmt.Errorf("%s uses unknown placeholder {{%s}}", field, m[1])
+		}
+	}
+	return nil
+}
+
+// renderReminderTemplate fills a template's placeholders
+func renderReminderTemplate(text string, values map[string]string) string {
+	return reminderPlaceholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return values[reminderPlaceholderPattern.FindStringSubmatch(m)[1]]
+	})
+}
+
+// defaultReminderRules returns the reminders of a clinic without its own rules
+func defaultReminderRules(clinicID uint64) []models.ClinicReminderRule {
+	rules := make([]models.ClinicReminderRule, 0, len(defaultReminderOffsets))
+	for _, offset := range defaultReminderOffsets {
+		rules = append(rules, models.ClinicReminderRule{
+			ClinicID:      clinicID,
+			OffsetMinutes: offset,
+			Channel:       models.ReminderChannelEmail,
+			IsActive:      true,
+		})
+	}
+	return rules
+}
+
+// GetClinicReminderRules returns the clinic's reminder rules, or the defaults when it has none
+func GetClinicReminderRules(clinicID uint64) (*resdto.ClinicReminderSettingsDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var rules []models.ClinicReminderRule
+	if err := db.Order("offset_minutes DESC, channel").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	settings := &resdto.ClinicReminderSettingsDTO{
+		Rules:        rules,
+		Channels:     reminderChannelNames(),
+		Placeholders: reminderPlaceholders,
+	}
+	if len(rules) == 0 {
+		settings.UsingDefaults = true
+		settings.Rules = defaultReminderRules(clinicID)
+	}
+	return settings, nil
+}
+
+// SetClinicReminderRules replaces the clinic's reminder rules; an empty list restores the defaults
+func SetClinicReminderRules(clinicID uint64, req reqdto.SetReminderRulesRequest) (*resdto.ClinicReminderSettingsDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if len(req.Rules) > maxReminderRules {
+		return nil, fmt.Errorf("at most %d reminder rules are allowed", maxReminderRules)
+	}
+
+	rules := make([]models.ClinicReminderRule, 0, len(req.Rules))
+	seen := make(map[string]bool, len(req.Rules))
+	for i, r := range req.Rules {
+		if r.OffsetMinutes < minReminderOffset || r.OffsetMinutes > maxReminderOffset || r.OffsetMinutes%5 != 0 {
+			return nil, fmt.Errorf("rules[%d].offset_minutes must be a multiple of 5 between %d and %d", i, minReminderOffset, maxReminderOffset)
+		}
+		channel := r.Channel
+		if channel == "" {
+			channel = models.ReminderChannelEmail
+		}
+		if _, ok := reminderChannels[channel]; !ok {
+			return nil, fmt.Errorf("rules[%d].channel must be one of %s", i, strings.Join(reminderChannelNames(), ", "))
+		}
+		key := fmt.Sprintf("%d:%s", r.OffsetMinutes, channel)
+		if seen[key] {
+			return nil, fmt.Errorf("rules[%d] repeats offset %d on channel %s", i, r.OffsetMinutes, channel)
+		}
+		seen[key] = true
+
+		subject := strings.TrimSpace(r.Subject)
+		if len(subject) > maxReminderSubject || strings.ContainsAny(subject, "\r\n") {
+			return nil, fmt.Errorf("rules[%d].subject must be a single line of at most %d characters", i, maxReminderSubject)
+		}
+		body := strings.TrimSpace(r.Body)
+		if len(body) > maxReminderBody {
+			return nil, fmt.Errorf("rules[%d].body must be at most %d characters", i, maxReminderBody)
+		}
+		if err := validateReminderTemplate(fmt.Sprintf("rules[%d].subject", i), subject); err != nil {
+			return nil, err
+		}
+		if err := validateReminderTemplate(fmt.Sprintf("rules[%d].body", i), body); err != nil {
+			return nil, err
+		}
+		active := true
+		if r.IsActive != nil {
+			active = *r.IsActive
+		}
+		rules = append(rules, models.ClinicReminderRule{
+			ClinicID:      clinicID,
+			OffsetMinutes: r.OffsetMinutes,
+			Channel:       channel,
+			Subject:       subject,
+			Body:          body,
+			IsActive:      active,
+		})
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("clinic_id = ?", clinicID).Delete(&models.ClinicReminderRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetClinicReminderRules(clinicID)
+}
+
+// ListAppointmentReminders returns the reminders recorded for one of the clinic's appointments
+func ListAppointmentReminders(clinicID, appointmentID uint64) ([]models.AppointmentReminder, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if _, err := loadAppointment(db, clinicID, 0, appointmentID); err != nil {
+		return nil, err
+	}
+	reminders := []models.AppointmentReminder{}
+	if err := db.Where("appointment_id = ?", appointmentID).Order("created_at").Find(&reminders).Error; err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// StartAppointmentReminders runs a background goroutine that sends due appointment reminders every minute
+func StartAppointmentReminders() {
+	go func() {
+		for {
+			time.Sleep(reminderScanPeriod)
+			if err := sendDueReminders(time.Now()); err != nil {
+				log.Printf("appointment reminders: %v", err)
+			}
+		}
+	}()
+}
+
+// sendDueReminders sends every reminder whose time has come for upcoming requested or confirmed appointments.
+// When several reminders of one channel are due at once (e.g. a late booking), only the closest to the
+// start goes out and the others are skipped; reminders due before the booking was made are skipped too.
+func sendDueReminders(now time.Time) error {
+	db := config.DB
+	if db == nil {
+		return nil
+	}
+
+	maxOffset := 0
+	for _, o := range defaultReminderOffsets {
+		if o > maxOffset {
+			maxOffset = o
+		}
+	}
+	var ruleMax *int
+	if err := db.Model(&models.ClinicReminderRule{}).Where("is_active = ?", true).
+		Select("MAX(offset_minutes)").Scan(&ruleMax).Error; err != nil {
+		return err
+	}
+	if ruleMax != nil && *ruleMax > maxOffset {
+		maxOffset = *ruleMax
+	}
+
+	var appts []models.Appointment
+	if err := db.Where("status IN ? AND start_at > ? AND start_at <= ?",
+		[]string{models.AppointmentRequested, models.AppointmentConfirmed}, now, now.Add(time.Duration(maxOffset)*time.Minute)).
+		Order("start_at").Find(&appts).Error; err != nil {
+		return err
+	}
+	if len(appts) == 0 {
+		return nil
+	}
+
+	clinicIDs := make([]uint64, 0, len(appts))
+	apptIDs := make([]uint64, 0, len(appts))
+	for _, a := range appts {
+		clinicIDs = append(clinicIDs, a.ClinicID)
+		apptIDs = append(apptIDs, a.ID)
+	}
+	var rules []models.ClinicReminderRule
+	if err := db.Where("clinic_id IN ?", clinicIDs).Find(&rules).Error; err != nil {
+		return err
+	}
+	configured := map[uint64]bool{}
+	rulesByClinic := map[uint64][]models.ClinicReminderRule{}
+	for _, r := range rules {
+		configured[r.ClinicID] = true
+		if r.IsActive {
+			rulesByClinic[r.ClinicID] = append(rulesByClinic[r.ClinicID], r)
+		}
+	}
+	var recorded []models.AppointmentReminder
+	if err := db.Select("appointment_id", "offset_minutes", "channel").Where("appointment_id IN ?", apptIDs).Find(&recorded).Error; err != nil {
+		return err
+	}
+	done := map[string]bool{}
+	for _, r := range recorded {
+		done[fmt.Sprintf("%d:%d:%s", r.AppointmentID, r.OffsetMinutes, r.Channel)] = true
+	}
+
+	for _, a := range appts {
+		clinicRules := rulesByClinic[a.ClinicID]
+		if !configured[a.ClinicID] {
+			clinicRules = defaultReminderRules(a.ClinicID)
+		}
+		// Closest reminder first so it is the one sent when several are due
+		sort.Slice(clinicRules, func(i, j int) bool { return clinicRules[i].OffsetMinutes < clinicRules[j].OffsetMinutes })
+		sentOn := map[string]bool{}
+		for _, rule := range clinicRules {
+			dueAt := a.StartAt.Add(-time.Duration(rule.OffsetMinutes) * time.Minute)
+			if now.Before(dueAt) || done[fmt.Sprintf("%d:%d:%s", a.ID, rule.OffsetMinutes, rule.Channel)] {
+				continue
+			}
+			skip := sentOn[rule.Channel] || dueAt.Before(a.CreatedAt)
+			sendAppointmentReminder(db, &a, rule, skip)
+			sentOn[rule.Channel] = true
+		}
+	}
+	return nil
+}
+
+// sendAppointmentReminder claims one reminder of an appointment and delivers it, or records it as skipped
+func sendAppointmentReminder(db *gorm.DB, appt *models.Appointment, rule models.ClinicReminderRule, skip bool) {
+	reminder := models.AppointmentReminder{
+		AppointmentID: appt.ID,
+		OffsetMinutes: rule.OffsetMinutes,
+		Channel:       rule.Channel,
+		Status:        models.ReminderSending,
+	}
+	if skip {
+		reminder.Status = models.ReminderSkipped
+	}
+	if err := db.Create(&reminder).Error; err != nil {
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			log.Printf("appointment %d: failed to record reminder: %v", appt.ID, err)
+		}
+		return
+	}
+	after := map[string]interface{}{
+		"appointment_id": appt.ID,
+		"offset_minutes": rule.OffsetMinutes,
+		"channel":        rule.Channel,
+		"status":         reminder.Status,
+	}
+	source := systemAuditSource("appointment-reminders")
+	if skip {
+		source.record("create", "appointment_reminder", reminder.ID, &appt.ClinicID, nil, after)
+		return
+	}
+
+	sendErr := deliverAppointmentReminder(db, appt, rule)
+	updates := map[string]interface{}{"status": models.ReminderSent, "sent_at": time.Now()}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		if len(msg) > 255 {
+			msg = msg[:255]
+		}
+		updates = map[string]interface{}{"status": models.ReminderFailed, "error": msg}
+		log.Printf("appointment %d: failed to send %d-minute reminder: %v", appt.ID, rule.OffsetMinutes, sendErr)
+	}
+	if err := db.Model(&reminder).Updates(updates).Error; err != nil {
+		log.Printf("appointment %d: failed to update reminder: %v", appt.ID, err)
+		return
+	}
+	for k, v := range updates {
+		after[k] = v
+	}
+	source.record("create", "appointment_reminder", reminder.ID, &appt.ClinicID, nil, after)
+}
+
+// deliverAppointmentReminder renders a reminder for the customer and sends it on the rule's channel
+func deliverAppointmentReminder(db *gorm.DB, appt *models.Appointment, rule models.ClinicReminderRule) error {
+	channel, ok := reminderChannels[rule.Channel]
+	if !ok {
+		return fmt.Errorf("unknown reminder channel %q", rule.Channel)
+	}
+	dtos, err := appointmentDTOs(db, []models.Appointment{*appt}, models.AuditActorCustomer)
+	if err != nil {
+		return err
+	}
+	dto := dtos[0]
+	confirmURL, err := appointmentActionURL(appt.ID, AppointmentActionConfirm, appt.StartAt)
+	if err != nil {
+		return err
+	}
+	cancelURL, err := appointmentActionURL(appt.ID, AppointmentActionCancel, appt.StartAt)
+	if err != nil {
+		return err
+	}
+
+	name := dto.CustomerName
+	if name == "" {
+		name = "there"
+	}
+	values := map[string]string{
+		"customer_name":     name,
+		"clinic_name":       dto.ClinicName,
+		"treatment_name":    dto.TreatmentName,
+		"practitioner_name": dto.PractitionerName,
+		"when":              formatAppointmentTime(dto.StartAt, dto.Timezone),
+		"appointment_id":    strconv.FormatUint(appt.ID, 10),
+		"confirm_url":       confirmURL,
+		"cancel_url":        cancelURL,
+	}
+	subject, body := rule.Subject, rule.Body
+	if subject == "" {
+		subject = defaultReminderSubject
+	}
+	if body == "" {
+		body = defaultReminderBody
+	}
+	recipient := reminderRecipient{UserID: appt.UserID, Name: name, Email: dto.CustomerEmail}
+	return channel.Send(recipient, renderReminderTemplate(subject, values), renderReminderTemplate(body, values))
+}
+
+// ==================== SIGNED APPOINTMENT LINKS ====================
+
+// appointmentActionAllowed reports whether a link action can still be applied to the appointment
+func appointmentActionAllowed(appt *models.Appointment, now time.Time) bool {
+	if !now.Before(appt.StartAt) {
+		return false
+	}
+	return appt.Status == models.AppointmentRequested || appt.Status == models.AppointmentConfirmed
+}
+
+// GetAppointmentAction resolves a signed reminder link to its action and appointment
+func GetAppointmentAction(token string) (*resdto.AppointmentActionDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	id, action, err := parseAppointmentActionToken(token)
+	if err != nil {
+		return nil, err
+	}
+	appt, err := loadAppointment(db, 0, 0, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidActionLink
+	}
+	if err != nil {
+		return nil, err
+	}
+	dto, err := appointmentDTO(db, id, models.AuditActorCustomer)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.AppointmentActionDTO{Action: action, Allowed: appointmentActionAllowed(appt, time.Now()), Appointment: *dto}, nil
+}
+
+// ApplyAppointmentAction confirms attendance or cancels the appointment of a signed reminder link
+func ApplyAppointmentAction(token string) (*resdto.AppointmentActionDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	id, action, err := parseAppointmentActionToken(token)
+	if err != nil {
+		return nil, err
+	}
+	appt, err := loadAppointment(db, 0, 0, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidActionLink
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !appointmentActionAllowed(appt, time.Now()) {
+		return nil, fmt.Errorf("%w: the appointment can no longer be changed from this link", ErrAppointmentStatusChange)
+	}
+
+	var dto *resdto.AppointmentDTO
+	switch action {
+	case AppointmentActionConfirm:
+		if appt.CustomerConfirmedAt == nil {
+			if err := db.Model(&models.Appointment{}).Where("id = ? AND customer_confirmed_at IS NULL", id).
+				Update("customer_confirmed_at", time.Now()).Error; err != nil {
+				return nil, err
+			}
+		}
+		dto, err = appointmentDTO(db, id, models.AuditActorCustomer)
+	case AppointmentActionCancel:
+		dto, err = CancelCustomerAppointment(appt.UserID, id, "cancelled from reminder link")
+	}
+	if err != nil {
+		return nil, err
+	}
+	source := auditSource{Actor: AuditActor{Type: models.AuditActorCustomer, ID: appt.UserID}, Method: http.MethodPost, Route: "/appointments/actions"}
+	source.record("update", "appointment", id, &appt.ClinicID,
+		map[string]interface{}{"status": appt.Status, "customer_confirmed_at": appt.CustomerConfirmedAt},
+		map[string]interface{}{"status": dto.Status, "customer_confirmed_at": dto.CustomerConfirmedAt, "action": action})
+	return &resdto.AppointmentActionDTO{Action: action, Allowed: false, Appointment: *dto}, nil
+}
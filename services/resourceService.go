@@ -0,0 +1,472 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+// ==================== CLINIC RESOURCES ====================
+//
+// Rooms and devices are clinic resources with a capacity. Treatments require resource types
+// (catalog requirements, adjusted per clinic); a booking holds enough free units of each
+// required type for its whole length plus the clinic buffer, using the same slot locks as
+// practitioners. Types a clinic has not registered any resource for are not enforced.
+
+// maxResourceCapacity caps how many appointments can share one resource at the same time
+const maxResourceCapacity = 20
+
+var (
+	// ErrResourceUnavailable is returned when no free unit of a required room or device is left
+	ErrResourceUnavailable = errors.New("a required room or device is not available at this time")
+	// ErrResourceInUse is returned when deleting a resource that upcoming appointments hold
+	ErrResourceInUse = errors.New("resource is held by upcoming appointments - deactivate it instead")
+)
+
+// resourceLockKey is the slot lock key of one unit of a clinic resource
+func resourceLockKey(resourceID uint64, unit int) string {
+	return fmt.Sprintf("resource:%d:%d", resourceID, unit)
+}
+
+// requirementKey identifies a requirement by side area and resource type
+func requirementKey(sideAreaID uint, resourceType string) string {
+	return fmt.Sprintf("%d:%s", sideAreaID, resourceType)
+}
+
+// mergeRequirements applies a clinic's adjustments to catalog requirements:
+// an adjustment replaces the catalog entry with the same side area and type, quantity 0 drops it
+func mergeRequirements(catalog []models.TreatmentResourceRequirement, adjustments []models.ClinicTreatmentRequirement) []models.TreatmentResourceRequirement {
+	adjusted := make(map[string]bool, len(adjustments))
+	for _, a := range adjustments {
+		adjusted[requirementKey(a.SideAreaID, a.ResourceType)] = true
+	}
+	merged := []models.TreatmentResourceRequirement{}
+	for _, r := range catalog {
+		if !adjusted[requirementKey(r.SideAreaID, r.ResourceType)] {
+			merged = append(merged, r)
+		}
+	}
+	for _, a := range adjustments {
+		if a.Quantity > 0 {
+			merged = append(merged, models.TreatmentResourceRequirement{
+				TreatmentID:  a.TreatmentID,
+				SideAreaID:   a.SideAreaID,
+				Kind:         a.Kind,
+				ResourceType: a.ResourceType,
+				Quantity:     a.Quantity,
+			})
+		}
+	}
+	return merged
+}
+
+// effectiveTreatmentRequirements lists the resources a treatment selection needs at a clinic
+func effectiveTreatmentRequirements(db *gorm.DB, clinicID uint64, treatmentID uint, sideAreaIDs []uint) ([]models.TreatmentResourceRequirement, error) {
+	catalog, err := treatmentRequirements(db, treatmentID, sideAreaIDs)
+	if err != nil {
+		return nil, err
+	}
+	query := db.Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID)
+	if len(sideAreaIDs) > 0 {
+		query = query.Where("side_area_id = 0 OR side_area_id IN ?", sideAreaIDs)
+	} else {
+		query = query.Where("side_area_id = 0")
+	}
+	var adjustments []models.ClinicTreatmentRequirement
+	if err := query.Order("id").Find(&adjustments).Error; err != nil {
+		return nil, err
+	}
+	return mergeRequirements(catalog, adjustments), nil
+}
+
+// resourceNeeds reduces requirements to the units needed per resource type the clinic tracks.
+// Requirements of the same type share units (a side area needing the procedure room uses the
+// treatment's room), so the largest quantity applies.
+func resourceNeeds(db *gorm.DB, clinicID uint64, requirements []models.TreatmentResourceRequirement) (map[string]int, error) {
+	needs := map[string]int{}
+	for _, r := range requirements {
+		if r.Quantity > needs[r.ResourceType] {
+			needs[r.ResourceType] = r.Quantity
+		}
+	}
+	if len(needs) == 0 {
+		return needs, nil
+	}
+	types := make([]string, 0, len(needs))
+	for t := range needs {
+		types = append(types, t)
+	}
+	var tracked []string
+	if err := db.Model(&models.ClinicResource{}).
+		Where("clinic_id = ? AND resource_type IN ?", clinicID, types).
+		Distinct().Pluck("resource_type", &tracked).Error; err != nil {
+		return nil, err
+	}
+	isTracked := make(map[string]bool, len(tracked))
+	for _, t := range tracked {
+		isTracked[t] = true
+	}
+	for t := range needs {
+		if !isTracked[t] {
+			delete(needs, t)
+		}
+	}
+	return needs, nil
+}
+
+// claimAppointmentResources holds free units of every resource type the appointment needs,
+// from its start until its end plus buffer. Units are tried in order; each attempt runs in a
+// savepoint so a taken unit does not abort the surrounding transaction.
+func claimAppointmentResources(tx *gorm.DB, appt *models.Appointment) error {
+	sideAreaIDs := make([]uint, 0, len(appt.Items))
+	for _, it := range appt.Items {
+		sideAreaIDs = append(sideAreaIDs, it.SideAreaID)
+	}
+	requirements, err := effectiveTreatmentRequirements(tx, appt.ClinicID, appt.TreatmentID, sideAreaIDs)
+	if err != nil {
+		return err
+	}
+	needs, err := resourceNeeds(tx, appt.ClinicID, requirements)
+	if err != nil || len(needs) == 0 {
+		return err
+	}
+	types := make([]string, 0, len(needs))
+	for t := range needs {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	end := appt.EndAt.Add(time.Duration(appt.BufferMinutes) * time.Minute)
+	var held []models.AppointmentResource
+	for _, t := range types {
+		var resources []models.ClinicResource
+		if err := tx.Where("clinic_id = ? AND resource_type = ? AND status = ?", appt.ClinicID, t, models.ClinicResourceActive).
+			Order("id").Find(&resources).Error; err != nil {
+			return err
+		}
+		need := needs[t]
+		for _, r := range resources {
+			for unit := 1; unit <= r.Capacity && need > 0; unit++ {
+				err := tx.Transaction(func(sp *gorm.DB) error {
+					return insertSlotLocks(sp, resourceLockKey(r.ID, unit), appt, end, ErrResourceUnavailable)
+				})
+				if errors.Is(err, ErrResourceUnavailable) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				held = append(held, models.AppointmentResource{AppointmentID: appt.ID, ClinicResourceID: r.ID, Unit: unit})
+				need--
+			}
+			if need == 0 {
+				break
+			}
+		}
+		if need > 0 {
+			return fmt.Errorf("%w (%s)", ErrResourceUnavailable, t)
+		}
+	}
+	if len(held) == 0 {
+		return nil
+	}
+	return tx.Create(&held).Error
+}
+
+// resourceUnit is one unit of a clinic resource with the windows it is held in
+type resourceUnit struct {
+	resourceID uint64
+	unit       int
+	busy       []timeWindow
+}
+
+// resourceCalendar holds the units of the resource types a slot search needs
+type resourceCalendar struct {
+	needs map[string]int
+	units map[string][]*resourceUnit
+}
+
+// loadResourceCalendar loads the active units of the needed types and their holds overlapping [from, to)
+func loadResourceCalendar(db *gorm.DB, clinicID uint64, needs map[string]int, from, to time.Time) (*resourceCalendar, error) {
+	cal := &resourceCalendar{needs: needs, units: map[string][]*resourceUnit{}}
+	if len(needs) == 0 {
+		return cal, nil
+	}
+	types := make([]string, 0, len(needs))
+	for t := range needs {
+		types = append(types, t)
+	}
+	var resources []models.ClinicResource
+	if err := db.Where("clinic_id = ? AND resource_type IN ? AND status = ?", clinicID, types, models.ClinicResourceActive).
+		Order("id").Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return cal, nil
+	}
+	byKey := map[string]*resourceUnit{}
+	ids := make([]uint64, 0, len(resources))
+	for _, r := range resources {
+		ids = append(ids, r.ID)
+		for unit := 1; unit <= r.Capacity; unit++ {
+			u := &resourceUnit{resourceID: r.ID, unit: unit}
+			cal.units[r.ResourceType] = append(cal.units[r.ResourceType], u)
+			byKey[resourceLockKey(r.ID, unit)] = u
+		}
+	}
+
+	var holds []struct {
+		ClinicResourceID uint64
+		Unit             int
+		StartAt          time.Time
+		EndAt            time.Time
+		BufferMinutes    int
+	}
+	if err := db.Table("appointment_resources").
+		Select("appointment_resources.clinic_resource_id, appointment_resources.unit, appointments.start_at, appointments.end_at, appointments.buffer_minutes").
+		Joins("JOIN appointments ON appointments.id = appointment_resources.appointment_id").
+		Where("appointment_resources.clinic_resource_id IN ? AND appointments.status <> ? AND appointments.start_at < ? AND appointments.end_at > ?",
+			ids, models.AppointmentCancelled, to, from).
+		Scan(&holds).Error; err != nil {
+		return nil, err
+	}
+	for _, h := range holds {
+		if u, ok := byKey[resourceLockKey(h.ClinicResourceID, h.Unit)]; ok {
+			u.busy = append(u.busy, timeWindow{h.StartAt, h.EndAt.Add(time.Duration(h.BufferMinutes) * time.Minute)})
+		}
+	}
+	return cal, nil
+}
+
+// free reports whether enough units of every needed type are free during w
+func (c *resourceCalendar) free(w timeWindow) bool {
+	for t, need := range c.needs {
+		available := 0
+		for _, u := range c.units[t] {
+			taken := false
+			for _, b := range u.busy {
+				if w.start.Before(b.end) && b.start.Before(w.end) {
+					taken = true
+					break
+				}
+			}
+			if !taken {
+				available++
+			}
+		}
+		if available < need {
+			return false
+		}
+	}
+	return true
+}
+
+// validateClinicResource checks the editable fields of a clinic resource
+func validateClinicResource(r *models.ClinicResource) error {
+	if r.Kind != models.ResourceKindRoom && r.Kind != models.ResourceKindEquipment {
+		return errors.New("kind must be 'room' or 'equipment'")
+	}
+	r.ResourceType = strings.ToLower(strings.TrimSpace(r.ResourceType))
+	if !resourceTypePattern.MatchString(r.ResourceType) {
+		return errors.New("resource_type must be 2-50 lowercase letters, digits or underscores")
+	}
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" || len(r.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+	if r.Capacity < 1 || r.Capacity > maxResourceCapacity {
+		return fmt.Errorf("capacity must be between 1 and %d", maxResourceCapacity)
+	}
+	if r.Status != models.ClinicResourceActive && r.Status != models.ClinicResourceInactive {
+		return errors.New("status must be 'active' or 'inactive'")
+	}
+	return nil
+}
+
+// ListClinicResources returns the clinic's rooms and devices
+func ListClinicResources(clinicID uint64) ([]models.ClinicResource, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	resources := []models.ClinicResource{}
+	if err := db.Order("kind, resource_type, name").Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// CreateClinicResource adds a room or device to the clinic
+func CreateClinicResource(clinicID uint64, req reqdto.CreateClinicResourceRequest) (*models.ClinicResource, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	resource := models.ClinicResource{
+		ClinicID:     clinicID,
+		Kind:         req.Kind,
+		ResourceType: req.ResourceType,
+		Name:         req.Name,
+		Capacity:     req.Capacity,
+		Status:       models.ClinicResourceActive,
+	}
+	if resource.Capacity == 0 {
+		resource.Capacity = 1
+	}
+	if err := validateClinicResource(&resource); err != nil {
+		return nil, err
+	}
+	if err := db.Create(&resource).Error; err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// UpdateClinicResource updates the provided fields of a clinic resource.
+// Units already held by appointments keep their bookings when capacity shrinks or the resource is deactivated.
+func UpdateClinicResource(clinicID, id uint64, req reqdto.UpdateClinicResourceRequest) (*models.ClinicResource, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	var resource models.ClinicResource
+	if err := db.First(&resource, id).Error; err != nil {
+		return nil, err
+	}
+	if req.Kind != nil {
+		resource.Kind = *req.Kind
+	}
+	if req.ResourceType != nil {
+		resource.ResourceType = *req.ResourceType
+	}
+	if req.Name != nil {
+		resource.Name = *req.Name
+	}
+	if req.Capacity != nil {
+		resource.Capacity = *req.Capacity
+	}
+	if req.Status != nil {
+		resource.Status = *req.Status
+	}
+	if err := validateClinicResource(&resource); err != nil {
+		return nil, err
+	}
+	if err := db.Save(&resource).Error; err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// DeleteClinicResource removes a resource that no upcoming appointment holds
+func DeleteClinicResource(clinicID, id uint64) error {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	var resource models.ClinicResource
+	if err := db.First(&resource, id).Error; err != nil {
+		return err
+	}
+	var upcoming int64
+	if err := config.DB.Model(&models.AppointmentResource{}).
+		Joins("JOIN appointments ON appointments.id = appointment_resources.appointment_id").
+		Where("appointment_resources.clinic_resource_id = ? AND appointments.status IN ? AND appointments.end_at > ?",
+			id, []string{models.AppointmentRequested, models.AppointmentConfirmed, models.AppointmentCheckedIn}, time.Now()).
+		Count(&upcoming).Error; err != nil {
+		return err
+	}
+	if upcoming > 0 {
+		return ErrResourceInUse
+	}
+	return db.Delete(&resource).Error
+}
+
+// ==================== CLINIC REQUIREMENT ADJUSTMENTS ====================
+
+// clinicTreatmentRequirements builds the catalog, clinic and effective requirements of a clinic treatment
+func clinicTreatmentRequirements(db *gorm.DB, clinicID uint64, treatmentID uint) (*resdto.ClinicTreatmentRequirementsDTO, error) {
+	var count int64
+	if err := db.Model(&models.ClinicTreatment{}).Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrTreatmentNotOffered
+	}
+	result := &resdto.ClinicTreatmentRequirementsDTO{
+		TreatmentID: treatmentID,
+		Catalog:     []models.TreatmentResourceRequirement{},
+		Clinic:      []models.ClinicTreatmentRequirement{},
+	}
+	if err := db.Where("treatment_id = ?", treatmentID).Order("side_area_id, id").Find(&result.Catalog).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).Order("side_area_id, id").Find(&result.Clinic).Error; err != nil {
+		return nil, err
+	}
+	result.Effective = mergeRequirements(result.Catalog, result.Clinic)
+	return result, nil
+}
+
+// GetClinicTreatmentRequirements returns a treatment's resource requirements at the clinic
+func GetClinicTreatmentRequirements(clinicID uint64, treatmentID uint) (*resdto.ClinicTreatmentRequirementsDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return clinicTreatmentRequirements(db, clinicID, treatmentID)
+}
+
+// SetClinicTreatmentRequirements replaces the clinic's requirement adjustments for a treatment
+func SetClinicTreatmentRequirements(clinicID uint64, treatmentID uint, req reqdto.SetClinicTreatmentRequirementsRequest) (*resdto.ClinicTreatmentRequirementsDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if _, err := clinicTreatmentRequirements(db, clinicID, treatmentID); err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.ClinicTreatmentRequirement, 0, len(req.Requirements))
+	seen := make(map[string]bool, len(req.Requirements))
+	for i, r := range req.Requirements {
+		quantity := 1
+		if r.Quantity != nil {
+			quantity = *r.Quantity
+		}
+		resourceType, err := validateRequirement(db, treatmentID, i, r.SideAreaID, r.Kind, r.ResourceType, quantity, seen)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, models.ClinicTreatmentRequirement{
+			ClinicID:     clinicID,
+			TreatmentID:  treatmentID,
+			SideAreaID:   r.SideAreaID,
+			Kind:         r.Kind,
+			ResourceType: resourceType,
+			Quantity:     quantity,
+		})
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("clinic_id = ? AND treatment_id = ?", clinicID, treatmentID).Delete(&models.ClinicTreatmentRequirement{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clinicTreatmentRequirements(db, clinicID, treatmentID)
+}
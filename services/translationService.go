@@ -0,0 +1,213 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"skinSync/config"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ==================== CONTENT TRANSLATIONS ====================
+
+// Translatable entity types
+const (
+	TranslationEntityTreatment = "treatment"
+	TranslationEntityArea      = "area"
+	TranslationEntitySideArea  = "side_area"
+	TranslationEntityQuestion  = "question"
+	TranslationEntityOption    = "option"
+)
+
+// translatableFields lists the fields that can be translated per entity type
+var translatableFields = map[string][]string{
+	TranslationEntityTreatment: {"name", "description"},
+	TranslationEntityArea:      {"name", "description"},
+	TranslationEntitySideArea:  {"name", "description"},
+	TranslationEntityQuestion:  {"question_text"},
+	TranslationEntityOption:    {"option_text"},
+}
+
+// translationEntityModels maps entity types to the model that owns the base content
+var translationEntityModels = map[string]func() interface{}{
+	TranslationEntityTreatment: func() interface{} { return &models.Treatment{} },
+	TranslationEntityArea:      func() interface{} { return &models.Area{} },
+	TranslationEntitySideArea:  func() interface{} { return &models.SideArea{} },
+	TranslationEntityQuestion:  func() interface{} { return &models.SkinConditionQuestion{} },
+	TranslationEntityOption:    func() interface{} { return &models.SkinConditionQuestionOption{} },
+}
+
+// EntityTranslations groups an entity's translations by locale then field
+type EntityTranslations struct {
+	EntityType    string                       `json:"entity_type"`
+	EntityID      uint64                       `json:"entity_id"`
+	DefaultLocale string                       `json:"default_locale"`
+	Fields        []string                     `json:"fields"`
+	Translations  map[string]map[string]string `json:"translations"`
+}
+
+// validateTranslationTarget checks entity type, locale and that the entity exists
+func validateTranslationTarget(db *gorm.DB, entityType string, entityID uint64, locale string) error {
+	newModel, ok := translationEntityModels[entityType]
+	if !ok {
+		return fmt.Errorf("unknown translation entity %q", entityType)
+	}
+	if locale != "" {
+		if !utils.IsSupportedLocale(locale) {
+			return fmt.Errorf("unsupported locale %q", locale)
+		}
+		if locale == utils.DefaultLocale() {
+			return fmt.Errorf("%q is the default locale - edit the content itself instead", locale)
+		}
+	}
+	return db.First(newModel(), entityID).Error
+}
+
+// GetEntityTranslations returns all translations for one entity
+func GetEntityTranslations(entityType string, entityID uint64) (*EntityTranslations, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateTranslationTarget(db, entityType, entityID, ""); err != nil {
+		return nil, err
+	}
+
+	var rows []models.ContentTranslation
+	if err := db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("locale, field").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := &EntityTranslations{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		DefaultLocale: utils.DefaultLocale(),
+		Fields:        translatableFields[entityType],
+		Translations:  map[string]map[string]string{},
+	}
+	for _, r := range rows {
+		if result.Translations[r.Locale] == nil {
+			result.Translations[r.Locale] = map[string]string{}
+		}
+		result.Translations[r.Locale][r.Field] = r.Value
+	}
+	return result, nil
+}
+
+// UpsertEntityTranslations sets translated fields for one entity and locale.
+// Blank values remove the translation so the default locale is served again.
+// Translations are not versioned with the catalog and are served as soon as they are saved.
+func UpsertEntityTranslations(entityType string, entityID uint64, locale string, fields map[string]string) (*EntityTranslations, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if err := validateTranslationTarget(db, entityType, entityID, locale); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("at least one field is required")
+	}
+
+	allowed := map[string]bool{}
+	for _, f := range translatableFields[entityType] {
+		allowed[f] = true
+	}
+	for field := range fields {
+		if !allowed[field] {
+			return nil, fmt.Errorf("field %q cannot be translated for %s", field, entityType)
+		}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for field, value := range fields {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				if err := tx.Where("entity_type = ? AND entity_id = ? AND field = ? AND locale = ?",
+					entityType, entityID, field, locale).Delete(&models.ContentTranslation{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			row := models.ContentTranslation{
+				EntityType: entityType,
+				EntityID:   entityID,
+				Field:      field,
+				Locale:     locale,
+				Value:      value,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+			}).Create(&row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GetEntityTranslations(entityType, entityID)
+}
+
+// DeleteEntityTranslations removes every translated field of an entity for one locale
+func DeleteEntityTranslations(entityType string, entityID uint64, locale string) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if err := validateTranslationTarget(db, entityType, entityID, locale); err != nil {
+		return err
+	}
+	return db.Where("entity_type = ? AND entity_id = ? AND locale = ?", entityType, entityID, locale).
+		Delete(&models.ContentTranslation{}).Error
+}
+
+// loadTranslations fetches translations for a set of entities in one locale, keyed by entity id then field.
+// Returns an empty map for the default locale.
+func loadTranslations(entityType string, ids []uint64, locale string) (map[uint64]map[string]string, error) {
+	result := map[uint64]map[string]string{}
+	if len(ids) == 0 || locale == "" || locale == utils.DefaultLocale() {
+		return result, nil
+	}
+
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var rows []models.ContentTranslation
+	if err := db.Where("entity_type = ? AND locale = ? AND entity_id IN ?", entityType, locale, ids).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		if result[r.EntityID] == nil {
+			result[r.EntityID] = map[string]string{}
+		}
+		result[r.EntityID][r.Field] = r.Value
+	}
+	return result, nil
+}
+
+// translated returns the translated value for field, falling back to the base value
+func translated(t map[uint64]map[string]string, id uint64, field, fallback string) string {
+	if v, ok := t[id][field]; ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+// deleteEntityTranslations removes all translations of deleted entities.
+// ids is an id slice or a subquery selecting ids.
+func deleteEntityTranslations(tx *gorm.DB, entityType string, ids interface{}) error {
+	return tx.Where("entity_type = ? AND entity_id IN (?)", entityType, ids).Delete(&models.ContentTranslation{}).Error
+}
@@ -0,0 +1,513 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	maxRecordLotNumbers = 20
+	maxLotNumberLength  = 64
+)
+
+var (
+	// ErrTreatmentRecordSigned is returned when changing a signed record, which only takes addenda
+	ErrTreatmentRecordSigned = errors.New("treatment record is signed; add an addendum instead")
+	// ErrTreatmentRecordNotSigned is returned when adding an addendum to a draft, which can still be edited
+	ErrTreatmentRecordNotSigned = errors.New("addenda can only be added to signed records; edit the draft instead")
+	// ErrTreatmentRecordExists is returned when the appointment already has a treatment record
+	ErrTreatmentRecordExists = errors.New("this appointment already has a treatment record")
+	// ErrNotRecordPractitioner is returned when someone other than the treating practitioner signs a record
+	ErrNotRecordPractitioner = errors.New("only the practitioner who performed the treatment can sign the record")
+)
+
+// checkRecordPractitioner ensures the clinic user is a doctor or injector of the clinic
+func checkRecordPractitioner(db *gorm.DB, clinicID, practitionerID uint64) error {
+	var user models.ClinicUser
+	err := db.Preload("Role").Where("id = ? AND clinic_id = ? AND deleted_at IS NULL", practitionerID, clinicID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !isPractitionerRole(user.Role.Name)) {
+		return errors.New("practitioner must be a doctor or injector of the clinic")
+	}
+	return err
+}
+
+// resolveTreatmentRecordItems validates the treated side areas against the treatment's active catalog
+func resolveTreatmentRecordItems(db *gorm.DB, treatmentID uint, req []reqdto.TreatmentRecordItemRequest) ([]models.TreatmentRecordItem, error) {
+	items := make([]models.TreatmentRecordItem, 0, len(req))
+	seen := make(map[uint]bool, len(req))
+	for _, r := range req {
+		if seen[r.SideAreaID] {
+			return nil, fmt.Errorf("side area %d is recorded more than once", r.SideAreaID)
+		}
+		seen[r.SideAreaID] = true
+
+		var sideArea models.SideArea
+		err := db.Select("id", "area_id", "name").Where("id = ? AND treatment_id = ? AND status = ?", r.SideAreaID, treatmentID, models.CatalogStatusActive).
+			First(&sideArea).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("side area %d is not an active part of this treatment", r.SideAreaID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		product := strings.TrimSpace(r.Product)
+		if product == "" || len(product) > 255 {
+			return nil, fmt.Errorf("product for %s is required and must be at most 255 characters", sideArea.Name)
+		}
+		if r.SyringeCount < 1 {
+			return nil, fmt.Errorf("syringe_count for %s must be at least 1", sideArea.Name)
+		}
+		if r.SyringeSize < 0 {
+			return nil, fmt.Errorf("syringe_size for %s cannot be negative", sideArea.Name)
+		}
+		var lots []string
+		for _, lot := range r.LotNumbers {
+			if lot = strings.TrimSpace(lot); lot == "" {
+				continue
+			}
+			if len(lot) > maxLotNumberLength {
+				return nil, fmt.Errorf("lot numbers must be at most %d characters", maxLotNumberLength)
+			}
+			lots = append(lots, lot)
+		}
+		if len(lots) > maxRecordLotNumbers {
+			return nil, fmt.Errorf("at most %d lot numbers can be recorded per side area", maxRecordLotNumbers)
+		}
+		notes := r.Notes
+		if err := trimmedPatientText("notes", &notes, maxPatientText); err != nil {
+			return nil, err
+		}
+
+		items = append(items, models.TreatmentRecordItem{
+			AreaID:       sideArea.AreaID,
+			SideAreaID:   sideArea.ID,
+			Product:      product,
+			SyringeCount: r.SyringeCount,
+			SyringeSize:  r.SyringeSize,
+			LotNumbers:   lots,
+			Notes:        notes,
+		})
+	}
+	return items, nil
+}
+
+// applyTreatmentRecordDetails validates and copies the editable fields of a draft onto the record
+func applyTreatmentRecordDetails(db *gorm.DB, record *models.TreatmentRecord, req reqdto.TreatmentRecordRequest) error {
+	if req.PractitionerID != 0 {
+		record.PractitionerID = req.PractitionerID
+	}
+	if err := checkRecordPractitioner(db, record.ClinicID, record.PractitionerID); err != nil {
+		return err
+	}
+	if req.PerformedAt != nil {
+		record.PerformedAt = req.PerformedAt.UTC()
+	}
+	if record.PerformedAt.After(time.Now()) {
+		return errors.New("performed_at cannot be in the future")
+	}
+	injectionNotes, aftercare := req.InjectionNotes, req.Aftercare
+	if err := trimmedPatientText("injection_notes", &injectionNotes, maxPatientText); err != nil {
+		return err
+	}
+	if err := trimmedPatientText("aftercare", &aftercare, maxPatientText); err != nil {
+		return err
+	}
+	record.InjectionNotes = injectionNotes
+	record.Aftercare = aftercare
+
+	items, err := resolveTreatmentRecordItems(db, record.TreatmentID, req.Items)
+	if err != nil {
+		return err
+	}
+	record.Items = items
+	return nil
+}
+
+// treatmentRecordDTOs builds record DTOs with patient, practitioner, treatment and side area names
+func treatmentRecordDTOs(db *gorm.DB, records []models.TreatmentRecord) ([]resdto.TreatmentRecordDTO, error) {
+	out := make([]resdto.TreatmentRecordDTO, 0, len(records))
+	if len(records) == 0 {
+		return out, nil
+	}
+
+	var patientIDs, staffIDs []uint64
+	var treatmentIDs, sideAreaIDs []uint
+	for _, r := range records {
+		patientIDs = append(patientIDs, r.PatientID)
+		staffIDs = append(staffIDs, r.PractitionerID)
+		if r.SignedByID != nil {
+			staffIDs = append(staffIDs, *r.SignedByID)
+		}
+		treatmentIDs = append(treatmentIDs, r.TreatmentID)
+		for _, item := range r.Items {
+			sideAreaIDs = append(sideAreaIDs, item.SideAreaID)
+		}
+		for _, a := range r.Addenda {
+			staffIDs = append(staffIDs, a.AuthorID)
+		}
+	}
+
+	var patients []models.ClinicPatient
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", patientIDs).Find(&patients).Error; err != nil {
+		return nil, err
+	}
+	patientByID := make(map[uint64]string, len(patients))
+	for _, p := range patients {
+		patientByID[p.ID] = p.Name
+	}
+
+	var staff []models.ClinicUser
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", staffIDs).Find(&staff).Error; err != nil {
+		return nil, err
+	}
+	staffByID := make(map[uint64]string, len(staff))
+	for _, s := range staff {
+		staffByID[s.ID] = s.Name
+	}
+
+	var treatments []models.Treatment
+	if err := db.Select("id", "name").Where("id IN ?", treatmentIDs).Find(&treatments).Error; err != nil {
+		return nil, err
+	}
+	treatmentByID := make(map[uint]string, len(treatments))
+	for _, t := range treatments {
+		treatmentByID[t.ID] = t.Name
+	}
+
+	sideAreaByID := make(map[uint]models.SideArea)
+	if len(sideAreaIDs) > 0 {
+		var sideAreas []models.SideArea
+		if err := db.Preload("Area").Where("id IN ?", sideAreaIDs).Find(&sideAreas).Error; err != nil {
+			return nil, err
+		}
+		for _, s := range sideAreas {
+			sideAreaByID[s.ID] = s
+		}
+	}
+
+	for _, r := range records {
+		dto := resdto.TreatmentRecordDTO{
+			TreatmentRecord:  r,
+			PatientName:      patientByID[r.PatientID],
+			PractitionerName: staffByID[r.PractitionerID],
+			TreatmentName:    treatmentByID[r.TreatmentID],
+			Items:            make([]resdto.TreatmentRecordItemDTO, 0, len(r.Items)),
+			Addenda:          make([]resdto.TreatmentRecordAddendumDTO, 0, len(r.Addenda)),
+		}
+		if r.SignedByID != nil {
+			dto.SignedByName = staffByID[*r.SignedByID]
+		}
+		dto.TreatmentRecord.Items = nil
+		dto.TreatmentRecord.Addenda = nil
+		for _, item := range r.Items {
+			sideArea := sideAreaByID[item.SideAreaID]
+			dto.Items = append(dto.Items, resdto.TreatmentRecordItemDTO{
+				TreatmentRecordItem: item,
+				AreaName:            sideArea.Area.Name,
+				SideAreaName:        sideArea.Name,
+			})
+		}
+		for _, a := range r.Addenda {
+			dto.Addenda = append(dto.Addenda, resdto.TreatmentRecordAddendumDTO{
+				TreatmentRecordAddendum: a,
+				AuthorName:              staffByID[a.AuthorID],
+			})
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+// loadTreatmentRecord fetches a record of the clinic with its items and addenda
+func loadTreatmentRecord(db *gorm.DB, id uint64) (*models.TreatmentRecord, error) {
+	var record models.TreatmentRecord
+	if err := db.Preload("Items").Preload("Addenda", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("created_at ASC, id ASC")
+	}).First(&record, id).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// treatmentRecordDTO reloads a record of the clinic and builds its DTO
+func treatmentRecordDTO(clinicID, id uint64) (*resdto.TreatmentRecordDTO, error) {
+	record, err := loadTreatmentRecord(config.ClinicDB(clinicID), id)
+	if err != nil {
+		return nil, err
+	}
+	dtos, err := treatmentRecordDTOs(config.DB, []models.TreatmentRecord{*record})
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// TreatmentRecordFilter narrows a treatment record listing; zero fields are ignored
+type TreatmentRecordFilter struct {
+	PatientID      uint64
+	AppointmentID  uint64
+	PractitionerID uint64
+	Status         string
+}
+
+// ListTreatmentRecords returns a page of the clinic's treatment records, most recent first
+func ListTreatmentRecords(clinicID uint64, f TreatmentRecordFilter, page, pageSize int) (*resdto.TreatmentRecordListResponse, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	page, pageSize = normalizePage(page, pageSize)
+
+	query := db.Model(&models.TreatmentRecord{})
+	if f.PatientID != 0 {
+		query = query.Where("patient_id = ?", f.PatientID)
+	}
+	if f.AppointmentID != 0 {
+		query = query.Where("appointment_id = ?", f.AppointmentID)
+	}
+	if f.PractitionerID != 0 {
+		query = query.Where("practitioner_id = ?", f.PractitionerID)
+	}
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	var records []models.TreatmentRecord
+	if err := query.Preload("Items").Preload("Addenda").Order("performed_at DESC, id DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	items, err := treatmentRecordDTOs(config.DB, records)
+	if err != nil {
+		return nil, err
+	}
+	return &resdto.TreatmentRecordListResponse{
+		Items: items,
+		Meta:  resdto.PageMeta{Page: page, PageSize: pageSize, Total: total},
+	}, nil
+}
+
+// GetTreatmentRecord returns one treatment record of the clinic
+func GetTreatmentRecord(clinicID, id uint64) (*resdto.TreatmentRecordDTO, error) {
+	if config.DB == nil {
+		return nil, errors.New("database not initialized")
+	}
+	return treatmentRecordDTO(clinicID, id)
+}
+
+// CreateTreatmentRecord starts a draft record of a performed treatment, for a checked-in or
+// completed appointment or for a patient treated without one
+func CreateTreatmentRecord(clinicID, clinicUserID uint64, req reqdto.TreatmentRecordRequest) (*resdto.TreatmentRecordDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	record := models.TreatmentRecord{
+		ClinicID:    clinicID,
+		Status:      models.TreatmentRecordDraft,
+		CreatedByID: clinicUserID,
+	}
+	if req.AppointmentID != nil {
+		var appt models.Appointment
+		if err := db.First(&appt, *req.AppointmentID).Error; err != nil {
+			return nil, err
+		}
+		if appt.Status != models.AppointmentCheckedIn && appt.Status != models.AppointmentCompleted {
+			return nil, errors.New("treatment can only be recorded for checked-in or completed appointments")
+		}
+		if req.TreatmentID != 0 && req.TreatmentID != appt.TreatmentID {
+			return nil, errors.New("treatment_id does not match the appointment")
+		}
+		if err := ensureClinicPatient(config.DB, clinicID, appt.UserID, appt.CreatedByType == models.AuditActorCustomer); err != nil {
+			return nil, err
+		}
+		var patient models.ClinicPatient
+		if err := db.Where("user_id = ?", appt.UserID).First(&patient).Error; err != nil {
+			return nil, err
+		}
+		record.AppointmentID = &appt.ID
+		record.PatientID = patient.ID
+		record.PractitionerID = appt.PractitionerID
+		record.TreatmentID = appt.TreatmentID
+		record.PerformedAt = appt.StartAt
+		if appt.CheckedInAt != nil {
+			record.PerformedAt = *appt.CheckedInAt
+		}
+	} else {
+		if req.PatientID == 0 || req.TreatmentID == 0 {
+			return nil, errors.New("patient_id and treatment_id are required without an appointment")
+		}
+		var patient models.ClinicPatient
+		if err := db.First(&patient, req.PatientID).Error; err != nil {
+			return nil, err
+		}
+		var treatment models.Treatment
+		if err := db.Select("id").First(&treatment, req.TreatmentID).Error; err != nil {
+			return nil, err
+		}
+		var offered int64
+		if err := db.Model(&models.ClinicTreatment{}).Where("clinic_id = ? AND treatment_id = ?", clinicID, treatment.ID).
+			Count(&offered).Error; err != nil {
+			return nil, err
+		}
+		if offered == 0 {
+			return nil, ErrTreatmentNotOffered
+		}
+		record.PatientID = patient.ID
+		record.PractitionerID = clinicUserID
+		record.TreatmentID = treatment.ID
+		record.PerformedAt = time.Now().UTC()
+	}
+	if err := applyTreatmentRecordDetails(db, &record, req); err != nil {
+		return nil, err
+	}
+
+	if err := db.Create(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrTreatmentRecordExists
+		}
+		return nil, err
+	}
+	return treatmentRecordDTO(clinicID, record.ID)
+}
+
+// UpdateTreatmentRecord edits a draft record; the items replace the recorded side areas.
+// The appointment, patient and treatment cannot change.
+func UpdateTreatmentRecord(clinicID, id uint64, req reqdto.TreatmentRecordRequest) (*resdto.TreatmentRecordDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var record models.TreatmentRecord
+	if err := db.First(&record, id).Error; err != nil {
+		return nil, err
+	}
+	if record.Status != models.TreatmentRecordDraft {
+		return nil, ErrTreatmentRecordSigned
+	}
+	if err := applyTreatmentRecordDetails(db, &record, req); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// The status condition keeps a concurrent sign-off from being overwritten
+		res := tx.Model(&models.TreatmentRecord{}).
+			Where("id = ? AND status = ?", record.ID, models.TreatmentRecordDraft).
+			Updates(map[string]interface{}{
+				"practitioner_id": record.PractitionerID,
+				"performed_at":    record.PerformedAt,
+				"injection_notes": record.InjectionNotes,
+				"aftercare":       record.Aftercare,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTreatmentRecordSigned
+		}
+		if err := tx.Where("record_id = ?", record.ID).Delete(&models.TreatmentRecordItem{}).Error; err != nil {
+			return err
+		}
+		for i := range record.Items {
+			record.Items[i].RecordID = record.ID
+		}
+		if len(record.Items) == 0 {
+			return nil
+		}
+		return tx.Create(&record.Items).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return treatmentRecordDTO(clinicID, record.ID)
+}
+
+// SignTreatmentRecord signs off a draft record, after which it is immutable. Only the treating
+// practitioner signs, and treatments with side areas need at least one recorded first.
+func SignTreatmentRecord(clinicID, clinicUserID, id uint64) (*resdto.TreatmentRecordDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	record, err := loadTreatmentRecord(db, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status != models.TreatmentRecordDraft {
+		return nil, ErrTreatmentRecordSigned
+	}
+	if record.PractitionerID != clinicUserID {
+		return nil, ErrNotRecordPractitioner
+	}
+	if len(record.Items) == 0 {
+		var sideAreas int64
+		if err := db.Model(&models.SideArea{}).Where("treatment_id = ?", record.TreatmentID).Count(&sideAreas).Error; err != nil {
+			return nil, err
+		}
+		if sideAreas > 0 {
+			return nil, errors.New("record the treated side areas before signing")
+		}
+	}
+
+	now := time.Now()
+	res := db.Model(&models.TreatmentRecord{}).
+		Where("id = ? AND status = ?", record.ID, models.TreatmentRecordDraft).
+		Updates(map[string]interface{}{
+			"status":       models.TreatmentRecordSigned,
+			"signed_at":    now,
+			"signed_by_id": clinicUserID,
+		})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrTreatmentRecordSigned
+	}
+	return treatmentRecordDTO(clinicID, record.ID)
+}
+
+// AddTreatmentRecordAddendum appends an amendment to a signed record
+func AddTreatmentRecordAddendum(clinicID, clinicUserID, id uint64, req reqdto.TreatmentRecordAddendumRequest) (*resdto.TreatmentRecordDTO, error) {
+	db := config.ClinicDB(clinicID)
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	note := req.Note
+	if err := trimmedPatientText("note", &note, maxPatientText); err != nil {
+		return nil, err
+	}
+	if note == "" {
+		return nil, errors.New("note is required")
+	}
+
+	var record models.TreatmentRecord
+	if err := db.Select("id", "status").First(&record, id).Error; err != nil {
+		return nil, err
+	}
+	if record.Status != models.TreatmentRecordSigned {
+		return nil, ErrTreatmentRecordNotSigned
+	}
+	addendum := models.TreatmentRecordAddendum{RecordID: record.ID, AuthorID: clinicUserID, Note: note}
+	if err := db.Create(&addendum).Error; err != nil {
+		return nil, err
+	}
+	return treatmentRecordDTO(clinicID, record.ID)
+}
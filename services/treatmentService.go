@@ -1,18 +1,35 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"skinSync/config"
+	reqdto "skinSync/dto/request"
 	resdto "skinSync/dto/response"
 	"skinSync/models"
-)
 
-// GetTreatmentMasters returns all treatments
-func GetTreatmentMasters() (resdto.TreatmentMastersResponse, error) {
-	db := config.DB
+	"gorm.io/gorm"
+)
 
-	var treatments []models.Treatment
+// GetTreatmentMasters returns all treatments from the published catalog, localized to locale
+func GetTreatmentMasters(locale string) (resdto.TreatmentMastersResponse, error) {
+	treatments, err := publishedActiveTreatments()
+	if err != nil {
+		return resdto.TreatmentMastersResponse{
+			IsSuccess: false,
+			Message:   "Failed to fetch treatments",
+			Data:      nil,
+		}, err
+	}
 
-	err := db.Find(&treatments).Error
+	ids := make([]uint64, 0, len(treatments))
+	for _, t := range treatments {
+		ids = append(ids, uint64(t.ID))
+	}
+	tr, err := loadTranslations(TranslationEntityTreatment, ids, locale)
 	if err != nil {
 		return resdto.TreatmentMastersResponse{
 			IsSuccess: false,
@@ -26,9 +43,9 @@ func GetTreatmentMasters() (resdto.TreatmentMastersResponse, error) {
 	for _, t := range treatments {
 		data = append(data, resdto.TreatmentDTO{
 			ID:          t.ID,
-			Name:        t.Name,
+			Name:        translated(tr, uint64(t.ID), "name", t.Name),
 			Icon:        t.Icon,
-			Description: t.Description,
+			Description: translated(tr, uint64(t.ID), "description", t.Description),
 			IsArea:      t.IsArea,
 		})
 	}
@@ -40,13 +57,22 @@ func GetTreatmentMasters() (resdto.TreatmentMastersResponse, error) {
 	}, nil
 }
 
-// GetAreasByTreatment returns all areas for a given treatment ID
-func GetAreasByTreatment(treatmentID uint) (resdto.AreasResponse, error) {
-	db := config.DB
-
-	var areas []models.Area
+// GetAreasByTreatment returns all published areas for a given treatment ID, localized to locale
+func GetAreasByTreatment(treatmentID uint, locale string) (resdto.AreasResponse, error) {
+	areas, err := publishedActiveAreas(treatmentID)
+	if err != nil {
+		return resdto.AreasResponse{
+			IsSuccess: false,
+			Message:   "Failed to fetch areas",
+			Data:      nil,
+		}, err
+	}
 
-	err := db.Where("treatment_id = ?", treatmentID).Find(&areas).Error
+	ids := make([]uint64, 0, len(areas))
+	for _, a := range areas {
+		ids = append(ids, uint64(a.ID))
+	}
+	tr, err := loadTranslations(TranslationEntityArea, ids, locale)
 	if err != nil {
 		return resdto.AreasResponse{
 			IsSuccess: false,
@@ -60,9 +86,9 @@ func GetAreasByTreatment(treatmentID uint) (resdto.AreasResponse, error) {
 	for _, a := range areas {
 		areaDTO := resdto.AreaDTO{
 			ID:          a.ID,
-			Name:        a.Name,
+			Name:        translated(tr, uint64(a.ID), "name", a.Name),
 			Icon:        a.Icon,
-			Description: a.Description,
+			Description: translated(tr, uint64(a.ID), "description", a.Description),
 			IsSideArea:  a.IsSideArea,
 		}
 
@@ -88,13 +114,22 @@ func GetAreasByTreatment(treatmentID uint) (resdto.AreasResponse, error) {
 	}, nil
 }
 
-// GetSideAreas returns all side areas for a given treatment ID and area ID
-func GetSideAreas(treatmentID, areaID uint) (resdto.SideAreasResponse, error) {
-	db := config.DB
-
-	var sideAreas []models.SideArea
+// GetSideAreas returns all published side areas for a given treatment ID and area ID, localized to locale
+func GetSideAreas(treatmentID, areaID uint, locale string) (resdto.SideAreasResponse, error) {
+	sideAreas, err := publishedActiveSideAreas(treatmentID, areaID)
+	if err != nil {
+		return resdto.SideAreasResponse{
+			IsSuccess: false,
+			Message:   "Failed to fetch side areas",
+			Data:      nil,
+		}, err
+	}
 
-	err := db.Where("treatment_id = ? AND area_id = ?", treatmentID, areaID).Find(&sideAreas).Error
+	ids := make([]uint64, 0, len(sideAreas))
+	for _, sa := range sideAreas {
+		ids = append(ids, uint64(sa.ID))
+	}
+	tr, err := loadTranslations(TranslationEntitySideArea, ids, locale)
 	if err != nil {
 		return resdto.SideAreasResponse{
 			IsSuccess: false,
@@ -114,9 +149,9 @@ func GetSideAreas(treatmentID, areaID uint) (resdto.SideAreasResponse, error) {
 
 		data = append(data, resdto.SideAreaDTO{
 			ID:             sa.ID,
-			Name:           sa.Name,
+			Name:           translated(tr, uint64(sa.ID), "name", sa.Name),
 			Icon:           sa.Icon,
-			Description:    sa.Description,
+			Description:    translated(tr, uint64(sa.ID), "description", sa.Description),
 			MinSyringe:     sa.MinSyringe,
 			MaxSyringe:     sa.MaxSyringe,
 			SyringeOptions: syringeOptions,
@@ -129,3 +164,566 @@ func GetSideAreas(treatmentID, areaID uint) (resdto.SideAreasResponse, error) {
 		Data:      data,
 	}, nil
 }
+
+// ==================== ADMIN CATALOG MANAGEMENT ====================
+
+var (
+	// ErrCatalogInUse is returned when deleting a node that clinics price or doctors are assigned to
+	ErrCatalogInUse = errors.New("catalog item is in use by clinics or doctors - archive it instead")
+	// ErrInvalidCatalogItem wraps validation failures of catalog create/update requests
+	ErrInvalidCatalogItem = errors.New("invalid catalog item")
+	// ErrCatalogNameTaken is returned when another treatment already uses the name
+	ErrCatalogNameTaken = errors.New("treatment with this name already exists")
+)
+
+// validateSyringeRange checks that a syringe range is usable
+func validateSyringeRange(minSyringe, maxSyringe int) error {
+	if minSyringe < 1 {
+		return fmt.Errorf("%w: min_syringe must be at least 1", ErrInvalidCatalogItem)
+	}
+	if maxSyringe < minSyringe {
+		return fmt.Errorf("%w: max_syringe must be greater than or equal to min_syringe", ErrInvalidCatalogItem)
+	}
+	return nil
+}
+
+// validateDurationMinutes checks a catalog scheduling duration; 0 means not set
+func validateDurationMinutes(field string, minutes, max int) error {
+	if minutes < 0 || minutes > max {
+		return fmt.Errorf("%w: %s must be between 0 and %d", ErrInvalidCatalogItem, field, max)
+	}
+	if minutes%int(appointmentBlock/time.Minute) != 0 {
+		return fmt.Errorf("%w: %s must be a multiple of %d", ErrInvalidCatalogItem, field, int(appointmentBlock/time.Minute))
+	}
+	return nil
+}
+
+// validateSyringeDurations checks the first-syringe and extra-syringe minutes of an area or side area
+func validateSyringeDurations(durationMinutes, extraSyringeMinutes int) error {
+	if err := validateDurationMinutes("duration_minutes", durationMinutes, maxAppointmentMinutes); err != nil {
+		return err
+	}
+	return validateDurationMinutes("extra_syringe_minutes", extraSyringeMinutes, maxExtraSyringeMinutes)
+}
+
+// validateCatalogStatus checks a requested catalog status
+func validateCatalogStatus(status string) error {
+	if status != models.CatalogStatusActive && status != models.CatalogStatusArchived {
+		return fmt.Errorf("%w: status must be 'active' or 'archived'", ErrInvalidCatalogItem)
+	}
+	return nil
+}
+
+// catalogCode normalizes an optional stable key; blank codes are stored as NULL
+func catalogCode(code string) *string {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil
+	}
+	return &code
+}
+
+// catalogUsageCount counts clinic prices, doctor assignments, bookings, waitlist entries and
+// treatment records that reference a catalog node. column is one of treatment_id, area_id or side_area_id.
+func catalogUsageCount(db *gorm.DB, column string, id uint) (int64, error) {
+	var total int64
+
+	usageModels := []interface{}{&models.ClinicSideArea{}, &models.ClinicUserSideArea{}}
+	switch column {
+	case "treatment_id":
+		usageModels = append(usageModels, &models.ClinicTreatment{}, &models.ClinicUserTreatment{},
+			&models.Appointment{}, &models.WaitlistEntry{}, &models.TreatmentRecord{})
+	case "area_id":
+		usageModels = append(usageModels, &models.AppointmentItem{}, &models.TreatmentRecordItem{})
+	case "side_area_id":
+		usageModels = append(usageModels, &models.AppointmentItem{}, &models.WaitlistItem{}, &models.TreatmentRecordItem{})
+	}
+
+	for _, m := range usageModels {
+		var count int64
+		if err := db.Model(m).Where(column+" = ?", id).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// CreateTreatment creates a new top-level treatment
+func CreateTreatment(req reqdto.CreateTreatmentRequest) (*models.Treatment, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidCatalogItem)
+	}
+
+	var existing models.Treatment
+	if err := db.Where("name = ?", name).First(&existing).Error; err == nil {
+		return nil, ErrCatalogNameTaken
+	}
+	if err := validateDurationMinutes("duration_minutes", req.DurationMinutes, maxAppointmentMinutes); err != nil {
+		return nil, err
+	}
+
+	treatment := models.Treatment{
+		Code:        catalogCode(req.Code),
+		Name:        name,
+		Icon:        req.Icon,
+		Description: req.Description,
+		IsArea:      req.IsArea,
+		Status:      models.CatalogStatusActive,
+
+		DurationMinutes: req.DurationMinutes,
+	}
+	if err := db.Create(&treatment).Error; err != nil {
+		return nil, err
+	}
+	return &treatment, nil
+}
+
+// UpdateTreatment updates the provided fields of a treatment
+func UpdateTreatment(id uint, req reqdto.UpdateTreatmentRequest) (*models.Treatment, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var treatment models.Treatment
+	if err := db.First(&treatment, id).Error; err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidCatalogItem)
+		}
+		var existing models.Treatment
+		if err := db.Where("name = ? AND id <> ?", name, id).First(&existing).Error; err == nil {
+			return nil, ErrCatalogNameTaken
+		}
+		treatment.Name = name
+	}
+	if req.Code != nil {
+		treatment.Code = catalogCode(*req.Code)
+	}
+	if req.Icon != nil {
+		treatment.Icon = *req.Icon
+	}
+	if req.Description != nil {
+		treatment.Description = *req.Description
+	}
+	if req.IsArea != nil {
+		treatment.IsArea = *req.IsArea
+	}
+	if req.DurationMinutes != nil {
+		if err := validateDurationMinutes("duration_minutes", *req.DurationMinutes, maxAppointmentMinutes); err != nil {
+			return nil, err
+		}
+		treatment.DurationMinutes = *req.DurationMinutes
+	}
+
+	if err := db.Save(&treatment).Error; err != nil {
+		return nil, err
+	}
+	return &treatment, nil
+}
+
+// DeleteTreatment stages a treatment with its areas and side areas for deletion in the draft.
+// They are removed with their translations on the next publish; restoring the status before then
+// cancels the deletion. Returns ErrCatalogInUse if any clinic or doctor still references it.
+func DeleteTreatment(id uint) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var treatment models.Treatment
+	if err := db.First(&treatment, id).Error; err != nil {
+		return err
+	}
+
+	inUse, err := catalogUsageCount(db, "treatment_id", id)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return ErrCatalogInUse
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SideArea{}).Where("treatment_id = ?", id).Update("status", models.CatalogStatusDeleted).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Area{}).Where("treatment_id = ?", id).Update("status", models.CatalogStatusDeleted).Error; err != nil {
+			return err
+		}
+		return tx.Model(&treatment).Update("status", models.CatalogStatusDeleted).Error
+	})
+}
+
+// SetTreatmentStatus archives or restores a treatment together with its areas and side areas.
+// Children staged for deletion stay staged.
+func SetTreatmentStatus(id uint, status string) (*models.Treatment, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateCatalogStatus(status); err != nil {
+		return nil, err
+	}
+
+	var treatment models.Treatment
+	if err := db.First(&treatment, id).Error; err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&treatment).Update("status", status).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Area{}).Where("treatment_id = ? AND status <> ?", id, models.CatalogStatusDeleted).Update("status", status).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SideArea{}).Where("treatment_id = ? AND status <> ?", id, models.CatalogStatusDeleted).Update("status", status).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &treatment, nil
+}
+
+// CreateArea creates an area under a treatment and marks the treatment as having areas
+func CreateArea(treatmentID uint, req reqdto.CreateAreaRequest) (*models.Area, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidCatalogItem)
+	}
+
+	var treatment models.Treatment
+	if err := db.First(&treatment, treatmentID).Error; err != nil {
+		return nil, err
+	}
+
+	if req.MinSyringe == 0 {
+		req.MinSyringe = 1
+	}
+	if req.MaxSyringe == 0 {
+		req.MaxSyringe = req.MinSyringe
+	}
+	if err := validateSyringeRange(req.MinSyringe, req.MaxSyringe); err != nil {
+		return nil, err
+	}
+	if err := validateSyringeDurations(req.DurationMinutes, req.ExtraSyringeMinutes); err != nil {
+		return nil, err
+	}
+
+	area := models.Area{
+		TreatmentID: treatmentID,
+		Code:        catalogCode(req.Code),
+		Name:        name,
+		Icon:        req.Icon,
+		Description: req.Description,
+		IsSideArea:  req.IsSideArea,
+		MinSyringe:  req.MinSyringe,
+		MaxSyringe:  req.MaxSyringe,
+		Status:      models.CatalogStatusActive,
+
+		DurationMinutes:     req.DurationMinutes,
+		ExtraSyringeMinutes: req.ExtraSyringeMinutes,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&area).Error; err != nil {
+			return err
+		}
+		if !treatment.IsArea {
+			return tx.Model(&treatment).Update("is_area", true).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+// UpdateArea updates the provided fields of an area
+func UpdateArea(id uint, req reqdto.UpdateAreaRequest) (*models.Area, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var area models.Area
+	if err := db.First(&area, id).Error; err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidCatalogItem)
+		}
+		area.Name = name
+	}
+	if req.Code != nil {
+		area.Code = catalogCode(*req.Code)
+	}
+	if req.Icon != nil {
+		area.Icon = *req.Icon
+	}
+	if req.Description != nil {
+		area.Description = *req.Description
+	}
+	if req.IsSideArea != nil {
+		area.IsSideArea = *req.IsSideArea
+	}
+	if req.MinSyringe != nil {
+		area.MinSyringe = *req.MinSyringe
+	}
+	if req.MaxSyringe != nil {
+		area.MaxSyringe = *req.MaxSyringe
+	}
+	if err := validateSyringeRange(area.MinSyringe, area.MaxSyringe); err != nil {
+		return nil, err
+	}
+	if req.DurationMinutes != nil {
+		area.DurationMinutes = *req.DurationMinutes
+	}
+	if req.ExtraSyringeMinutes != nil {
+		area.ExtraSyringeMinutes = *req.ExtraSyringeMinutes
+	}
+	if err := validateSyringeDurations(area.DurationMinutes, area.ExtraSyringeMinutes); err != nil {
+		return nil, err
+	}
+
+	if err := db.Save(&area).Error; err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+// DeleteArea stages an area and its side areas for deletion in the draft; see DeleteTreatment.
+// Returns ErrCatalogInUse if any clinic or doctor still references it.
+func DeleteArea(id uint) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var area models.Area
+	if err := db.First(&area, id).Error; err != nil {
+		return err
+	}
+
+	inUse, err := catalogUsageCount(db, "area_id", id)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return ErrCatalogInUse
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SideArea{}).Where("area_id = ?", id).Update("status", models.CatalogStatusDeleted).Error; err != nil {
+			return err
+		}
+		return tx.Model(&area).Update("status", models.CatalogStatusDeleted).Error
+	})
+}
+
+// SetAreaStatus archives or restores an area together with its side areas.
+// Side areas staged for deletion stay staged.
+func SetAreaStatus(id uint, status string) (*models.Area, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateCatalogStatus(status); err != nil {
+		return nil, err
+	}
+
+	var area models.Area
+	if err := db.First(&area, id).Error; err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&area).Update("status", status).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.SideArea{}).Where("area_id = ? AND status <> ?", id, models.CatalogStatusDeleted).Update("status", status).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &area, nil
+}
+
+// CreateSideArea creates a side area under an area and marks the area as having side areas
+func CreateSideArea(areaID uint, req reqdto.CreateSideAreaRequest) (*models.SideArea, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidCatalogItem)
+	}
+
+	var area models.Area
+	if err := db.First(&area, areaID).Error; err != nil {
+		return nil, err
+	}
+
+	if req.MinSyringe == 0 {
+		req.MinSyringe = 1
+	}
+	if req.MaxSyringe == 0 {
+		req.MaxSyringe = req.MinSyringe
+	}
+	if err := validateSyringeRange(req.MinSyringe, req.MaxSyringe); err != nil {
+		return nil, err
+	}
+	if err := validateSyringeDurations(req.DurationMinutes, req.ExtraSyringeMinutes); err != nil {
+		return nil, err
+	}
+
+	sideArea := models.SideArea{
+		TreatmentID: area.TreatmentID,
+		AreaID:      area.ID,
+		Code:        catalogCode(req.Code),
+		Name:        name,
+		Icon:        req.Icon,
+		Description: req.Description,
+		MinSyringe:  req.MinSyringe,
+		MaxSyringe:  req.MaxSyringe,
+		Status:      models.CatalogStatusActive,
+
+		DurationMinutes:     req.DurationMinutes,
+		ExtraSyringeMinutes: req.ExtraSyringeMinutes,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&sideArea).Error; err != nil {
+			return err
+		}
+		if !area.IsSideArea {
+			return tx.Model(&area).Update("is_side_area", true).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sideArea, nil
+}
+
+// UpdateSideArea updates the provided fields of a side area
+func UpdateSideArea(id uint, req reqdto.UpdateSideAreaRequest) (*models.SideArea, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+
+	var sideArea models.SideArea
+	if err := db.First(&sideArea, id).Error; err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidCatalogItem)
+		}
+		sideArea.Name = name
+	}
+	if req.Code != nil {
+		sideArea.Code = catalogCode(*req.Code)
+	}
+	if req.Icon != nil {
+		sideArea.Icon = *req.Icon
+	}
+	if req.Description != nil {
+		sideArea.Description = *req.Description
+	}
+	if req.MinSyringe != nil {
+		sideArea.MinSyringe = *req.MinSyringe
+	}
+	if req.MaxSyringe != nil {
+		sideArea.MaxSyringe = *req.MaxSyringe
+	}
+	if err := validateSyringeRange(sideArea.MinSyringe, sideArea.MaxSyringe); err != nil {
+		return nil, err
+	}
+	if req.DurationMinutes != nil {
+		sideArea.DurationMinutes = *req.DurationMinutes
+	}
+	if req.ExtraSyringeMinutes != nil {
+		sideArea.ExtraSyringeMinutes = *req.ExtraSyringeMinutes
+	}
+	if err := validateSyringeDurations(sideArea.DurationMinutes, sideArea.ExtraSyringeMinutes); err != nil {
+		return nil, err
+	}
+
+	if err := db.Save(&sideArea).Error; err != nil {
+		return nil, err
+	}
+	return &sideArea, nil
+}
+
+// DeleteSideArea stages a side area for deletion in the draft; see DeleteTreatment.
+// Returns ErrCatalogInUse if any clinic or doctor still references it.
+func DeleteSideArea(id uint) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+
+	var sideArea models.SideArea
+	if err := db.First(&sideArea, id).Error; err != nil {
+		return err
+	}
+
+	inUse, err := catalogUsageCount(db, "side_area_id", id)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return ErrCatalogInUse
+	}
+
+	return db.Model(&sideArea).Update("status", models.CatalogStatusDeleted).Error
+}
+
+// SetSideAreaStatus archives or restores a single side area
+func SetSideAreaStatus(id uint, status string) (*models.SideArea, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := validateCatalogStatus(status); err != nil {
+		return nil, err
+	}
+
+	var sideArea models.SideArea
+	if err := db.First(&sideArea, id).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&sideArea).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+	return &sideArea, nil
+}
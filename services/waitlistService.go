@@ -0,0 +1,673 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"skinSync/config"
+	reqdto "skinSync/dto/request"
+	resdto "skinSync/dto/response"
+	"skinSync/models"
+	"skinSync/utils"
+
+	"github.com/dgrijalva/jwt-go"
+	"gorm.io/gorm"
+)
+
+// ==================== WAITLIST ====================
+
+const (
+	maxWaitlistDays       = 90
+	maxActiveWaitlist     = 10
+	waitlistScanPeriod    = time.Minute
+	waitlistOfferPurpose  = "waitlist_offer"
+	waitlistCandidateScan = 50
+	waitlistAuditJob      = "waitlist-offers" // audit route of offers and expiries made by the waitlist job
+)
+
+var (
+	// ErrWaitlistDuplicate is returned when the customer already waits for the same practitioner and treatment
+	ErrWaitlistDuplicate = errors.New("you are already on this waitlist")
+	// ErrWaitlistOfferClosed is returned when claiming or declining an offer that was answered or has lapsed
+	ErrWaitlistOfferClosed = errors.New("this offer is no longer available")
+)
+
+// waitlistActiveStatuses are the entry statuses that hold a place in line
+var waitlistActiveStatuses = []string{models.WaitlistWaiting, models.WaitlistOffered}
+
+// getWaitlistClaimWindow returns how long a customer has to claim an offered slot (WAITLIST_CLAIM_MINUTES, default 30)
+func getWaitlistClaimWindow() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("WAITLIST_CLAIM_MINUTES")); err == nil && v > 0 {
+		return time.Duration(v) * time.Minute
+	}
+	return 30 * time.Minute
+}
+
+// generateWaitlistOfferToken signs the claim link token of an offer, valid until the offer expires
+func generateWaitlistOfferToken(offer *models.WaitlistOffer) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"purpose":  waitlistOfferPurpose,
+		"offer_id": offer.ID,
+		"exp":      offer.ExpiresAt.Unix(),
+	})
+	return token.SignedString(appointmentActionSecret())
+}
+
+// parseWaitlistOfferToken verifies a claim link token and returns its offer id
+func parseWaitlistOfferToken(tokenString string) (uint64, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidActionLink
+		}
+		return appointmentActionSecret(), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, ErrInvalidActionLink
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != waitlistOfferPurpose {
+		return 0, ErrInvalidActionLink
+	}
+	id, ok := claims["offer_id"].(float64)
+	if !ok || id <= 0 {
+		return 0, ErrInvalidActionLink
+	}
+	return uint64(id), nil
+}
+
+// waitlistItemRequests converts an entry's stored side areas back to booking items
+func waitlistItemRequests(items []models.WaitlistItem) []reqdto.AppointmentItemRequest {
+	out := make([]reqdto.AppointmentItemRequest, 0, len(items))
+	for _, it := range items {
+		out = append(out, reqdto.AppointmentItemRequest{SideAreaID: it.SideAreaID, SyringeCount: it.SyringeCount, SyringeSize: it.SyringeSize})
+	}
+	return out
+}
+
+// waitlistEntryDTOs adds names, line positions and open offers to waitlist entries
+func waitlistEntryDTOs(db *gorm.DB, entries []models.WaitlistEntry) ([]resdto.WaitlistEntryDTO, error) {
+	out := make([]resdto.WaitlistEntryDTO, 0, len(entries))
+	if len(entries) == 0 {
+		return out, nil
+	}
+	var clinicIDs, userIDs, practitionerIDs, entryIDs []uint64
+	var treatmentIDs []uint
+	for _, e := range entries {
+		clinicIDs = append(clinicIDs, e.ClinicID)
+		userIDs = append(userIDs, e.UserID)
+		practitionerIDs = append(practitionerIDs, e.PractitionerID)
+		treatmentIDs = append(treatmentIDs, e.TreatmentID)
+		entryIDs = append(entryIDs, e.ID)
+	}
+
+	var clinics []models.Clinic
+	if err := db.Unscoped().Select("id", "name", "timezone").Where("id IN ?", clinicIDs).Find(&clinics).Error; err != nil {
+		return nil, err
+	}
+	clinicByID := make(map[uint64]models.Clinic, len(clinics))
+	for _, c := range clinics {
+		clinicByID[c.ID] = c
+	}
+	var profiles []models.UserProfile
+	if err := db.Select("user_id", "name").Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	nameByUser := make(map[uint64]string, len(profiles))
+	for _, p := range profiles {
+		nameByUser[p.UserID] = p.Name
+	}
+	var practitioners []models.ClinicUser
+	if err := db.Unscoped().Select("id", "name").Where("id IN ?", practitionerIDs).Find(&practitioners).Error; err != nil {
+		return nil, err
+	}
+	practitionerByID := make(map[uint64]string, len(practitioners))
+	for _, p := range practitioners {
+		practitionerByID[p.ID] = p.Name
+	}
+	var treatments []models.Treatment
+	if err := db.Select("id", "name").Where("id IN ?", treatmentIDs).Find(&treatments).Error; err != nil {
+		return nil, err
+	}
+	treatmentByID := make(map[uint]string, len(treatments))
+	for _, t := range treatments {
+		treatmentByID[t.ID] = t.Name
+	}
+	var offers []models.WaitlistOffer
+	if err := db.Where("entry_id IN ? AND status = ?", entryIDs, models.WaitlistOfferPending).Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	offerByEntry := make(map[uint64]*models.WaitlistOffer, len(offers))
+	for i, o := range offers {
+		offerByEntry[o.EntryID] = &offers[i]
+	}
+
+	for _, e := range entries {
+		dto := resdto.WaitlistEntryDTO{
+			WaitlistEntry:    e,
+			ClinicName:       clinicByID[e.ClinicID].Name,
+			Timezone:         clinicByID[e.ClinicID].Timezone,
+			PractitionerName: practitionerByID[e.PractitionerID],
+			TreatmentName:    treatmentByID[e.TreatmentID],
+			CustomerName:     nameByUser[e.UserID],
+			Offer:            offerByEntry[e.ID],
+		}
+		if e.Status == models.WaitlistWaiting || e.Status == models.WaitlistOffered {
+			var ahead int64
+			if err := db.Model(&models.WaitlistEntry{}).
+				Where("clinic_id = ? AND practitioner_id = ? AND status IN ? AND id < ?", e.ClinicID, e.PractitionerID, waitlistActiveStatuses, e.ID).
+				Count(&ahead).Error; err != nil {
+				return nil, err
+			}
+			dto.Position = int(ahead) + 1
+		}
+		out = append(out, dto)
+	}
+	return out, nil
+}
+
+// JoinWaitlist adds the customer to a practitioner's waitlist for a treatment and date range
+func JoinWaitlist(userID uint64, req reqdto.JoinWaitlistRequest) (*resdto.WaitlistEntryDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	if err := checkBookableCustomer(db, userID); err != nil {
+		return nil, err
+	}
+	clinic, err := checkBookableClinic(db, req.ClinicID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBookablePractitioner(db, req.ClinicID, req.PractitionerID); err != nil {
+		return nil, err
+	}
+	items, _, err := resolveAppointmentItems(db, req.ClinicID, req.TreatmentID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPractitionerQualified(db, req.ClinicID, req.PractitionerID, req.TreatmentID, items); err != nil {
+		return nil, err
+	}
+
+	from, err := parseScheduleDate(req.FromDate)
+	if err != nil {
+		return nil, fmt.Errorf("from_date: %w", err)
+	}
+	to, err := parseScheduleDate(req.ToDate)
+	if err != nil {
+		return nil, fmt.Errorf("to_date: %w", err)
+	}
+	today := time.Now().In(clinicLocation(clinic)).Format(scheduleDateLayout)
+	if from.Format(scheduleDateLayout) < today {
+		return nil, errors.New("from_date must not be in the past")
+	}
+	if to.Before(from) {
+		return nil, errors.New("to_date must not be before from_date")
+	}
+	if to.Sub(from) > maxWaitlistDays*24*time.Hour {
+		return nil, fmt.Errorf("the date range must be at most %d days", maxWaitlistDays)
+	}
+
+	// The active-entry limit spans the customer's clinics; the entry itself goes through the clinic's scoped handle
+	var active int64
+	if err := db.Model(&models.WaitlistEntry{}).Where("user_id = ? AND status IN ?", userID, waitlistActiveStatuses).Count(&active).Error; err != nil {
+		return nil, err
+	}
+	if active >= maxActiveWaitlist {
+		return nil, fmt.Errorf("you can wait for at most %d slots at a time", maxActiveWaitlist)
+	}
+	clinicDB := config.ClinicDB(req.ClinicID)
+	var duplicates int64
+	if err := clinicDB.Model(&models.WaitlistEntry{}).
+		Where("user_id = ? AND practitioner_id = ? AND treatment_id = ? AND status IN ?",
+			userID, req.PractitionerID, req.TreatmentID, waitlistActiveStatuses).
+		Count(&duplicates).Error; err != nil {
+		return nil, err
+	}
+	if duplicates > 0 {
+		return nil, ErrWaitlistDuplicate
+	}
+
+	entry := models.WaitlistEntry{
+		ClinicID:       req.ClinicID,
+		UserID:         userID,
+		PractitionerID: req.PractitionerID,
+		TreatmentID:    req.TreatmentID,
+		FromDate:       from.Format(scheduleDateLayout),
+		ToDate:         to.Format(scheduleDateLayout),
+		Status:         models.WaitlistWaiting,
+	}
+	for _, it := range items {
+		entry.Items = append(entry.Items, models.WaitlistItem{SideAreaID: it.SideAreaID, SyringeCount: it.SyringeCount, SyringeSize: it.SyringeSize})
+	}
+	if err := clinicDB.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	dtos, err := waitlistEntryDTOs(db, []models.WaitlistEntry{entry})
+	if err != nil {
+		return nil, err
+	}
+	return &dtos[0], nil
+}
+
+// ListWaitlistEntries returns waitlist entries in line order; non-zero clinicID/userID/practitionerID narrow them
+func ListWaitlistEntries(clinicID, userID, practitionerID uint64, status string) ([]resdto.WaitlistEntryDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	// Clinic listings use the clinic's scoped handle; customer listings span clinics
+	if clinicID != 0 {
+		db = config.ClinicDB(clinicID)
+	}
+	query := db.Preload("Items")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if practitionerID != 0 {
+		query = query.Where("practitioner_id = ?", practitionerID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var entries []models.WaitlistEntry
+	if err := query.Order("id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return waitlistEntryDTOs(db, entries)
+}
+
+// LeaveWaitlist cancels the customer's waitlist entry; an open offer passes to the next in line
+func LeaveWaitlist(userID, id uint64) error {
+	db := config.DB
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	var entry models.WaitlistEntry
+	if err := db.Where("user_id = ?", userID).First(&entry, id).Error; err != nil {
+		return err
+	}
+	res := db.Model(&models.WaitlistEntry{}).Where("id = ? AND status IN ?", id, waitlistActiveStatuses).
+		Update("status", models.WaitlistCancelled)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("only waiting entries can be cancelled")
+	}
+
+	var offer models.WaitlistOffer
+	err := db.Where("entry_id = ? AND status = ?", id, models.WaitlistOfferPending).First(&offer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	closeWaitlistOffer(db, &offer, models.WaitlistOfferDeclined,
+		auditSource{Actor: AuditActor{Type: models.AuditActorCustomer, ID: userID}, Method: http.MethodDelete, Route: "/v1/waitlist/:id"})
+	return nil
+}
+
+// findWaitlistSlot returns the first slot within the opening the entry's selection fits, or nil
+func findWaitlistSlot(entry *models.WaitlistEntry, date string, openStart, openEnd time.Time) (*resdto.AvailableSlotDTO, error) {
+	slots, err := ListAvailableSlots(entry.ClinicID, SlotQuery{
+		TreatmentID:    entry.TreatmentID,
+		PractitionerID: entry.PractitionerID,
+		Date:           date,
+		Days:           1,
+		Items:          waitlistItemRequests(entry.Items),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range slots.Slots {
+		if s.PractitionerID == entry.PractitionerID && !s.StartAt.Before(openStart) && s.StartAt.Before(openEnd) {
+			return &slots.Slots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// offerWaitlistOpening offers a freed practitioner time to the first waiting entry whose date range
+// covers it and whose selection fits, skipping entries already offered this opening.
+// Only one offer per opening is open at a time.
+func offerWaitlistOpening(db *gorm.DB, clinicID, practitionerID uint64, openStart, openEnd time.Time) {
+	now := time.Now()
+	if !openStart.After(now) {
+		return
+	}
+	var open int64
+	if err := db.Model(&models.WaitlistOffer{}).
+		Where("practitioner_id = ? AND opening_start = ? AND status = ?", practitionerID, openStart, models.WaitlistOfferPending).
+		Count(&open).Error; err != nil || open > 0 {
+		return
+	}
+	clinic, err := checkBookableClinic(db, clinicID)
+	if err != nil {
+		return
+	}
+	date := openStart.In(clinicLocation(clinic)).Format(scheduleDateLayout)
+
+	var candidates []models.WaitlistEntry
+	if err := db.Preload("Items").
+		Where("clinic_id = ? AND practitioner_id = ? AND status = ? AND from_date <= ? AND to_date >= ?",
+			clinicID, practitionerID, models.WaitlistWaiting, date, date).
+		Where("id NOT IN (?)", db.Model(&models.WaitlistOffer{}).Select("entry_id").
+			Where("practitioner_id = ? AND opening_start = ?", practitionerID, openStart)).
+		Order("id").Limit(waitlistCandidateScan).Find(&candidates).Error; err != nil {
+		log.Printf("waitlist: failed to load candidates: %v", err)
+		return
+	}
+
+	for i := range candidates {
+		entry := &candidates[i]
+		slot, err := findWaitlistSlot(entry, date, openStart, openEnd)
+		if err != nil {
+			log.Printf("waitlist entry %d: %v", entry.ID, err)
+			continue
+		}
+		if slot == nil {
+			continue
+		}
+
+		expires := now.Add(getWaitlistClaimWindow())
+		if slot.StartAt.Before(expires) {
+			expires = slot.StartAt
+		}
+		offer := models.WaitlistOffer{
+			EntryID:        entry.ID,
+			ClinicID:       clinicID,
+			PractitionerID: practitionerID,
+			OpeningStart:   openStart,
+			OpeningEnd:     openEnd,
+			StartAt:        slot.StartAt,
+			EndAt:          slot.EndAt,
+			Status:         models.WaitlistOfferPending,
+			ExpiresAt:      expires,
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			res := tx.Model(&models.WaitlistEntry{}).Where("id = ? AND status = ?", entry.ID, models.WaitlistWaiting).
+				Update("status", models.WaitlistOffered)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrWaitlistOfferClosed
+			}
+			return tx.Create(&offer).Error
+		})
+		if errors.Is(err, ErrWaitlistOfferClosed) {
+			continue
+		}
+		if err != nil {
+			log.Printf("waitlist entry %d: failed to create offer: %v", entry.ID, err)
+			return
+		}
+		systemAuditSource(waitlistAuditJob).record("create", "waitlist_offer", offer.ID, &offer.ClinicID, nil,
+			map[string]interface{}{"entry_id": offer.EntryID, "start_at": offer.StartAt, "expires_at": offer.ExpiresAt, "status": offer.Status})
+		notifyWaitlistOffer(db, entry, &offer, clinic.Timezone)
+		return
+	}
+}
+
+// notifyWaitlistOffer emails the customer the claim link of an offer; failures are only logged
+// and the offer lapses to the next in line when it expires
+func notifyWaitlistOffer(db *gorm.DB, entry *models.WaitlistEntry, offer *models.WaitlistOffer, timezone string) {
+	dtos, err := waitlistEntryDTOs(db, []models.WaitlistEntry{*entry})
+	if err != nil {
+		log.Printf("waitlist offer %d: failed to load entry: %v", offer.ID, err)
+		return
+	}
+	dto := dtos[0]
+	var user models.User
+	if err := db.Select("id", "primary_email").First(&user, entry.UserID).Error; err != nil || user.PrimaryEmail == nil {
+		log.Printf("waitlist offer %d: customer has no email address", offer.ID)
+		return
+	}
+	token, err := generateWaitlistOfferToken(offer)
+	if err != nil {
+		log.Printf("waitlist offer %d: failed to sign link: %v", offer.ID, err)
+		return
+	}
+	name := dto.CustomerName
+	if name == "" {
+		name = "there"
+	}
+	claimURL := getAppBaseURL() + "/waitlist/offers?token=" + url.QueryEscape(token)
+	if err := utils.SendWaitlistOfferEmail(*user.PrimaryEmail, name, dto.ClinicName, dto.TreatmentName, dto.PractitionerName,
+		formatAppointmentTime(offer.StartAt, timezone), formatAppointmentTime(offer.ExpiresAt, timezone), claimURL); err != nil {
+		log.Printf("waitlist offer %d: failed to send email: %v", offer.ID, err)
+	}
+}
+
+// closeWaitlistOffer ends a pending offer, puts its entry back in line when it is still active,
+// and passes the opening on to the next entry. The change is audited as made by source.
+func closeWaitlistOffer(db *gorm.DB, offer *models.WaitlistOffer, status string, source auditSource) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.WaitlistOffer{}).Where("id = ? AND status = ?", offer.ID, models.WaitlistOfferPending).
+			Updates(map[string]interface{}{"status": status, "responded_at": time.Now().UTC()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrWaitlistOfferClosed
+		}
+		return tx.Model(&models.WaitlistEntry{}).Where("id = ? AND status = ?", offer.EntryID, models.WaitlistOffered).
+			Update("status", models.WaitlistWaiting).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrWaitlistOfferClosed) {
+			log.Printf("waitlist offer %d: failed to close: %v", offer.ID, err)
+		}
+		return
+	}
+	source.record("update", "waitlist_offer", offer.ID, &offer.ClinicID,
+		map[string]interface{}{"status": models.WaitlistOfferPending}, map[string]interface{}{"status": status})
+	offerWaitlistOpening(db, offer.ClinicID, offer.PractitionerID, offer.OpeningStart, offer.OpeningEnd)
+}
+
+// StartWaitlistOffers runs a background goroutine that lapses unclaimed offers to the next customer
+// in line and expires entries whose date range has passed
+func StartWaitlistOffers() {
+	go func() {
+		for {
+			time.Sleep(waitlistScanPeriod)
+			if err := expireWaitlist(time.Now()); err != nil {
+				log.Printf("waitlist: %v", err)
+			}
+		}
+	}()
+}
+
+// expireWaitlist closes offers past their claim deadline and entries past their last date
+func expireWaitlist(now time.Time) error {
+	db := config.DB
+	if db == nil {
+		return nil
+	}
+	var offers []models.WaitlistOffer
+	if err := db.Where("status = ? AND expires_at <= ?", models.WaitlistOfferPending, now).Order("id").Find(&offers).Error; err != nil {
+		return err
+	}
+	source := systemAuditSource(waitlistAuditJob)
+	for i := range offers {
+		closeWaitlistOffer(db, &offers[i], models.WaitlistOfferExpired, source)
+	}
+	// Dates are in the clinic's timezone; a day's margin covers every zone
+	yesterday := now.UTC().AddDate(0, 0, -1).Format(scheduleDateLayout)
+	var entries []models.WaitlistEntry
+	if err := db.Select("id", "clinic_id").Where("status = ? AND to_date < ?", models.WaitlistWaiting, yesterday).
+		Order("id").Find(&entries).Error; err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		res := db.Model(&models.WaitlistEntry{}).Where("id = ? AND status = ?", entry.ID, models.WaitlistWaiting).
+			Update("status", models.WaitlistExpired)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			source.record("update", "waitlist_entry", entry.ID, &entry.ClinicID,
+				map[string]interface{}{"status": models.WaitlistWaiting}, map[string]interface{}{"status": models.WaitlistExpired})
+		}
+	}
+	return nil
+}
+
+// loadWaitlistOffer resolves a claim link to its offer and entry
+func loadWaitlistOffer(db *gorm.DB, token string) (*models.WaitlistOffer, *models.WaitlistEntry, error) {
+	id, err := parseWaitlistOfferToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	var offer models.WaitlistOffer
+	if err := db.First(&offer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidActionLink
+		}
+		return nil, nil, err
+	}
+	var entry models.WaitlistEntry
+	if err := db.Preload("Items").First(&entry, offer.EntryID).Error; err != nil {
+		return nil, nil, err
+	}
+	return &offer, &entry, nil
+}
+
+// waitlistOfferDTO builds the claim page view of an offer
+func waitlistOfferDTO(db *gorm.DB, offer *models.WaitlistOffer, entry *models.WaitlistEntry) (*resdto.WaitlistOfferDTO, error) {
+	dtos, err := waitlistEntryDTOs(db, []models.WaitlistEntry{*entry})
+	if err != nil {
+		return nil, err
+	}
+	out := &resdto.WaitlistOfferDTO{
+		WaitlistOffer:    *offer,
+		ClinicName:       dtos[0].ClinicName,
+		Timezone:         dtos[0].Timezone,
+		PractitionerName: dtos[0].PractitionerName,
+		TreatmentName:    dtos[0].TreatmentName,
+		Claimable:        offer.Status == models.WaitlistOfferPending && time.Now().Before(offer.ExpiresAt),
+	}
+	if offer.AppointmentID != nil {
+		if out.Appointment, err = appointmentDTO(db, *offer.AppointmentID, models.AuditActorCustomer); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetWaitlistOffer returns the offer behind a claim link.
+// The claim token is the credential and names the clinic, so the offer paths load through the unscoped DB.
+func GetWaitlistOffer(token string) (*resdto.WaitlistOfferDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	offer, entry, err := loadWaitlistOffer(db, token)
+	if err != nil {
+		return nil, err
+	}
+	return waitlistOfferDTO(db, offer, entry)
+}
+
+// ClaimWaitlistOffer books the offered slot for the waiting customer. When the slot has been
+// taken in the meantime the offer is closed and the opening passes to the next in line.
+func ClaimWaitlistOffer(token string) (*resdto.WaitlistOfferDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	offer, entry, err := loadWaitlistOffer(db, token)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.WaitlistOfferPending || !time.Now().Before(offer.ExpiresAt) {
+		return nil, ErrWaitlistOfferClosed
+	}
+
+	source := auditSource{Actor: AuditActor{Type: models.AuditActorCustomer, ID: entry.UserID}, Method: http.MethodPost, Route: "/waitlist/offers/claim"}
+
+	// Take the offer first so concurrent claims cannot both book
+	res := db.Model(&models.WaitlistOffer{}).Where("id = ? AND status = ?", offer.ID, models.WaitlistOfferPending).
+		Updates(map[string]interface{}{"status": models.WaitlistOfferClaimed, "responded_at": time.Now().UTC()})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrWaitlistOfferClosed
+	}
+
+	appt := models.Appointment{
+		ClinicID:       entry.ClinicID,
+		UserID:         entry.UserID,
+		PractitionerID: entry.PractitionerID,
+		TreatmentID:    entry.TreatmentID,
+		StartAt:        offer.StartAt,
+		Status:         models.AppointmentRequested,
+		CreatedByType:  models.AuditActorCustomer,
+		CreatedByID:    entry.UserID,
+	}
+	if err := createAppointment(db, &appt, waitlistItemRequests(entry.Items), 0, true); err != nil {
+		if err := db.Model(&models.WaitlistOffer{}).Where("id = ?", offer.ID).Update("status", models.WaitlistOfferUnavailable).Error; err != nil {
+			log.Printf("waitlist offer %d: failed to close: %v", offer.ID, err)
+		} else {
+			source.record("update", "waitlist_offer", offer.ID, &offer.ClinicID,
+				map[string]interface{}{"status": models.WaitlistOfferPending}, map[string]interface{}{"status": models.WaitlistOfferUnavailable})
+		}
+		if err := db.Model(&models.WaitlistEntry{}).Where("id = ? AND status = ?", entry.ID, models.WaitlistOffered).
+			Update("status", models.WaitlistWaiting).Error; err != nil {
+			log.Printf("waitlist entry %d: failed to requeue: %v", entry.ID, err)
+		}
+		offerWaitlistOpening(db, offer.ClinicID, offer.PractitionerID, offer.OpeningStart, offer.OpeningEnd)
+		return nil, err
+	}
+
+	if err := db.Model(&models.WaitlistOffer{}).Where("id = ?", offer.ID).Update("appointment_id", appt.ID).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.WaitlistEntry{}).Where("id = ?", entry.ID).
+		Updates(map[string]interface{}{"status": models.WaitlistBooked, "appointment_id": appt.ID}).Error; err != nil {
+		return nil, err
+	}
+	source.record("create", "appointment", appt.ID, &appt.ClinicID, nil, map[string]interface{}{
+		"waitlist_offer_id": offer.ID,
+		"practitioner_id":   appt.PractitionerID,
+		"treatment_id":      appt.TreatmentID,
+		"start_at":          appt.StartAt,
+		"status":            appt.Status,
+	})
+	processAppointmentCharges(db, appt.ID)
+	notifyAppointmentCustomer(appt.ID, models.AppointmentRequested, "")
+
+	offer.Status = models.WaitlistOfferClaimed
+	offer.AppointmentID = &appt.ID
+	return waitlistOfferDTO(db, offer, entry)
+}
+
+// DeclineWaitlistOffer turns down an offer; the customer keeps their place for later openings
+func DeclineWaitlistOffer(token string) (*resdto.WaitlistOfferDTO, error) {
+	db := config.DB
+	if db == nil {
+		return nil, errors.New("database not initialized")
+	}
+	offer, entry, err := loadWaitlistOffer(db, token)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != models.WaitlistOfferPending {
+		return nil, ErrWaitlistOfferClosed
+	}
+	closeWaitlistOffer(db, offer, models.WaitlistOfferDeclined,
+		auditSource{Actor: AuditActor{Type: models.AuditActorCustomer, ID: entry.UserID}, Method: http.MethodPost, Route: "/waitlist/offers/decline"})
+	if err := db.First(offer, offer.ID).Error; err != nil {
+		return nil, err
+	}
+	return waitlistOfferDTO(db, offer, entry)
+}
@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/smtp"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // SendOTPEmail sends OTP to the specified email address
@@ -146,3 +150,243 @@ SkinSync Team
 
 	return nil
 }
+
+// SendClinicApplicationStatusEmail tells a clinic applicant where their application stands.
+// status is one of submitted, resubmitted, info_requested, approved or rejected; note is the reviewer's message.
+func SendClinicApplicationStatusEmail(toEmail, ownerName, clinicName string, applicationID uint64, status, note string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	fromEmail := os.Getenv("SMTP_FROM")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPassword == "" {
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	if fromEmail == "" {
+		fromEmail = smtpUser
+	}
+
+	var subject, summary string
+	switch status {
+	case "submitted":
+		subject = "SkinSync - Clinic Application Received"
+		summary = "We have received your application and our team will review it shortly."
+	case "resubmitted":
+		subject = "SkinSync - Clinic Application Updated"
+		summary = "Thanks for the additional information. Your application is back in our review queue."
+	case "info_requested":
+		subject = "SkinSync - More Information Needed for Your Clinic Application"
+		summary = "Our team needs more information before we can continue reviewing your application. Please reply through the application page using the access token you received when applying."
+	case "approved":
+		subject = "SkinSync - Clinic Application Approved"
+		summary = "Your application has been approved. Your login credentials are being sent in a separate email."
+	case "rejected":
+		subject = "SkinSync - Clinic Application Update"
+		summary = "Unfortunately we are unable to approve your application at this time."
+	default:
+		return fmt.Errorf("unknown application status %q", status)
+	}
+
+	noteBlock := ""
+	if note != "" {
+		noteBlock = fmt.Sprintf("\nMessage from our team:\n%s\n", note)
+	}
+
+	body := fmt.Sprintf(`
+Hello %s,
+
+Application #%d for "%s":
+
+%s
+%s
+Thanks,
+SkinSync Team
+`, ownerName, applicationID, clinicName, summary, noteBlock)
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		fromEmail, toEmail, subject, body)
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPassword, smtpHost)
+
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, []byte(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendAppointmentStatusEmail notifies a customer that their appointment was requested, confirmed, rescheduled or cancelled.
+// when is the appointment start already formatted in the clinic's timezone; a non-empty ics is attached as invite.ics.
+func SendAppointmentStatusEmail(toEmail, customerName, clinicName, treatmentName, practitionerName, when string, appointmentID uint64, status, note, ics string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	fromEmail := os.Getenv("SMTP_FROM")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPassword == "" {
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	if fromEmail == "" {
+		fromEmail = smtpUser
+	}
+
+	var subject, summary string
+	switch status {
+	case "requested":
+		subject = "SkinSync - Appointment Request Received"
+		summary = "We have sent your request to the clinic. You will receive another email once it is confirmed."
+	case "confirmed":
+		subject = "SkinSync - Appointment Confirmed"
+		summary = "Your appointment is confirmed. We look forward to seeing you."
+	case "rescheduled":
+		subject = "SkinSync - Appointment Rescheduled"
+		summary = "The clinic has changed your appointment. The updated details are below."
+	case "cancelled":
+		subject = "SkinSync - Appointment Cancelled"
+		summary = "Your appointment has been cancelled."
+	default:
+		return fmt.Errorf("unknown appointment status %q", status)
+	}
+
+	noteBlock := ""
+	if note != "" {
+		noteBlock = fmt.Sprintf("\nNote:\n%s\n", note)
+	}
+
+	body := fmt.Sprintf(`
+Hello %s,
+
+%s
+
+Appointment #%d
+Clinic: %s
+Treatment: %s
+Practitioner: %s
+When: %s
+%s
+Thanks,
+SkinSync Team
+`, customerName, summary, appointmentID, clinicName, treatmentName, practitionerName, when, noteBlock)
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		fromEmail, toEmail, subject, body)
+	if ics != "" {
+		message = withCalendarAttachment(fromEmail, toEmail, subject, body, ics)
+	}
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPassword, smtpHost)
+
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, []byte(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// withCalendarAttachment builds a multipart message with the text body and an iCalendar attachment
+func withCalendarAttachment(fromEmail, toEmail, subject, body, ics string) string {
+	boundary := "skinsync-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	encoded := base64.StdEncoding.EncodeToString([]byte(ics))
+	var lines strings.Builder
+	for len(encoded) > 76 {
+		lines.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	lines.WriteString(encoded)
+
+	return fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\n"+
+		"Content-Type: multipart/mixed; boundary=%q\r\n\r\n"+
+		"--%s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n"+
+		"--%s\r\nContent-Type: text/calendar; charset=utf-8; method=PUBLISH; name=\"invite.ics\"\r\n"+
+		"Content-Disposition: attachment; filename=\"invite.ics\"\r\nContent-Transfer-Encoding: base64\r\n\r\n%s\r\n"+
+		"--%s--\r\n",
+		fromEmail, toEmail, subject, boundary, boundary, body, boundary, lines.String(), boundary)
+}
+
+// SendAppointmentReminderEmail sends a rendered appointment reminder to the customer
+func SendAppointmentReminderEmail(toEmail, subject, body string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	fromEmail := os.Getenv("SMTP_FROM")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPassword == "" {
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	if fromEmail == "" {
+		fromEmail = smtpUser
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		fromEmail, toEmail, subject, body)
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPassword, smtpHost)
+
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, []byte(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// SendWaitlistOfferEmail offers a freed appointment slot to a waitlisted customer with a claim link
+func SendWaitlistOfferEmail(toEmail, customerName, clinicName, treatmentName, practitionerName, when, expires, claimURL string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	fromEmail := os.Getenv("SMTP_FROM")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPassword == "" {
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	if fromEmail == "" {
+		fromEmail = smtpUser
+	}
+
+	subject := "SkinSync - A Slot Opened Up For You"
+	body := fmt.Sprintf(`
+Hello %s,
+
+A slot you were waiting for is now available.
+
+Clinic: %s
+Treatment: %s
+Practitioner: %s
+When: %s
+
+Claim it before %s:
+%s
+
+If you do not claim it in time, it will be offered to the next person on the waitlist.
+
+Thanks,
+SkinSync Team
+`, customerName, clinicName, treatmentName, practitionerName, when, expires, claimURL)
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s",
+		fromEmail, toEmail, subject, body)
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPassword, smtpHost)
+
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, []byte(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
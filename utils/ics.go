@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarEvent is one VEVENT of an iCalendar (RFC 5545) document
+type CalendarEvent struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+	Sequence    int
+}
+
+const icsTimeLayout = "20060102T150405Z"
+
+// icsEscape escapes text property values
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsFold splits a content line into 75-octet lines as the format requires
+func icsFold(line string) string {
+	if len(line) <= 75 {
+		return line + "\r\n"
+	}
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Do not split a UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// BuildICS renders events as an iCalendar document; method is empty for subscription feeds
+// and PUBLISH for email attachments
+func BuildICS(calendarName, method string, events []CalendarEvent) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("PRODID:-//SkinSync//Appointments//EN\r\n")
+	b.WriteString("CALSCALE:GREGORIAN\r\n")
+	if method != "" {
+		b.WriteString("METHOD:" + method + "\r\n")
+	}
+	if calendarName != "" {
+		b.WriteString(icsFold("X-WR-CALNAME:" + icsEscape(calendarName)))
+	}
+	stamp := time.Now().UTC().Format(icsTimeLayout)
+	for _, e := range events {
+		b.WriteString("BEGIN:VEVENT\r\n")
+		b.WriteString(icsFold("UID:" + e.UID))
+		b.WriteString("DTSTAMP:" + stamp + "\r\n")
+		b.WriteString("DTSTART:" + e.Start.UTC().Format(icsTimeLayout) + "\r\n")
+		b.WriteString("DTEND:" + e.End.UTC().Format(icsTimeLayout) + "\r\n")
+		b.WriteString(icsFold("SUMMARY:" + icsEscape(e.Summary)))
+		if e.Description != "" {
+			b.WriteString(icsFold("DESCRIPTION:" + icsEscape(e.Description)))
+		}
+		if e.Location != "" {
+			b.WriteString(icsFold("LOCATION:" + icsEscape(e.Location)))
+		}
+		if e.Status != "" {
+			b.WriteString("STATUS:" + e.Status + "\r\n")
+		}
+		if e.Sequence > 0 {
+			b.WriteString("SEQUENCE:" + strconv.Itoa(e.Sequence) + "\r\n")
+		}
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale returns the locale stored in the base content columns (DEFAULT_LOCALE, default "en")
+func DefaultLocale() string {
+	if l := strings.ToLower(strings.TrimSpace(os.Getenv("DEFAULT_LOCALE"))); l != "" {
+		return l
+	}
+	return "en"
+}
+
+// SupportedLocales returns the locales content can be served in (SUPPORTED_LOCALES, default "en,ar,es")
+func SupportedLocales() []string {
+	raw := os.Getenv("SUPPORTED_LOCALES")
+	if strings.TrimSpace(raw) == "" {
+		raw = "en,ar,es"
+	}
+	locales := []string{DefaultLocale()}
+	for _, l := range strings.Split(raw, ",") {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l != "" && l != locales[0] {
+			locales = append(locales, l)
+		}
+	}
+	return locales
+}
+
+// IsSupportedLocale reports whether locale is one of SupportedLocales
+func IsSupportedLocale(locale string) bool {
+	locale = strings.ToLower(locale)
+	for _, l := range SupportedLocales() {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveLocale picks the best supported locale for an Accept-Language header.
+// Region subtags fall back to their language ("es-MX" -> "es"); anything else gets DefaultLocale.
+func ResolveLocale(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if IsSupportedLocale(c.tag) {
+			return c.tag
+		}
+		if i := strings.IndexAny(c.tag, "-_"); i > 0 && IsSupportedLocale(c.tag[:i]) {
+			return c.tag[:i]
+		}
+	}
+	return DefaultLocale()
+}